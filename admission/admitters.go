@@ -0,0 +1,40 @@
+package admission
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/lager"
+)
+
+// Admitters admits a spec only if each of its Admitters does, asking them in
+// order and stopping at the first denial
+type Admitters []gardener.Admitter
+
+func (admitters Admitters) Admit(ctx context.Context, log lager.Logger, spec garden.ContainerSpec) error {
+	for _, admitter := range admitters {
+		if err := admitter.Admit(ctx, log, spec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AdmitLimits asks those of its Admitters which can check new limits, in order
+// and stopping at the first denial
+func (admitters Admitters) AdmitLimits(log lager.Logger, handle string, limits garden.Limits) error {
+	for _, admitter := range admitters {
+		limitsAdmitter, ok := admitter.(gardener.LimitsAdmitter)
+		if !ok {
+			continue
+		}
+
+		if err := limitsAdmitter.AdmitLimits(log, handle, limits); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
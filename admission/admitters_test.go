@@ -0,0 +1,77 @@
+package admission_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/admission"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Admitters", func() {
+	var (
+		first, second *fakes.FakeAdmitter
+		admitters     admission.Admitters
+		logger        *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		first = new(fakes.FakeAdmitter)
+		second = new(fakes.FakeAdmitter)
+		admitters = admission.Admitters{first, second}
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	It("admits the spec when every admitter does", func() {
+		Expect(admitters.Admit(context.Background(), logger, garden.ContainerSpec{Handle: "some-handle"})).To(Succeed())
+
+		Expect(first.AdmitCallCount()).To(Equal(1))
+		Expect(second.AdmitCallCount()).To(Equal(1))
+		_, _, spec := second.AdmitArgsForCall(0)
+		Expect(spec.Handle).To(Equal("some-handle"))
+	})
+
+	It("stops at the first denial", func() {
+		denial := gardener.AdmissionDeniedError{Handle: "some-handle", Reason: "no"}
+		first.AdmitReturns(denial)
+
+		Expect(admitters.Admit(context.Background(), logger, garden.ContainerSpec{})).To(MatchError(denial))
+		Expect(second.AdmitCallCount()).To(Equal(0))
+	})
+
+	It("returns errors from later admitters", func() {
+		second.AdmitReturns(errors.New("boom"))
+		Expect(admitters.Admit(context.Background(), logger, garden.ContainerSpec{})).To(MatchError("boom"))
+	})
+
+	Describe("AdmitLimits", func() {
+		var limitsAdmitter *fakes.FakeLimitsAdmitter
+
+		BeforeEach(func() {
+			limitsAdmitter = new(fakes.FakeLimitsAdmitter)
+			admitters = admission.Admitters{first, limitsAdmitter}
+		})
+
+		It("asks the admitters which can check limits", func() {
+			limits := garden.Limits{Memory: garden.MemoryLimits{LimitInBytes: 1024}}
+			Expect(admitters.AdmitLimits(logger, "some-handle", limits)).To(Succeed())
+
+			Expect(limitsAdmitter.AdmitLimitsCallCount()).To(Equal(1))
+			_, handle, actualLimits := limitsAdmitter.AdmitLimitsArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(actualLimits).To(Equal(limits))
+		})
+
+		It("returns their denials", func() {
+			denial := gardener.AdmissionDeniedError{Handle: "some-handle", Reason: "no"}
+			limitsAdmitter.AdmitLimitsReturns(denial)
+
+			Expect(admitters.AdmitLimits(logger, "some-handle", garden.Limits{})).To(MatchError(denial))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"code.cloudfoundry.org/commandrunner"
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/lager"
+)
+
+// Decision is what an external admission plugin writes to stdout
+type Decision struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type externalBinaryAdmitter struct {
+	commandRunner commandrunner.CommandRunner
+	path          string
+	extraArg      []string
+}
+
+// NewExternalAdmitter returns an Admitter which runs the binary at path with
+// the container spec as JSON on stdin, less any image registry credentials,
+// and expects a Decision as JSON on stdout. Creation fails if the plugin fails
+// to run or to give a decision, and the plugin is killed if the create's
+// context is done before it decides.
+func NewExternalAdmitter(commandRunner commandrunner.CommandRunner, path string, extraArg []string) gardener.Admitter {
+	return &externalBinaryAdmitter{
+		commandRunner: commandRunner,
+		path:          path,
+		extraArg:      extraArg,
+	}
+}
+
+func (a *externalBinaryAdmitter) Admit(ctx context.Context, log lager.Logger, spec garden.ContainerSpec) error {
+	log = log.Session("external-admitter", lager.Data{"handle": spec.Handle})
+
+	// the plugin has no need for the registry credentials, so is not trusted
+	// with them
+	spec.Image.Username = ""
+	spec.Image.Password = ""
+
+	stdinBytes, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	args := append(append([]string{}, a.extraArg...), "--action", "admit", "--handle", spec.Handle)
+	cmd := exec.CommandContext(ctx, a.path, args...)
+	stdout := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	cmd.Stdin = bytes.NewReader(stdinBytes)
+
+	err = a.commandRunner.Run(cmd)
+
+	logData := lager.Data{"stderr": stderr.String(), "stdout": stdout.String()}
+	if err != nil {
+		log.Error("external-admitter-result", err, logData)
+		if ctx.Err() != nil {
+			return fmt.Errorf("external admission plugin: %s", ctx.Err())
+		}
+
+		return fmt.Errorf("external admission plugin: %s", err)
+	}
+
+	var decision Decision
+	if err := json.Unmarshal(stdout.Bytes(), &decision); err != nil {
+		log.Error("external-admitter-result", err, logData)
+		return fmt.Errorf("unmarshaling result from external admission plugin: %s", err)
+	}
+
+	log.Debug("external-admitter-result", logData)
+
+	if !decision.Allowed {
+		reason := decision.Reason
+		if reason == "" {
+			reason = "denied by admission plugin"
+		}
+
+		return gardener.AdmissionDeniedError{Handle: spec.Handle, Reason: reason}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package admission_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os/exec"
+
+	"code.cloudfoundry.org/commandrunner/fake_command_runner"
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/admission"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ExternalAdmitter", func() {
+	var (
+		fakeCommandRunner *fake_command_runner.FakeCommandRunner
+		logger            *lagertest.TestLogger
+		admitter          gardener.Admitter
+		spec              garden.ContainerSpec
+		pluginOutput      string
+		pluginErr         error
+	)
+
+	BeforeEach(func() {
+		fakeCommandRunner = fake_command_runner.New()
+		logger = lagertest.NewTestLogger("test")
+		admitter = admission.NewExternalAdmitter(fakeCommandRunner, "some/path", []string{"arg1", "arg2"})
+		spec = garden.ContainerSpec{Handle: "some-handle", Privileged: true}
+
+		pluginOutput = `{"allowed": true}`
+		pluginErr = nil
+		fakeCommandRunner.WhenRunning(fake_command_runner.CommandSpec{
+			Path: "some/path",
+		}, func(cmd *exec.Cmd) error {
+			cmd.Stdout.Write([]byte(pluginOutput))
+			cmd.Stderr.Write([]byte("some-stderr-bytes"))
+			return pluginErr
+		})
+	})
+
+	It("executes the plugin with the correct args", func() {
+		Expect(admitter.Admit(context.Background(), logger, spec)).To(Succeed())
+
+		cmd := fakeCommandRunner.ExecutedCommands()[0]
+		Expect(cmd.Path).To(Equal("some/path"))
+		Expect(cmd.Args).To(Equal([]string{
+			"some/path",
+			"arg1",
+			"arg2",
+			"--action", "admit",
+			"--handle", "some-handle",
+		}))
+	})
+
+	It("passes the container spec to the plugin's stdin", func() {
+		Expect(admitter.Admit(context.Background(), logger, spec)).To(Succeed())
+
+		cmd := fakeCommandRunner.ExecutedCommands()[0]
+		input, err := ioutil.ReadAll(cmd.Stdin)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(input).To(MatchJSON(mustMarshalJSON(spec)))
+	})
+
+	It("does not pass the image registry credentials to the plugin", func() {
+		spec.Image = garden.ImageRef{URI: "docker:///some/image", Username: "some-user", Password: "some-password"}
+		Expect(admitter.Admit(context.Background(), logger, spec)).To(Succeed())
+
+		cmd := fakeCommandRunner.ExecutedCommands()[0]
+		input, err := ioutil.ReadAll(cmd.Stdin)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(input)).NotTo(ContainSubstring("some-user"))
+		Expect(string(input)).NotTo(ContainSubstring("some-password"))
+		Expect(string(input)).To(ContainSubstring("docker:///some/image"))
+	})
+
+	Context("when the plugin denies the spec", func() {
+		BeforeEach(func() {
+			pluginOutput = `{"allowed": false, "reason": "no privileged containers on this cell"}`
+		})
+
+		It("returns the reason", func() {
+			Expect(admitter.Admit(context.Background(), logger, spec)).To(MatchError(gardener.AdmissionDeniedError{
+				Handle: "some-handle",
+				Reason: "no privileged containers on this cell",
+			}))
+		})
+
+		Context("without a reason", func() {
+			BeforeEach(func() {
+				pluginOutput = `{"allowed": false}`
+			})
+
+			It("returns a generic reason", func() {
+				Expect(admitter.Admit(context.Background(), logger, spec)).To(MatchError(gardener.AdmissionDeniedError{
+					Handle: "some-handle",
+					Reason: "denied by admission plugin",
+				}))
+			})
+		})
+	})
+
+	Context("when the plugin fails", func() {
+		BeforeEach(func() {
+			pluginErr = errors.New("potato")
+		})
+
+		It("returns an error", func() {
+			Expect(admitter.Admit(context.Background(), logger, spec)).To(MatchError("external admission plugin: potato"))
+		})
+
+		It("logs the plugin's stderr", func() {
+			admitter.Admit(context.Background(), logger, spec)
+			Expect(logger).To(gbytes.Say("some-stderr-bytes"))
+		})
+
+		Context("because the context is done", func() {
+			It("returns the context's error", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				Expect(admitter.Admit(ctx, logger, spec)).To(MatchError("external admission plugin: context canceled"))
+			})
+		})
+	})
+
+	Context("when the plugin's output is not a decision", func() {
+		BeforeEach(func() {
+			pluginOutput = "not-json"
+		})
+
+		It("returns an error", func() {
+			Expect(admitter.Admit(context.Background(), logger, spec)).To(MatchError(ContainSubstring("unmarshaling result from external admission plugin")))
+		})
+	})
+})
+
+func mustMarshalJSON(input interface{}) string {
+	bytes, err := json.Marshal(input)
+	Expect(err).NotTo(HaveOccurred())
+	return string(bytes)
+}
@@ -0,0 +1,170 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/lager"
+)
+
+// Policy is the built-in admission policy, read from a JSON file. Zero values
+// leave the corresponding property of the spec unrestricted. When a ceiling is
+// set, containers must ask for a limit no higher than it, as leaving the limit
+// unset would let them use as much as they like.
+type Policy struct {
+	// DenyPrivileged rejects privileged containers
+	DenyPrivileged bool `json:"deny_privileged"`
+
+	// AllowedBindMountSources lists the host directories which containers may
+	// bind mount from, including any of their subdirectories. Sources are
+	// checked once any symlinks in them are followed, so sources which do not
+	// exist are denied. When it is empty any host path may be mounted.
+	AllowedBindMountSources []string `json:"allowed_bind_mount_sources"`
+
+	// MaxMemoryBytes is the highest memory limit a container may ask for
+	MaxMemoryBytes uint64 `json:"max_memory_bytes"`
+
+	// MaxDiskBytes is the highest hard disk limit a container may ask for
+	MaxDiskBytes uint64 `json:"max_disk_bytes"`
+
+	// MaxCPUShares is the highest CPU share a container may ask for
+	MaxCPUShares uint64 `json:"max_cpu_shares"`
+
+	// MaxPids is the highest pid limit a container may ask for
+	MaxPids uint64 `json:"max_pids"`
+}
+
+// LoadPolicy reads a Policy from the JSON file at path
+func LoadPolicy(path string) (*Policy, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading admission policy: %s", err)
+	}
+
+	policy := &Policy{}
+	if err := json.Unmarshal(contents, policy); err != nil {
+		return nil, fmt.Errorf("parsing admission policy %s: %s", path, err)
+	}
+
+	return policy, nil
+}
+
+// Admit rejects the spec with an AdmissionDeniedError listing every way in
+// which it breaks the policy
+func (p *Policy) Admit(ctx context.Context, log lager.Logger, spec garden.ContainerSpec) error {
+	log = log.Session("policy", lager.Data{"handle": spec.Handle})
+
+	violations := p.violations(spec)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	reason := strings.Join(violations, "; ")
+	log.Info("denied", lager.Data{"reason": reason})
+
+	return gardener.AdmissionDeniedError{Handle: spec.Handle, Reason: reason}
+}
+
+func (p *Policy) violations(spec garden.ContainerSpec) []string {
+	var violations []string
+
+	if p.DenyPrivileged && spec.Privileged {
+		violations = append(violations, "privileged containers are not allowed")
+	}
+
+	for _, mount := range spec.BindMounts {
+		if mount.Origin != garden.BindMountOriginHost {
+			continue
+		}
+
+		if violation := p.bindMountSourceViolation(mount.SrcPath); violation != "" {
+			violations = append(violations, violation)
+		}
+	}
+
+	limits := spec.Limits
+	violations = appendCeiling(violations, "memory limit", limits.Memory.LimitInBytes, p.MaxMemoryBytes)
+	violations = appendCeiling(violations, "disk limit", limits.Disk.ByteHard, p.MaxDiskBytes)
+	violations = appendCeiling(violations, "cpu shares", limits.CPU.LimitInShares, p.MaxCPUShares)
+	violations = appendCeiling(violations, "pid limit", limits.Pid.Max, p.MaxPids)
+
+	return violations
+}
+
+// AdmitLimits rejects new limits for a container which exceed the policy's
+// ceilings. Only the limits which are set are being changed, so unlike Admit
+// it does not require the others to be set.
+func (p *Policy) AdmitLimits(log lager.Logger, handle string, limits garden.Limits) error {
+	log = log.Session("policy", lager.Data{"handle": handle})
+
+	var violations []string
+	if limits.Memory.LimitInBytes != 0 {
+		violations = appendCeiling(violations, "memory limit", limits.Memory.LimitInBytes, p.MaxMemoryBytes)
+	}
+	if limits.CPU.LimitInShares != 0 {
+		violations = appendCeiling(violations, "cpu shares", limits.CPU.LimitInShares, p.MaxCPUShares)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	reason := strings.Join(violations, "; ")
+	log.Info("denied", lager.Data{"reason": reason})
+
+	return gardener.AdmissionDeniedError{Handle: handle, Reason: reason}
+}
+
+// bindMountSourceViolation follows any symlinks in the source, so that a link
+// in an allowed directory cannot be used to mount from anywhere else
+func (p *Policy) bindMountSourceViolation(src string) string {
+	if len(p.AllowedBindMountSources) == 0 {
+		return ""
+	}
+
+	resolved, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return fmt.Sprintf("bind mount source '%s' cannot be resolved: %s", src, err)
+	}
+
+	for _, allowed := range p.AllowedBindMountSources {
+		allowed = resolveAllowed(allowed)
+		if resolved == allowed || strings.HasPrefix(resolved, strings.TrimSuffix(allowed, "/")+"/") {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("bind mounts from '%s' are not allowed", src)
+}
+
+// resolveAllowed follows any symlinks in an allowed directory, so that it can
+// be compared with resolved sources
+func resolveAllowed(allowed string) string {
+	if resolved, err := filepath.EvalSymlinks(allowed); err == nil {
+		return resolved
+	}
+
+	return filepath.Clean(allowed)
+}
+
+func appendCeiling(violations []string, name string, requested, ceiling uint64) []string {
+	if ceiling == 0 {
+		return violations
+	}
+
+	if requested == 0 {
+		return append(violations, fmt.Sprintf("%s must be set, to at most %d", name, ceiling))
+	}
+
+	if requested > ceiling {
+		return append(violations, fmt.Sprintf("%s %d exceeds the maximum of %d", name, requested, ceiling))
+	}
+
+	return violations
+}
@@ -0,0 +1,250 @@
+package admission_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/admission"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy", func() {
+	var (
+		logger *lagertest.TestLogger
+		policy *admission.Policy
+		spec   garden.ContainerSpec
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		policy = &admission.Policy{}
+		spec = garden.ContainerSpec{Handle: "some-handle"}
+	})
+
+	Describe("LoadPolicy", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "admission-policy")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("reads the policy from the file", func() {
+			path := filepath.Join(dir, "policy.json")
+			Expect(ioutil.WriteFile(path, []byte(`{
+				"deny_privileged": true,
+				"allowed_bind_mount_sources": ["/var/vcap/data"],
+				"max_memory_bytes": 1024,
+				"max_disk_bytes": 2048,
+				"max_cpu_shares": 512,
+				"max_pids": 100
+			}`), 0600)).To(Succeed())
+
+			loaded, err := admission.LoadPolicy(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(Equal(&admission.Policy{
+				DenyPrivileged:          true,
+				AllowedBindMountSources: []string{"/var/vcap/data"},
+				MaxMemoryBytes:          1024,
+				MaxDiskBytes:            2048,
+				MaxCPUShares:            512,
+				MaxPids:                 100,
+			}))
+		})
+
+		Context("when the file does not exist", func() {
+			It("returns an error", func() {
+				_, err := admission.LoadPolicy(filepath.Join(dir, "missing.json"))
+				Expect(err).To(MatchError(ContainSubstring("reading admission policy")))
+			})
+		})
+
+		Context("when the file is not valid JSON", func() {
+			It("returns an error", func() {
+				path := filepath.Join(dir, "policy.json")
+				Expect(ioutil.WriteFile(path, []byte("{"), 0600)).To(Succeed())
+
+				_, err := admission.LoadPolicy(path)
+				Expect(err).To(MatchError(ContainSubstring("parsing admission policy")))
+			})
+		})
+	})
+
+	Context("when the policy is empty", func() {
+		It("admits any spec", func() {
+			spec.Privileged = true
+			spec.BindMounts = []garden.BindMount{{SrcPath: "/etc"}}
+			spec.Limits.Memory.LimitInBytes = 1 << 40
+
+			Expect(policy.Admit(context.Background(), logger, spec)).To(Succeed())
+		})
+	})
+
+	Describe("privileged containers", func() {
+		BeforeEach(func() {
+			policy.DenyPrivileged = true
+		})
+
+		It("denies them", func() {
+			spec.Privileged = true
+			Expect(policy.Admit(context.Background(), logger, spec)).To(MatchError(gardener.AdmissionDeniedError{
+				Handle: "some-handle",
+				Reason: "privileged containers are not allowed",
+			}))
+		})
+
+		It("admits unprivileged containers", func() {
+			Expect(policy.Admit(context.Background(), logger, spec)).To(Succeed())
+		})
+	})
+
+	Describe("bind mounts", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "admission-bind-mounts")
+			Expect(err).NotTo(HaveOccurred())
+			dir, err = filepath.EvalSymlinks(dir)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, sub := range []string{"data/some/dir", "srv", "srvfoo", "etc"} {
+				Expect(os.MkdirAll(filepath.Join(dir, sub), 0755)).To(Succeed())
+			}
+
+			policy.AllowedBindMountSources = []string{filepath.Join(dir, "data") + "/", filepath.Join(dir, "srv")}
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("admits mounts of allowed directories and their subdirectories", func() {
+			spec.BindMounts = []garden.BindMount{
+				{SrcPath: filepath.Join(dir, "srv")},
+				{SrcPath: filepath.Join(dir, "data", "some", "dir")},
+			}
+			Expect(policy.Admit(context.Background(), logger, spec)).To(Succeed())
+		})
+
+		It("denies mounts of other host paths", func() {
+			src := filepath.Join(dir, "srvfoo")
+			spec.BindMounts = []garden.BindMount{{SrcPath: src}}
+			Expect(policy.Admit(context.Background(), logger, spec)).To(MatchError(gardener.AdmissionDeniedError{
+				Handle: "some-handle",
+				Reason: "bind mounts from '" + src + "' are not allowed",
+			}))
+		})
+
+		It("denies mounts which escape an allowed directory", func() {
+			spec.BindMounts = []garden.BindMount{{SrcPath: filepath.Join(dir, "srv") + "/../etc"}}
+			Expect(policy.Admit(context.Background(), logger, spec)).NotTo(Succeed())
+		})
+
+		It("denies mounts which follow a symlink out of an allowed directory", func() {
+			link := filepath.Join(dir, "srv", "escape")
+			Expect(os.Symlink(filepath.Join(dir, "etc"), link)).To(Succeed())
+
+			spec.BindMounts = []garden.BindMount{{SrcPath: link}}
+			Expect(policy.Admit(context.Background(), logger, spec)).To(MatchError(gardener.AdmissionDeniedError{
+				Handle: "some-handle",
+				Reason: "bind mounts from '" + link + "' are not allowed",
+			}))
+		})
+
+		It("denies mounts of sources which do not exist", func() {
+			spec.BindMounts = []garden.BindMount{{SrcPath: filepath.Join(dir, "srv", "missing")}}
+			Expect(policy.Admit(context.Background(), logger, spec)).To(MatchError(ContainSubstring("cannot be resolved")))
+		})
+
+		It("admits mounts from inside the container", func() {
+			spec.BindMounts = []garden.BindMount{{SrcPath: "/etc", Origin: garden.BindMountOriginContainer}}
+			Expect(policy.Admit(context.Background(), logger, spec)).To(Succeed())
+		})
+	})
+
+	Describe("limits", func() {
+		BeforeEach(func() {
+			policy.MaxMemoryBytes = 1024
+			policy.MaxDiskBytes = 2048
+			policy.MaxCPUShares = 512
+			policy.MaxPids = 100
+		})
+
+		It("admits limits up to the ceilings", func() {
+			spec.Limits = garden.Limits{
+				Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				Disk:   garden.DiskLimits{ByteHard: 2048},
+				CPU:    garden.CPULimits{LimitInShares: 512},
+				Pid:    garden.PidLimits{Max: 100},
+			}
+			Expect(policy.Admit(context.Background(), logger, spec)).To(Succeed())
+		})
+
+		It("denies limits above the ceilings, giving every reason", func() {
+			spec.Limits = garden.Limits{
+				Memory: garden.MemoryLimits{LimitInBytes: 1025},
+				Disk:   garden.DiskLimits{ByteHard: 2048},
+				CPU:    garden.CPULimits{LimitInShares: 512},
+				Pid:    garden.PidLimits{Max: 101},
+			}
+			Expect(policy.Admit(context.Background(), logger, spec)).To(MatchError(gardener.AdmissionDeniedError{
+				Handle: "some-handle",
+				Reason: "memory limit 1025 exceeds the maximum of 1024; pid limit 101 exceeds the maximum of 100",
+			}))
+		})
+
+		It("denies specs which leave limits with a ceiling unset, as they would be unlimited", func() {
+			spec.Limits = garden.Limits{}
+			Expect(policy.Admit(context.Background(), logger, spec)).To(MatchError(gardener.AdmissionDeniedError{
+				Handle: "some-handle",
+				Reason: "memory limit must be set, to at most 1024; disk limit must be set, to at most 2048; " +
+					"cpu shares must be set, to at most 512; pid limit must be set, to at most 100",
+			}))
+		})
+
+		It("leaves limits without a ceiling unrestricted", func() {
+			policy.MaxDiskBytes = 0
+			policy.MaxCPUShares = 0
+			spec.Limits = garden.Limits{
+				Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				Pid:    garden.PidLimits{Max: 100},
+			}
+			Expect(policy.Admit(context.Background(), logger, spec)).To(Succeed())
+		})
+	})
+
+	Describe("AdmitLimits", func() {
+		BeforeEach(func() {
+			policy.MaxMemoryBytes = 1024
+			policy.MaxCPUShares = 512
+		})
+
+		It("admits new limits up to the ceilings, without requiring the others to be set", func() {
+			Expect(policy.AdmitLimits(logger, "some-handle", garden.Limits{Memory: garden.MemoryLimits{LimitInBytes: 1024}})).To(Succeed())
+			Expect(policy.AdmitLimits(logger, "some-handle", garden.Limits{CPU: garden.CPULimits{LimitInShares: 512}})).To(Succeed())
+		})
+
+		It("denies new limits above the ceilings", func() {
+			Expect(policy.AdmitLimits(logger, "some-handle", garden.Limits{Memory: garden.MemoryLimits{LimitInBytes: 1025}})).To(MatchError(gardener.AdmissionDeniedError{
+				Handle: "some-handle",
+				Reason: "memory limit 1025 exceeds the maximum of 1024",
+			}))
+			Expect(policy.AdmitLimits(logger, "some-handle", garden.Limits{CPU: garden.CPULimits{LimitInShares: 513}})).To(MatchError(gardener.AdmissionDeniedError{
+				Handle: "some-handle",
+				Reason: "cpu shares 513 exceeds the maximum of 512",
+			}))
+		})
+	})
+})
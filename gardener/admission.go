@@ -0,0 +1,40 @@
+package gardener
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . Admitter
+
+// Admitter decides whether a container spec complies with site policy. It is
+// consulted before any resources are allocated for the container, and should
+// return an AdmissionDeniedError to reject the spec. It should give up once ctx
+// is done.
+type Admitter interface {
+	Admit(ctx context.Context, log lager.Logger, spec garden.ContainerSpec) error
+}
+
+//go:generate counterfeiter . LimitsAdmitter
+
+// LimitsAdmitter is an Admitter which also decides whether the new limits of an
+// existing container comply with site policy. Only the limits being changed
+// are set.
+type LimitsAdmitter interface {
+	Admitter
+	AdmitLimits(log lager.Logger, handle string, limits garden.Limits) error
+}
+
+// AdmissionDeniedError is returned when a container spec is rejected by the
+// Admitter
+type AdmissionDeniedError struct {
+	Handle string
+	Reason string
+}
+
+func (err AdmissionDeniedError) Error() string {
+	return fmt.Sprintf("container '%s' denied admission: %s", err.Handle, err.Reason)
+}
@@ -0,0 +1,144 @@
+package gardener
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultBulkWorkers is the number of containers a bulk operation works on at
+// once when the gardener does not specify a number
+const DefaultBulkWorkers = 16
+
+// MaxBulkCallsPerHandle is the number of calls bulk operations may have in
+// flight for one container at once. Calls which time out carry on in the
+// background, so without a cap a container which never responds would gather
+// another of them with every BulkInfo or BulkMetrics.
+const MaxBulkCallsPerHandle = 2
+
+// BulkTimeoutError is reported for a container which took longer than the
+// bulk timeout to respond
+type BulkTimeoutError struct {
+	Handle  string
+	Timeout time.Duration
+}
+
+func (err BulkTimeoutError) Error() string {
+	return fmt.Sprintf("container '%s' did not respond within %s", err.Handle, err.Timeout)
+}
+
+// BulkBusyError is reported for a container which already has as many calls in
+// flight as bulk operations may make to it, so another was not started
+type BulkBusyError struct {
+	Handle   string
+	InFlight int
+}
+
+func (err BulkBusyError) Error() string {
+	return fmt.Sprintf("container '%s' has not responded to %d earlier requests", err.Handle, err.InFlight)
+}
+
+// bulkInFlight counts the calls in flight for each container, including any
+// which timed out but have not returned yet. The zero value is ready to use.
+type bulkInFlight struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *bulkInFlight) acquire(handle string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.calls == nil {
+		f.calls = make(map[string]int)
+	}
+
+	if f.calls[handle] >= MaxBulkCallsPerHandle {
+		return BulkBusyError{Handle: handle, InFlight: f.calls[handle]}
+	}
+
+	f.calls[handle]++
+	return nil
+}
+
+func (f *bulkInFlight) release(handle string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls[handle]--
+	if f.calls[handle] <= 0 {
+		delete(f.calls, handle)
+	}
+}
+
+type bulkResult struct {
+	value interface{}
+	err   error
+}
+
+// bulk calls fn for each of the handles concurrently, on at most BulkWorkers
+// handles at once. If BulkTimeout is set, a handle for which fn does not return
+// in time gets a BulkTimeoutError and frees its worker for the next handle;
+// the call carries on in the background and its result is discarded. Until it
+// returns it counts against MaxBulkCallsPerHandle, so a container which never
+// responds holds at most that many calls.
+func (g *Gardener) bulk(handles []string, fn func(handle string) (interface{}, error)) map[string]bulkResult {
+	workers := g.BulkWorkers
+	if workers <= 0 {
+		workers = DefaultBulkWorkers
+	}
+
+	var (
+		mu      sync.Mutex
+		results = make(map[string]bulkResult, len(handles))
+		wg      sync.WaitGroup
+		slots   = make(chan struct{}, workers)
+	)
+
+	for _, handle := range handles {
+		slots <- struct{}{}
+		wg.Add(1)
+
+		go func(handle string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result := g.bulkCall(handle, fn)
+
+			mu.Lock()
+			results[handle] = result
+			mu.Unlock()
+		}(handle)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func (g *Gardener) bulkCall(handle string, fn func(handle string) (interface{}, error)) bulkResult {
+	if err := g.bulkInFlight.acquire(handle); err != nil {
+		return bulkResult{err: err}
+	}
+
+	done := make(chan bulkResult, 1)
+	go func() {
+		defer g.bulkInFlight.release(handle)
+
+		value, err := fn(handle)
+		done <- bulkResult{value: value, err: err}
+	}()
+
+	if g.BulkTimeout <= 0 {
+		return <-done
+	}
+
+	timer := time.NewTimer(g.BulkTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		return result
+	case <-timer.C:
+		return bulkResult{err: BulkTimeoutError{Handle: handle, Timeout: g.BulkTimeout}}
+	}
+}
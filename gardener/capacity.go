@@ -0,0 +1,196 @@
+package gardener
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// CommittedMemoryKey, CommittedDiskKey and CommittedCPUKey record the limits
+// promised to a container, so they can be accounted for after a restart
+const CommittedMemoryKey = "garden.committed.memory"
+const CommittedDiskKey = "garden.committed.disk"
+const CommittedCPUKey = "garden.committed.cpu-shares"
+
+// MemoryOvercommitError is returned when creating a container would take the
+// memory limits of all containers past the overcommit ratio
+type MemoryOvercommitError struct {
+	Handle    string
+	Requested uint64
+	Committed uint64
+	Limit     uint64
+}
+
+func (err MemoryOvercommitError) Error() string {
+	return fmt.Sprintf(
+		"container '%s' requests a memory limit of %d bytes, but %d of the %d bytes which may be committed already are",
+		err.Handle, err.Requested, err.Committed, err.Limit,
+	)
+}
+
+type commitment struct {
+	memory uint64
+	disk   uint64
+}
+
+// capacityLedger keeps the memory and disk limits committed to each container
+// in memory. It is loaded from the container properties on first use and is
+// then kept up to date as containers are created, limited and destroyed. The
+// zero value is ready to use.
+type capacityLedger struct {
+	mu          sync.Mutex
+	commitments map[string]commitment
+}
+
+// committed returns the total memory and disk committed to containers
+func (l *capacityLedger) committed(properties PropertyManager) (memory, disk uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.load(properties)
+
+	for _, c := range l.commitments {
+		memory += c.memory
+		disk += c.disk
+	}
+
+	return memory, disk
+}
+
+// commit records the limits of a new container. If memoryLimit is not zero,
+// the commitment is refused when it would take the committed memory past it.
+func (l *capacityLedger) commit(properties PropertyManager, handle string, c commitment, memoryLimit uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.load(properties)
+
+	if memoryLimit > 0 {
+		var memory uint64
+		for _, other := range l.commitments {
+			memory += other.memory
+		}
+
+		if memory+c.memory > memoryLimit {
+			return MemoryOvercommitError{Handle: handle, Requested: c.memory, Committed: memory, Limit: memoryLimit}
+		}
+	}
+
+	l.commitments[handle] = c
+	return nil
+}
+
+// setMemory records a change to the memory limit of a container. If
+// memoryLimit is not zero, raising the limit is refused when it would take the
+// committed memory past it. The returned function puts back the previous limit,
+// for when the change cannot be made after all.
+func (l *capacityLedger) setMemory(properties PropertyManager, handle string, memory, memoryLimit uint64) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.load(properties)
+
+	previous := l.commitments[handle]
+
+	if memoryLimit > 0 && memory > previous.memory {
+		var committed uint64
+		for other, c := range l.commitments {
+			if other != handle {
+				committed += c.memory
+			}
+		}
+
+		if committed+memory > memoryLimit {
+			return nil, MemoryOvercommitError{Handle: handle, Requested: memory, Committed: committed, Limit: memoryLimit}
+		}
+	}
+
+	c := previous
+	c.memory = memory
+	l.commitments[handle] = c
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if c, ok := l.commitments[handle]; ok {
+			c.memory = previous.memory
+			l.commitments[handle] = c
+		}
+	}, nil
+}
+
+func (l *capacityLedger) release(handle string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.commitments, handle)
+}
+
+// reset discards the commitments so they are loaded again on next use
+func (l *capacityLedger) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.commitments = nil
+}
+
+func (l *capacityLedger) load(properties PropertyManager) {
+	if l.commitments != nil {
+		return
+	}
+
+	l.commitments = make(map[string]commitment)
+	for _, handle := range properties.Handles() {
+		l.commitments[handle] = commitment{
+			memory: committedBytes(properties, handle, CommittedMemoryKey),
+			disk:   committedBytes(properties, handle, CommittedDiskKey),
+		}
+	}
+}
+
+func committedBytes(properties PropertyManager, handle, key string) uint64 {
+	value, ok := properties.Get(handle, key)
+	if !ok {
+		return 0
+	}
+
+	bytes, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return bytes
+}
+
+// memoryBudget is how much memory the limits of all containers may add up to:
+// a multiple of the memory of the host which is not reserved
+type memoryBudget struct {
+	sysInfo         SysInfoProvider
+	reserved        uint64
+	overcommitRatio float64
+}
+
+// limit returns the budget, or zero when there is no overcommit ratio
+func (b memoryBudget) limit() (uint64, error) {
+	if b.overcommitRatio == 0 {
+		return 0, nil
+	}
+
+	total, err := b.sysInfo.TotalMemory()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(float64(allocatable(total, b.reserved, 0)) * b.overcommitRatio), nil
+}
+
+// allocatable is what is left of total once reserved and committed are taken
+// away from it
+func allocatable(total, reserved, committed uint64) uint64 {
+	if reserved+committed >= total {
+		return 0
+	}
+
+	return total - reserved - committed
+}
@@ -2,11 +2,14 @@ package gardener
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/properties"
 	"code.cloudfoundry.org/lager"
 )
 
@@ -18,6 +21,14 @@ type container struct {
 	volumeCreator   VolumeCreator
 	networker       Networker
 	propertyManager PropertyManager
+	admitter        Admitter
+	lifecycle       *handleLifecycle
+	eventBus        EventPublisher
+	reaper          *Reaper
+	capacity        *capacityLedger
+	memoryBudget    memoryBudget
+	quotas          *Quotas
+	reserved        reservedProperties
 }
 
 func (c *container) Handle() string {
@@ -25,15 +36,68 @@ func (c *container) Handle() string {
 }
 
 func (c *container) Run(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
-	return c.containerizer.Run(c.logger, c.handle, spec, io)
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	process, err := c.containerizer.Run(c.logger, c.handle, spec, io)
+	if err != nil {
+		return nil, err
+	}
+
+	publish(c.eventBus, Event{Kind: ProcessStartedEvent, Handle: c.handle, ProcessID: process.ID()})
+	return c.pinned(process), nil
 }
 
 func (c *container) Attach(processID string, io garden.ProcessIO) (garden.Process, error) {
-	return c.containerizer.Attach(c.logger, c.handle, processID, io)
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	process, err := c.containerizer.Attach(c.logger, c.handle, processID, io)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.pinned(process), nil
+}
+
+// pinned keeps the container from expiring until the process has been waited on
+func (c *container) pinned(process garden.Process) garden.Process {
+	if c.reaper == nil {
+		return process
+	}
+
+	return &pinnedProcess{Process: process, unpin: c.reaper.Pin(c.handle)}
+}
+
+type pinnedProcess struct {
+	garden.Process
+	unpin func()
+}
+
+func (p *pinnedProcess) Wait() (int, error) {
+	defer p.unpin()
+	return p.Process.Wait()
 }
 
 func (c *container) Stop(kill bool) error {
-	return c.containerizer.Stop(c.logger, c.handle, kill)
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := c.containerizer.Stop(c.logger, c.handle, kill); err != nil {
+		return err
+	}
+
+	publish(c.eventBus, Event{Kind: ContainerStoppedEvent, Handle: c.handle})
+	return nil
 }
 
 func (c *container) Info() (garden.ContainerInfo, error) {
@@ -42,19 +106,27 @@ func (c *container) Info() (garden.ContainerInfo, error) {
 	log.Debug("starting")
 	defer log.Debug("finished")
 
-	containerIP, ok := c.propertyManager.Get(c.handle, ContainerIPKey)
-	if !ok {
-		return garden.ContainerInfo{}, fmt.Errorf("info: no property found: %s", ContainerIPKey)
+	containerIPKey, hostIPKey, externalIPKey := ContainerIPKey, BridgeIPKey, ExternalIPKey
+	if _, ok := c.propertyManager.Get(c.handle, ContainerIPKey); !ok {
+		if _, ok := c.propertyManager.Get(c.handle, ContainerIPv6Key); ok {
+			// containers with only IPv6 report their IPv6 addresses instead
+			containerIPKey, hostIPKey, externalIPKey = ContainerIPv6Key, BridgeIPv6Key, ExternalIPv6Key
+		}
 	}
 
-	hostIP, ok := c.propertyManager.Get(c.handle, BridgeIPKey)
+	containerIP, ok := c.propertyManager.Get(c.handle, containerIPKey)
 	if !ok {
-		return garden.ContainerInfo{}, fmt.Errorf("info: no property found: %s", BridgeIPKey)
+		return garden.ContainerInfo{}, fmt.Errorf("info: no property found: %s", containerIPKey)
 	}
 
-	externalIP, ok := c.propertyManager.Get(c.handle, ExternalIPKey)
+	hostIP, ok := c.propertyManager.Get(c.handle, hostIPKey)
 	if !ok {
-		return garden.ContainerInfo{}, fmt.Errorf("info: no property found: %s", ExternalIPKey)
+		return garden.ContainerInfo{}, fmt.Errorf("info: no property found: %s", hostIPKey)
+	}
+
+	externalIP, ok := c.propertyManager.Get(c.handle, externalIPKey)
+	if !ok && externalIPKey == ExternalIPKey {
+		return garden.ContainerInfo{}, fmt.Errorf("info: no property found: %s", externalIPKey)
 	}
 
 	actualContainerSpec, err := c.containerizer.Info(c.logger, c.handle)
@@ -74,6 +146,9 @@ func (c *container) Info() (garden.ContainerInfo, error) {
 	if actualContainerSpec.Stopped {
 		state = "stopped"
 	}
+	if actualContainerSpec.Paused {
+		state = "paused"
+	}
 
 	json.Unmarshal([]byte(mappedPortsCfg), &mappedPorts)
 	return garden.ContainerInfo{
@@ -89,32 +164,98 @@ func (c *container) Info() (garden.ContainerInfo, error) {
 }
 
 func (c *container) StreamIn(spec garden.StreamInSpec) error {
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	return c.containerizer.StreamIn(c.logger, c.handle, spec)
 }
 
 func (c *container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	return c.containerizer.StreamOut(c.logger, c.handle, spec)
 }
 
 func (c *container) LimitBandwidth(limits garden.BandwidthLimits) error {
-	return nil
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return c.networker.LimitBandwidth(c.logger, c.handle, limits)
 }
 
 func (c *container) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
-	return garden.BandwidthLimits{}, nil
+	limits := garden.BandwidthLimits{}
+
+	limitsJson, ok := c.propertyManager.Get(c.handle, BandwidthLimitsKey)
+	if !ok {
+		return limits, nil
+	}
+
+	if err := json.Unmarshal([]byte(limitsJson), &limits); err != nil {
+		return garden.BandwidthLimits{}, fmt.Errorf("current bandwidth limits: %s", err)
+	}
+
+	return limits, nil
 }
 
 func (c *container) LimitCPU(limits garden.CPULimits) error {
+	if limits.LimitInShares == 0 {
+		return errors.New("limit cpu: LimitInShares must be greater than zero")
+	}
+
+	if err := c.admitLimits(garden.Limits{CPU: limits}); err != nil {
+		return err
+	}
+
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	doneQuota, err := c.quotas.reserveCPU(c.handle, limits.LimitInShares)
+	if err != nil {
+		return err
+	}
+	defer doneQuota()
+
+	if err := c.containerizer.UpdateLimits(c.logger, c.handle, garden.Limits{CPU: limits}); err != nil {
+		return err
+	}
+
+	c.propertyManager.Set(c.handle, CommittedCPUKey, strconv.FormatUint(limits.LimitInShares, 10))
+
 	return nil
 }
 
+// admitLimits holds new limits to the same policy as the container's spec was
+// held to at create, when the admitter can check them
+func (c *container) admitLimits(limits garden.Limits) error {
+	admitter, ok := c.admitter.(LimitsAdmitter)
+	if !ok {
+		return nil
+	}
+
+	return admitter.AdmitLimits(c.logger.Session("admit-limits"), c.handle, limits)
+}
+
 func (c *container) CurrentCPULimits() (garden.CPULimits, error) {
 	info, err := c.containerizer.Info(c.logger, c.handle)
 	return info.Limits.CPU, err
 }
 
 func (c *container) LimitDisk(limits garden.DiskLimits) error {
-	return nil
+	return errors.New("limit disk: disk limits cannot be changed after the container is created")
 }
 
 func (c *container) CurrentDiskLimits() (garden.DiskLimits, error) {
@@ -122,6 +263,43 @@ func (c *container) CurrentDiskLimits() (garden.DiskLimits, error) {
 }
 
 func (c *container) LimitMemory(limits garden.MemoryLimits) error {
+	if limits.LimitInBytes == 0 {
+		return errors.New("limit memory: LimitInBytes must be greater than zero")
+	}
+
+	if err := c.admitLimits(garden.Limits{Memory: limits}); err != nil {
+		return err
+	}
+
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	doneQuota, err := c.quotas.reserveMemory(c.handle, limits.LimitInBytes)
+	if err != nil {
+		return err
+	}
+	defer doneQuota()
+
+	memoryLimit, err := c.memoryBudget.limit()
+	if err != nil {
+		return err
+	}
+
+	restore, err := c.capacity.setMemory(c.propertyManager, c.handle, limits.LimitInBytes, memoryLimit)
+	if err != nil {
+		return err
+	}
+
+	if err := c.containerizer.UpdateLimits(c.logger, c.handle, garden.Limits{Memory: limits}); err != nil {
+		restore()
+		return err
+	}
+
+	c.propertyManager.Set(c.handle, CommittedMemoryKey, strconv.FormatUint(limits.LimitInBytes, 10))
+
 	return nil
 }
 
@@ -131,14 +309,38 @@ func (c *container) CurrentMemoryLimits() (garden.MemoryLimits, error) {
 }
 
 func (c *container) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer release()
+
+	doneQuota, err := c.quotas.reservePort(c.handle)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer doneQuota()
+
 	return c.networker.NetIn(c.logger, c.handle, hostPort, containerPort)
 }
 
 func (c *container) NetOut(netOutRule garden.NetOutRule) error {
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	return c.networker.NetOut(c.logger, c.handle, netOutRule)
 }
 
 func (c *container) BulkNetOut(netOutRules []garden.NetOutRule) error {
+	release, err := c.lifecycle.use(c.handle)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	return c.networker.BulkNetOut(c.logger, c.handle, netOutRules)
 }
 
@@ -162,6 +364,7 @@ func (c *container) Metrics() (garden.Metrics, error) {
 		CPUStat:    actualContainerMetrics.CPU,
 		MemoryStat: actualContainerMetrics.Memory,
 		DiskStat:   diskMetrics,
+		PidStat:    actualContainerMetrics.Pid,
 	}, nil
 }
 
@@ -178,16 +381,43 @@ func (c *container) Property(name string) (string, error) {
 }
 
 func (c *container) SetProperty(name string, value string) error {
+	if err := c.reserved.check(name); err != nil {
+		return err
+	}
+
 	c.propertyManager.Set(c.handle, name, value)
 	return nil
 }
 
 func (c *container) RemoveProperty(name string) error {
+	if err := c.reserved.check(name); err != nil {
+		return err
+	}
+
 	c.propertyManager.Remove(c.handle, name)
 	return nil
 }
 
+// ApplyProperties makes the changes in the batch, if none of them are to
+// reserved properties and all of its preconditions hold
+func (c *container) ApplyProperties(batch properties.Batch) error {
+	for name := range batch.Set {
+		if err := c.reserved.check(name); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range batch.Remove {
+		if err := c.reserved.check(name); err != nil {
+			return err
+		}
+	}
+
+	return c.propertyManager.Apply(c.handle, batch)
+}
+
 func (c *container) SetGraceTime(t time.Duration) error {
 	c.propertyManager.Set(c.handle, GraceTimeKey, fmt.Sprintf("%d", t))
+	c.reaper.Touch(c.handle)
 	return nil
 }
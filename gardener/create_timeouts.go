@@ -0,0 +1,52 @@
+package gardener
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateTimeouts limit the time each stage of creating a container may take.
+// A zero timeout leaves the stage unlimited. Calls made through the API once
+// the container exists, such as NetIn, NetOut and LimitBandwidth, are not
+// covered.
+type CreateTimeouts struct {
+	Volume    time.Duration
+	Container time.Duration
+	Network   time.Duration
+}
+
+// CreateStageTimeoutError is returned when a stage of creating a container does
+// not finish within its timeout
+type CreateStageTimeoutError struct {
+	Handle  string
+	Stage   string
+	Timeout time.Duration
+}
+
+func (err CreateStageTimeoutError) Error() string {
+	return fmt.Sprintf("container '%s' timed out creating its %s after %s", err.Handle, err.Stage, err.Timeout)
+}
+
+// runStage runs a stage of creating a container with a context which expires
+// after timeout, if it is not zero. The stage is not started at all when ctx
+// is already done.
+func runStage(ctx context.Context, handle, stage string, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	stageCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		stageCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := fn(stageCtx)
+	if err != nil && ctx.Err() == nil && stageCtx.Err() == context.DeadlineExceeded {
+		return CreateStageTimeoutError{Handle: handle, Stage: stage, Timeout: timeout}
+	}
+
+	return err
+}
@@ -0,0 +1,152 @@
+package gardener_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden-shed/rootfs_spec"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create timeouts", func() {
+	var (
+		containerizer *fakes.FakeContainerizer
+		networker     *fakes.FakeNetworker
+		volumeCreator *fakes.FakeVolumeCreator
+		gdnr          *gardener.Gardener
+	)
+
+	BeforeEach(func() {
+		containerizer = new(fakes.FakeContainerizer)
+		networker = new(fakes.FakeNetworker)
+		volumeCreator = new(fakes.FakeVolumeCreator)
+
+		gdnr = &gardener.Gardener{
+			Containerizer:   containerizer,
+			Networker:       networker,
+			VolumeCreator:   volumeCreator,
+			SysInfoProvider: new(fakes.FakeSysInfoProvider),
+			UidGenerator:    new(fakes.FakeUidGenerator),
+			PropertyManager: new(fakes.FakePropertyManager),
+			Logger:          lagertest.NewTestLogger("test"),
+		}
+	})
+
+	blockUntilDone := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	It("passes each stage a context without a deadline by default", func() {
+		_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+		Expect(err).NotTo(HaveOccurred())
+
+		volumeCtx, _, _, _ := volumeCreator.CreateArgsForCall(0)
+		containerCtx, _, _ := containerizer.CreateArgsForCall(0)
+		networkCtx, _, _, _ := networker.NetworkArgsForCall(0)
+
+		for _, ctx := range []context.Context{volumeCtx, containerCtx, networkCtx} {
+			_, hasDeadline := ctx.Deadline()
+			Expect(hasDeadline).To(BeFalse())
+		}
+	})
+
+	It("gives each stage the deadline of its timeout", func() {
+		gdnr.CreateTimeouts = gardener.CreateTimeouts{Volume: time.Hour}
+
+		before := time.Now()
+		_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+		Expect(err).NotTo(HaveOccurred())
+
+		volumeCtx, _, _, _ := volumeCreator.CreateArgsForCall(0)
+		deadline, ok := volumeCtx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", before.Add(time.Hour), time.Minute))
+
+		containerCtx, _, _ := containerizer.CreateArgsForCall(0)
+		_, ok = containerCtx.Deadline()
+		Expect(ok).To(BeFalse())
+	})
+
+	Context("when creating the volume takes too long", func() {
+		BeforeEach(func() {
+			gdnr.CreateTimeouts.Volume = 10 * time.Millisecond
+			volumeCreator.CreateStub = func(ctx context.Context, _ lager.Logger, _ string, _ rootfs_spec.Spec) (gardener.DesiredImageSpec, error) {
+				return gardener.DesiredImageSpec{}, blockUntilDone(ctx)
+			}
+		})
+
+		It("returns a timeout error naming the stage", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).To(MatchError(gardener.CreateStageTimeoutError{
+				Handle:  "some-handle",
+				Stage:   "volume",
+				Timeout: 10 * time.Millisecond,
+			}))
+			Expect(containerizer.CreateCallCount()).To(Equal(0))
+		})
+
+		It("cleans up", func() {
+			gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+
+			Expect(volumeCreator.DestroyCallCount()).To(Equal(1))
+			Expect(containerizer.DestroyCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when networking the container takes too long", func() {
+		BeforeEach(func() {
+			gdnr.CreateTimeouts.Network = 10 * time.Millisecond
+			networker.NetworkStub = func(ctx context.Context, _ lager.Logger, _ garden.ContainerSpec, _ int) error {
+				return blockUntilDone(ctx)
+			}
+		})
+
+		It("cleans up the container it created", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).To(MatchError(gardener.CreateStageTimeoutError{
+				Handle:  "some-handle",
+				Stage:   "network",
+				Timeout: 10 * time.Millisecond,
+			}))
+
+			Expect(containerizer.CreateCallCount()).To(Equal(1))
+			Expect(containerizer.DestroyCallCount()).To(Equal(1))
+			Expect(networker.DestroyCallCount()).To(Equal(1))
+			Expect(containerizer.RemoveBundleCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the context of the create is cancelled", func() {
+		It("stops at the stage in progress and cleans up", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			containerizer.CreateStub = func(context.Context, lager.Logger, gardener.DesiredContainerSpec) error {
+				cancel()
+				return nil
+			}
+
+			_, err := gdnr.CreateContext(ctx, garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).To(Equal(context.Canceled))
+			Expect(networker.NetworkCallCount()).To(Equal(0))
+			Expect(containerizer.DestroyCallCount()).To(Equal(1))
+		})
+
+		It("does not mistake it for a timeout", func() {
+			gdnr.CreateTimeouts.Container = time.Hour
+			ctx, cancel := context.WithCancel(context.Background())
+			containerizer.CreateStub = func(ctx context.Context, _ lager.Logger, _ gardener.DesiredContainerSpec) error {
+				cancel()
+				return blockUntilDone(ctx)
+			}
+
+			_, err := gdnr.CreateContext(ctx, garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).To(Equal(context.Canceled))
+		})
+	})
+})
@@ -0,0 +1,220 @@
+package gardener
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+)
+
+// ErrDraining is returned for creates while the gardener is draining. It is a
+// ServiceUnavailableError, so clients can tell it apart from other failures.
+var ErrDraining = garden.NewServiceUnavailableError("garden is draining and does not accept new containers")
+
+// DrainTimeoutError is returned when operations are still in flight once the
+// drain timeout has passed
+type DrainTimeoutError struct {
+	InFlight int
+	Timeout  time.Duration
+}
+
+func (err DrainTimeoutError) Error() string {
+	return fmt.Sprintf("%d operations still in flight after draining for %s", err.InFlight, err.Timeout)
+}
+
+// DrainStatus reports the progress of a drain
+type DrainStatus struct {
+	Draining bool `json:"draining"`
+	InFlight int  `json:"in_flight"`
+
+	// Containers is the number of containers to stop once nothing is in
+	// flight, and Stopped the number which have been stopped so far
+	Containers int `json:"containers"`
+	Stopped    int `json:"stopped"`
+
+	Done  bool   `json:"done"`
+	Error string `json:"error,omitempty"`
+}
+
+// drainer counts the operations in flight, so a drain can wait for them, and
+// refuses new creates once draining starts. The zero value is ready to use.
+type drainer struct {
+	mu     sync.Mutex
+	status DrainStatus
+	idle   chan struct{}
+}
+
+// begin counts an operation as in flight until the returned function is
+// called. Unless the operation may go on while draining, it is refused once
+// draining has started.
+func (d *drainer) begin(allowedWhileDraining bool) (func(), error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.status.Draining && !allowedWhileDraining {
+		return nil, ErrDraining
+	}
+
+	d.status.InFlight++
+
+	return func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		d.status.InFlight--
+		if d.status.InFlight == 0 && d.idle != nil {
+			close(d.idle)
+			d.idle = nil
+		}
+	}, nil
+}
+
+// start starts draining, and returns a channel which is closed once nothing is
+// in flight
+func (d *drainer) start() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.status.Draining = true
+
+	idle := make(chan struct{})
+	if d.status.InFlight == 0 {
+		close(idle)
+	} else if d.idle != nil {
+		idle = d.idle
+	} else {
+		d.idle = idle
+	}
+
+	return idle
+}
+
+func (d *drainer) update(fn func(status *DrainStatus)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fn(&d.status)
+}
+
+func (d *drainer) current() DrainStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.status
+}
+
+// Drain stops the gardener accepting new containers and waits up to timeout
+// for the operations in flight to finish. If stopContainers is set, it then
+// stops every container, so that it is left in a consistent state. Paused
+// containers are resumed first, as their processes could not exit otherwise.
+func (g *Gardener) Drain(timeout time.Duration, stopContainers bool) (err error) {
+	log := g.Logger.Session("drain", lager.Data{"timeout": timeout.String(), "stop-containers": stopContainers})
+	log.Info("start")
+	defer log.Info("finished")
+
+	defer func() {
+		g.lifecycle.drain.update(func(status *DrainStatus) {
+			status.Done = true
+			if err != nil {
+				status.Error = err.Error()
+			}
+		})
+	}()
+
+	select {
+	case <-g.lifecycle.drain.start():
+	case <-time.After(timeout):
+		err := DrainTimeoutError{InFlight: g.lifecycle.drain.current().InFlight, Timeout: timeout}
+		log.Error("timed-out", err)
+		return err
+	}
+	log.Info("nothing-in-flight")
+
+	if !stopContainers {
+		return nil
+	}
+
+	handles, err := g.Containerizer.Handles()
+	if err != nil {
+		log.Error("handles-failed", err)
+		return err
+	}
+	g.lifecycle.drain.update(func(status *DrainStatus) { status.Containers = len(handles) })
+
+	var failed int
+	for _, handle := range handles {
+		if err := g.resumeIfPaused(log, handle); err != nil {
+			log.Error("resume-failed", err, lager.Data{"handle": handle})
+			failed++
+			continue
+		}
+
+		if err := g.Containerizer.Stop(log, handle, false); err != nil {
+			log.Error("stop-failed", err, lager.Data{"handle": handle})
+			failed++
+			continue
+		}
+
+		g.lifecycle.drain.update(func(status *DrainStatus) { status.Stopped++ })
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to stop %d of %d containers", failed, len(handles))
+	}
+
+	return nil
+}
+
+func (g *Gardener) resumeIfPaused(log lager.Logger, handle string) error {
+	info, err := g.Containerizer.Info(log, handle)
+	if err != nil {
+		return err
+	}
+
+	if !info.Paused {
+		return nil
+	}
+
+	log.Info("resuming-paused-container", lager.Data{"handle": handle})
+	if err := g.Containerizer.Resume(log, handle); err != nil {
+		return err
+	}
+
+	publish(g.EventBus, Event{Kind: ContainerResumedEvent, Handle: handle})
+	return nil
+}
+
+// DrainStatus reports the progress of draining the gardener
+func (g *Gardener) DrainStatus() DrainStatus {
+	return g.lifecycle.drain.current()
+}
+
+// DrainStatusReporter reports the progress of a drain
+type DrainStatusReporter interface {
+	DrainStatus() DrainStatus
+}
+
+// NewDrainHandler serves the progress of a drain as JSON, and calls start on a
+// POST so that the server begins draining
+func NewDrainHandler(reporter DrainStatusReporter, start func()) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" && r.Method != "POST" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		code := http.StatusOK
+		if r.Method == "POST" {
+			start()
+			code = http.StatusAccepted
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(reporter.DrainStatus())
+	})
+}
@@ -0,0 +1,247 @@
+package gardener_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Draining", func() {
+	var (
+		containerizer *fakes.FakeContainerizer
+		gdnr          *gardener.Gardener
+	)
+
+	BeforeEach(func() {
+		containerizer = new(fakes.FakeContainerizer)
+		containerizer.HandlesReturns([]string{}, nil)
+
+		gdnr = &gardener.Gardener{
+			Containerizer:   containerizer,
+			Networker:       new(fakes.FakeNetworker),
+			VolumeCreator:   new(fakes.FakeVolumeCreator),
+			SysInfoProvider: new(fakes.FakeSysInfoProvider),
+			UidGenerator:    new(fakes.FakeUidGenerator),
+			PropertyManager: new(fakes.FakePropertyManager),
+			Logger:          lagertest.NewTestLogger("test"),
+		}
+	})
+
+	It("refuses new creates once draining", func() {
+		Expect(gdnr.Drain(time.Second, false)).To(Succeed())
+
+		_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+		Expect(err).To(Equal(gardener.ErrDraining))
+		Expect(err).To(BeAssignableToTypeOf(garden.ServiceUnavailableError{}))
+		Expect(containerizer.CreateCallCount()).To(Equal(0))
+	})
+
+	It("still allows destroys once draining", func() {
+		containerizer.HandlesReturns([]string{"some-handle"}, nil)
+		Expect(gdnr.Drain(time.Second, false)).To(Succeed())
+
+		Expect(gdnr.Destroy("some-handle")).To(Succeed())
+	})
+
+	Context("when a create is in flight", func() {
+		var (
+			unblock chan struct{}
+			created chan error
+		)
+
+		BeforeEach(func() {
+			unblock = make(chan struct{})
+			started := make(chan struct{})
+			containerizer.CreateStub = func(context.Context, lager.Logger, gardener.DesiredContainerSpec) error {
+				close(started)
+				<-unblock
+				return nil
+			}
+
+			created = make(chan error, 1)
+			go func() {
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "in-flight"})
+				created <- err
+			}()
+			Eventually(started).Should(BeClosed())
+		})
+
+		It("waits for it to finish", func() {
+			drained := make(chan error, 1)
+			go func() { drained <- gdnr.Drain(time.Minute, false) }()
+
+			Eventually(gdnr.DrainStatus).Should(Equal(gardener.DrainStatus{Draining: true, InFlight: 1}))
+			Consistently(drained).ShouldNot(Receive())
+
+			close(unblock)
+			Eventually(created).Should(Receive(BeNil()))
+			Eventually(drained).Should(Receive(BeNil()))
+			Expect(gdnr.DrainStatus()).To(Equal(gardener.DrainStatus{Draining: true, Done: true}))
+		})
+
+		It("gives up after the timeout", func() {
+			defer close(unblock)
+
+			err := gdnr.Drain(10*time.Millisecond, false)
+			Expect(err).To(MatchError(gardener.DrainTimeoutError{InFlight: 1, Timeout: 10 * time.Millisecond}))
+			Expect(gdnr.DrainStatus().Error).To(Equal(err.Error()))
+			Expect(gdnr.DrainStatus().Done).To(BeTrue())
+		})
+	})
+
+	Context("when an operation on a container is in flight", func() {
+		var (
+			unblock  chan struct{}
+			streamed chan error
+		)
+
+		BeforeEach(func() {
+			unblock = make(chan struct{})
+			started := make(chan struct{})
+			containerizer.StreamInStub = func(lager.Logger, string, garden.StreamInSpec) error {
+				close(started)
+				<-unblock
+				return nil
+			}
+
+			container, err := gdnr.Lookup("some-handle")
+			Expect(err).NotTo(HaveOccurred())
+
+			streamed = make(chan error, 1)
+			go func() { streamed <- container.StreamIn(garden.StreamInSpec{Path: "/some/path"}) }()
+			Eventually(started).Should(BeClosed())
+		})
+
+		It("waits for it to finish", func() {
+			drained := make(chan error, 1)
+			go func() { drained <- gdnr.Drain(time.Minute, false) }()
+
+			Eventually(gdnr.DrainStatus).Should(Equal(gardener.DrainStatus{Draining: true, InFlight: 1}))
+			Consistently(drained).ShouldNot(Receive())
+
+			close(unblock)
+			Eventually(streamed).Should(Receive(BeNil()))
+			Eventually(drained).Should(Receive(BeNil()))
+		})
+	})
+
+	Context("when asked to stop the containers", func() {
+		BeforeEach(func() {
+			containerizer.HandlesReturns([]string{"first", "second"}, nil)
+		})
+
+		It("stops every container without killing it", func() {
+			Expect(gdnr.Drain(time.Second, true)).To(Succeed())
+
+			Expect(containerizer.StopCallCount()).To(Equal(2))
+			stopped := []string{}
+			for i := 0; i < 2; i++ {
+				_, handle, kill := containerizer.StopArgsForCall(i)
+				Expect(kill).To(BeFalse())
+				stopped = append(stopped, handle)
+			}
+			Expect(stopped).To(ConsistOf("first", "second"))
+
+			Expect(gdnr.DrainStatus()).To(Equal(gardener.DrainStatus{
+				Draining:   true,
+				Containers: 2,
+				Stopped:    2,
+				Done:       true,
+			}))
+		})
+
+		Context("when a container is paused", func() {
+			BeforeEach(func() {
+				containerizer.InfoStub = func(_ lager.Logger, handle string) (gardener.ActualContainerSpec, error) {
+					return gardener.ActualContainerSpec{Paused: handle == "second"}, nil
+				}
+			})
+
+			It("resumes it before stopping it", func() {
+				containerizer.StopStub = func(_ lager.Logger, handle string, _ bool) error {
+					if handle == "second" {
+						Expect(containerizer.ResumeCallCount()).To(Equal(1))
+					}
+					return nil
+				}
+
+				Expect(gdnr.Drain(time.Second, true)).To(Succeed())
+
+				Expect(containerizer.ResumeCallCount()).To(Equal(1))
+				_, handle := containerizer.ResumeArgsForCall(0)
+				Expect(handle).To(Equal("second"))
+				Expect(containerizer.StopCallCount()).To(Equal(2))
+			})
+
+			Context("when resuming it fails", func() {
+				BeforeEach(func() {
+					containerizer.ResumeReturns(errors.New("boom"))
+				})
+
+				It("does not try to stop it, and reports it", func() {
+					Expect(gdnr.Drain(time.Second, true)).To(MatchError("failed to stop 1 of 2 containers"))
+					Expect(containerizer.StopCallCount()).To(Equal(1))
+					_, handle, _ := containerizer.StopArgsForCall(0)
+					Expect(handle).To(Equal("first"))
+				})
+			})
+		})
+
+		It("reports containers which fail to stop, after trying them all", func() {
+			containerizer.StopStub = func(_ lager.Logger, handle string, _ bool) error {
+				if handle == "first" {
+					return errors.New("boom")
+				}
+				return nil
+			}
+
+			Expect(gdnr.Drain(time.Second, true)).To(MatchError("failed to stop 1 of 2 containers"))
+			Expect(containerizer.StopCallCount()).To(Equal(2))
+			Expect(gdnr.DrainStatus().Stopped).To(Equal(1))
+		})
+	})
+
+	Describe("the drain handler", func() {
+		var started int
+
+		BeforeEach(func() {
+			started = 0
+		})
+
+		serve := func(method string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			handler := gardener.NewDrainHandler(gdnr, func() { started++ })
+			handler.ServeHTTP(recorder, httptest.NewRequest(method, "/drain", nil))
+			return recorder
+		}
+
+		It("reports the drain status", func() {
+			recorder := serve("GET")
+
+			Expect(recorder.Code).To(Equal(200))
+			Expect(recorder.Body.String()).To(MatchJSON(`{"draining": false, "in_flight": 0, "containers": 0, "stopped": 0, "done": false}`))
+			Expect(started).To(Equal(0))
+		})
+
+		It("starts draining on a POST", func() {
+			recorder := serve("POST")
+
+			Expect(recorder.Code).To(Equal(202))
+			Expect(started).To(Equal(1))
+		})
+
+		It("refuses other methods", func() {
+			Expect(serve("DELETE").Code).To(Equal(405))
+			Expect(started).To(Equal(0))
+		})
+	})
+})
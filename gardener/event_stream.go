@@ -0,0 +1,118 @@
+package gardener
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . EventSubscriber
+
+type EventSubscriber interface {
+	Subscribe(filter func(Event) bool) (<-chan Event, func())
+}
+
+// EventStreamHandler streams events to HTTP clients as newline-delimited JSON
+// until they disconnect. A client which falls too far behind is sent an event
+// of kind "resync", and the stream ends. Clients choose the events they
+// receive with the query parameters:
+//
+//	handle=<handle>          only events for the given container, may be repeated
+//	property=<name>:<value>  only events for containers with the property, may be
+//	                         repeated, in which case every property must match
+type EventStreamHandler struct {
+	subscriber EventSubscriber
+	logger     lager.Logger
+}
+
+func NewEventStreamHandler(subscriber EventSubscriber, logger lager.Logger) *EventStreamHandler {
+	return &EventStreamHandler{
+		subscriber: subscriber,
+		logger:     logger,
+	}
+}
+
+func (h *EventStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.Session("stream-events", lager.Data{"query": r.URL.RawQuery})
+	log.Info("started")
+	defer log.Info("finished")
+
+	filter, err := eventFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := h.subscriber.Subscribe(filter)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if err := encoder.Encode(event); err != nil {
+				log.Error("encode-failed", err)
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+type invalidPropertyFilterError string
+
+func (err invalidPropertyFilterError) Error() string {
+	return "invalid property filter, expected name:value: " + string(err)
+}
+
+func eventFilter(r *http.Request) (func(Event) bool, error) {
+	query := r.URL.Query()
+
+	handles := map[string]bool{}
+	for _, handle := range query["handle"] {
+		handles[handle] = true
+	}
+
+	props := garden.Properties{}
+	for _, prop := range query["property"] {
+		parts := strings.SplitN(prop, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, invalidPropertyFilterError(prop)
+		}
+
+		props[parts[0]] = parts[1]
+	}
+
+	return func(event Event) bool {
+		if len(handles) > 0 && !handles[event.Handle] {
+			return false
+		}
+
+		for name, value := range props {
+			if event.Properties[name] != value {
+				return false
+			}
+		}
+
+		return true
+	}, nil
+}
@@ -0,0 +1,158 @@
+package gardener
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/pivotal-golang/clock"
+)
+
+//go:generate counterfeiter . EventPublisher
+
+type EventPublisher interface {
+	Publish(event Event)
+}
+
+type EventKind string
+
+const (
+	ContainerCreatedEvent   EventKind = "container-created"
+	ContainerDestroyedEvent EventKind = "container-destroyed"
+	ContainerStoppedEvent   EventKind = "container-stopped"
+	ContainerPausedEvent    EventKind = "container-paused"
+	ContainerResumedEvent   EventKind = "container-resumed"
+	OutOfMemoryEvent        EventKind = "out-of-memory"
+	ProcessStartedEvent     EventKind = "process-started"
+	ProcessExitedEvent      EventKind = "process-exited"
+	NetworkConfiguredEvent  EventKind = "network-configured"
+
+	// ResyncEvent is the last event a subscriber which fell too far behind is
+	// sent, before its channel is closed. It has no handle. The subscriber has
+	// missed events since, so should look at the containers again.
+	ResyncEvent EventKind = "resync"
+)
+
+// Event describes something which happened to a container
+type Event struct {
+	Kind   EventKind `json:"kind"`
+	Handle string    `json:"handle"`
+	Time   time.Time `json:"time"`
+
+	// The properties of the container when the event was published
+	Properties garden.Properties `json:"properties,omitempty"`
+
+	// Set for process events
+	ProcessID string `json:"process_id,omitempty"`
+
+	// Set for process exited events
+	ExitStatus *int `json:"exit_status,omitempty"`
+}
+
+// publish sends the event to the publisher, if there is one
+func publish(publisher EventPublisher, event Event) {
+	if publisher != nil {
+		publisher.Publish(event)
+	}
+}
+
+const subscriptionBufferSize = 256
+
+// EventBus delivers the events published about containers to each of its
+// subscribers. Publishing never blocks: a subscriber which falls
+// subscriptionBufferSize events behind is sent a ResyncEvent and unsubscribed.
+type EventBus struct {
+	properties PropertyManager
+	clock      clock.Clock
+
+	mu            sync.Mutex
+	subscriptions map[*subscription]struct{}
+}
+
+type subscription struct {
+	filter func(Event) bool
+	events chan Event
+}
+
+func NewEventBus(properties PropertyManager, clock clock.Clock) *EventBus {
+	return &EventBus{
+		properties:    properties,
+		clock:         clock,
+		subscriptions: map[*subscription]struct{}{},
+	}
+}
+
+// Publish stamps the event with the current time and, unless the publisher has
+// already captured them, the current properties of the container before
+// delivering it
+func (b *EventBus) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = b.clock.Now()
+	}
+
+	if event.Properties == nil {
+		event.Properties = b.propertiesOf(event.Handle)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscriptions {
+		if !sub.filter(event) {
+			continue
+		}
+
+		// only Publish sends to the channel, and always with the mutex held, so
+		// there is room for the ResyncEvent
+		if len(sub.events) >= subscriptionBufferSize {
+			sub.events <- Event{Kind: ResyncEvent, Time: event.Time}
+			b.unsubscribe(sub)
+			continue
+		}
+
+		sub.events <- event
+	}
+}
+
+// Subscribe returns a channel of the events which match the filter. The
+// returned function must be called to unsubscribe, after which the channel is
+// closed.
+func (b *EventBus) Subscribe(filter func(Event) bool) (<-chan Event, func()) {
+	sub := &subscription{
+		filter: filter,
+		// the extra slot is kept for the ResyncEvent
+		events: make(chan Event, subscriptionBufferSize+1),
+	}
+
+	b.mu.Lock()
+	b.subscriptions[sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return sub.events, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			// the subscriber may already have been unsubscribed for falling behind
+			if _, ok := b.subscriptions[sub]; ok {
+				b.unsubscribe(sub)
+			}
+		})
+	}
+}
+
+// unsubscribe must be called with the mutex held
+func (b *EventBus) unsubscribe(sub *subscription) {
+	delete(b.subscriptions, sub)
+	close(sub.events)
+}
+
+func (b *EventBus) propertiesOf(handle string) garden.Properties {
+	props, err := b.properties.All(handle)
+	if err != nil {
+		return nil
+	}
+
+	return props
+}
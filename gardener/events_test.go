@@ -0,0 +1,298 @@
+package gardener_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+)
+
+func receive(events <-chan gardener.Event) gardener.Event {
+	var event gardener.Event
+	EventuallyWithOffset(1, events).Should(Receive(&event))
+	return event
+}
+
+var _ = Describe("EventBus", func() {
+	var (
+		propertyManager *fakes.FakePropertyManager
+		clock           *fakeclock.FakeClock
+		bus             *gardener.EventBus
+	)
+
+	BeforeEach(func() {
+		propertyManager = new(fakes.FakePropertyManager)
+		clock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		bus = gardener.NewEventBus(propertyManager, clock)
+	})
+
+	It("delivers published events to subscribers whose filter matches", func() {
+		all, unsubscribeAll := bus.Subscribe(func(gardener.Event) bool { return true })
+		defer unsubscribeAll()
+		none, unsubscribeNone := bus.Subscribe(func(gardener.Event) bool { return false })
+		defer unsubscribeNone()
+
+		bus.Publish(gardener.Event{Kind: gardener.ContainerCreatedEvent, Handle: "some-handle"})
+
+		Expect(receive(all).Handle).To(Equal("some-handle"))
+		Expect(none).NotTo(Receive())
+	})
+
+	It("stamps events with the current time", func() {
+		events, unsubscribe := bus.Subscribe(func(gardener.Event) bool { return true })
+		defer unsubscribe()
+
+		bus.Publish(gardener.Event{Kind: gardener.ContainerCreatedEvent, Handle: "some-handle"})
+
+		Expect(receive(events).Time).To(Equal(time.Unix(123, 456)))
+	})
+
+	It("adds the properties of the container to events", func() {
+		propertyManager.AllReturns(garden.Properties{"foo": "bar"}, nil)
+		events, unsubscribe := bus.Subscribe(func(gardener.Event) bool { return true })
+		defer unsubscribe()
+
+		bus.Publish(gardener.Event{Kind: gardener.ContainerCreatedEvent, Handle: "some-handle"})
+
+		Expect(propertyManager.AllArgsForCall(0)).To(Equal("some-handle"))
+		Expect(receive(events).Properties).To(Equal(garden.Properties{"foo": "bar"}))
+	})
+
+	It("can publish while the properties of the container change", func() {
+		manager := properties.NewManager()
+		manager.Set("some-handle", "name", "value")
+		bus = gardener.NewEventBus(manager, clock)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 1000; i++ {
+				manager.Set("some-handle", "name", "value")
+				manager.Remove("some-handle", "name")
+			}
+		}()
+
+		for i := 0; i < 1000; i++ {
+			bus.Publish(gardener.Event{Kind: gardener.ContainerCreatedEvent, Handle: "some-handle"})
+		}
+		Eventually(done).Should(BeClosed())
+	})
+
+	Context("when the event already has properties", func() {
+		It("does not replace them", func() {
+			events, unsubscribe := bus.Subscribe(func(gardener.Event) bool { return true })
+			defer unsubscribe()
+
+			bus.Publish(gardener.Event{Handle: "some-handle", Properties: garden.Properties{"a": "b"}})
+
+			Expect(propertyManager.AllCallCount()).To(Equal(0))
+			Expect(receive(events).Properties).To(Equal(garden.Properties{"a": "b"}))
+		})
+	})
+
+	Context("when the properties of the container cannot be found", func() {
+		It("still delivers the event", func() {
+			propertyManager.AllReturns(nil, errors.New("no such keyspace"))
+			events, unsubscribe := bus.Subscribe(func(gardener.Event) bool { return true })
+			defer unsubscribe()
+
+			bus.Publish(gardener.Event{Handle: "some-handle"})
+
+			Expect(receive(events).Handle).To(Equal("some-handle"))
+		})
+	})
+
+	Context("when a subscriber is not keeping up", func() {
+		It("does not block", func() {
+			events, unsubscribe := bus.Subscribe(func(gardener.Event) bool { return true })
+			defer unsubscribe()
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := 0; i < 1000; i++ {
+					bus.Publish(gardener.Event{Handle: "some-handle"})
+				}
+			}()
+
+			Eventually(done).Should(BeClosed())
+			Expect(len(events)).To(BeNumerically("<", 1000))
+		})
+
+		It("tells the subscriber to resync once its buffer is full, and unsubscribes it", func() {
+			events, unsubscribe := bus.Subscribe(func(gardener.Event) bool { return true })
+			defer unsubscribe()
+
+			for i := 0; i < 300; i++ {
+				bus.Publish(gardener.Event{Handle: strconv.Itoa(i)})
+			}
+
+			for i := 0; i < 256; i++ {
+				Expect(receive(events).Handle).To(Equal(strconv.Itoa(i)))
+			}
+			Expect(receive(events)).To(Equal(gardener.Event{Kind: gardener.ResyncEvent, Time: clock.Now()}))
+			Expect(events).To(BeClosed())
+		})
+
+		It("does not send the subscriber events published after it was unsubscribed", func() {
+			events, unsubscribe := bus.Subscribe(func(gardener.Event) bool { return true })
+			defer unsubscribe()
+
+			for i := 0; i < 257; i++ {
+				bus.Publish(gardener.Event{Handle: "some-handle"})
+			}
+
+			var received []gardener.Event
+			for event := range events {
+				received = append(received, event)
+			}
+			Expect(received).To(HaveLen(257))
+
+			bus.Publish(gardener.Event{Handle: "some-handle"})
+			Expect(events).To(BeClosed())
+		})
+	})
+
+	Context("when unsubscribed", func() {
+		It("closes the channel and stops delivering events", func() {
+			events, unsubscribe := bus.Subscribe(func(gardener.Event) bool { return true })
+			unsubscribe()
+
+			bus.Publish(gardener.Event{Handle: "some-handle"})
+
+			Expect(events).To(BeClosed())
+		})
+
+		It("can be unsubscribed more than once", func() {
+			_, unsubscribe := bus.Subscribe(func(gardener.Event) bool { return true })
+			unsubscribe()
+			Expect(unsubscribe).NotTo(Panic())
+		})
+	})
+})
+
+var _ = Describe("EventStreamHandler", func() {
+	var (
+		propertyManager *fakes.FakePropertyManager
+		bus             *gardener.EventBus
+		server          *httptest.Server
+	)
+
+	BeforeEach(func() {
+		propertyManager = new(fakes.FakePropertyManager)
+		propertyManager.AllStub = func(handle string) (garden.Properties, error) {
+			if handle == "tagged" {
+				return garden.Properties{"team": "potato", "env": "prod"}, nil
+			}
+			return garden.Properties{}, nil
+		}
+
+		bus = gardener.NewEventBus(propertyManager, fakeclock.NewFakeClock(time.Unix(123, 0)))
+		server = httptest.NewServer(gardener.NewEventStreamHandler(bus, lagertest.NewTestLogger("test")))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	stream := func(query string) (*http.Response, <-chan gardener.Event) {
+		resp, err := http.Get(server.URL + "/events?" + query)
+		Expect(err).NotTo(HaveOccurred())
+
+		events := make(chan gardener.Event, 10)
+		go func() {
+			defer GinkgoRecover()
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				var event gardener.Event
+				Expect(json.Unmarshal(scanner.Bytes(), &event)).To(Succeed())
+				events <- event
+			}
+		}()
+
+		return resp, events
+	}
+
+	It("streams every event as JSON", func() {
+		resp, events := stream("")
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		// the handler subscribes before responding, so nothing published now is missed
+		bus.Publish(gardener.Event{Kind: gardener.ContainerStoppedEvent, Handle: "some-handle"})
+		event := receive(events)
+		Expect(event.Kind).To(Equal(gardener.ContainerStoppedEvent))
+		Expect(event.Handle).To(Equal("some-handle"))
+		Expect(event.Time.Equal(time.Unix(123, 0))).To(BeTrue())
+	})
+
+	Context("when the client falls too far behind", func() {
+		It("streams the resync event and ends the stream", func() {
+			subscriber := new(fakes.FakeEventSubscriber)
+			subscribed := make(chan gardener.Event, 1)
+			subscribed <- gardener.Event{Kind: gardener.ResyncEvent}
+			close(subscribed)
+			subscriber.SubscribeReturns(subscribed, func() {})
+
+			recorder := httptest.NewRecorder()
+			gardener.NewEventStreamHandler(subscriber, lagertest.NewTestLogger("test")).ServeHTTP(recorder, httptest.NewRequest("GET", "/events", nil))
+
+			var event gardener.Event
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &event)).To(Succeed())
+			Expect(event.Kind).To(Equal(gardener.ResyncEvent))
+		})
+	})
+
+	Context("when filtering by handle", func() {
+		It("only streams events for the given handles", func() {
+			resp, events := stream("handle=wanted&handle=tagged")
+			defer resp.Body.Close()
+
+			bus.Publish(gardener.Event{Handle: "wanted"})
+			bus.Publish(gardener.Event{Handle: "unwanted"})
+			bus.Publish(gardener.Event{Handle: "tagged"})
+
+			Expect(receive(events).Handle).To(Equal("wanted"))
+			Expect(receive(events).Handle).To(Equal("tagged"))
+			Consistently(events).ShouldNot(Receive())
+		})
+	})
+
+	Context("when filtering by property", func() {
+		It("only streams events for containers with every given property", func() {
+			resp, events := stream("property=team:potato&property=env:prod")
+			defer resp.Body.Close()
+
+			bus.Publish(gardener.Event{Handle: "untagged"})
+			bus.Publish(gardener.Event{Handle: "other", Properties: garden.Properties{"team": "potato"}})
+			bus.Publish(gardener.Event{Handle: "tagged"})
+
+			event := receive(events)
+			Expect(event.Handle).To(Equal("tagged"))
+			Expect(event.Properties).To(Equal(garden.Properties{"team": "potato", "env": "prod"}))
+			Consistently(events).ShouldNot(Receive())
+		})
+
+		Context("when the property filter is malformed", func() {
+			It("responds with a bad request", func() {
+				resp, err := http.Get(server.URL + "/events?property=potato")
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+
+				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
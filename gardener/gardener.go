@@ -1,16 +1,20 @@
 package gardener
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden-shed/rootfs_spec"
+	"code.cloudfoundry.org/guardian/properties"
 	"code.cloudfoundry.org/lager"
 )
 
@@ -23,11 +27,16 @@ import (
 //go:generate counterfeiter . Restorer
 //go:generate counterfeiter . Starter
 //go:generate counterfeiter . BulkStarter
+//go:generate counterfeiter . UncancellableVolumeCreator
 
 const ContainerIPKey = "garden.network.container-ip"
 const BridgeIPKey = "garden.network.host-ip"
 const ExternalIPKey = "garden.network.external-ip"
+const ContainerIPv6Key = "garden.network.container-ipv6"
+const BridgeIPv6Key = "garden.network.host-ipv6"
+const ExternalIPv6Key = "garden.network.external-ipv6"
 const MappedPortsKey = "garden.network.mapped-ports"
+const BandwidthLimitsKey = "garden.network.bandwidth-limits"
 const GraceTimeKey = "garden.grace-time"
 
 const RawRootFSScheme = "raw"
@@ -40,7 +49,7 @@ type SysInfoProvider interface {
 }
 
 type Containerizer interface {
-	Create(log lager.Logger, spec DesiredContainerSpec) error
+	Create(ctx context.Context, log lager.Logger, spec DesiredContainerSpec) error
 	Handles() ([]string, error)
 
 	StreamIn(log lager.Logger, handle string, spec garden.StreamInSpec) error
@@ -54,20 +63,24 @@ type Containerizer interface {
 
 	Info(log lager.Logger, handle string) (ActualContainerSpec, error)
 	Metrics(log lager.Logger, handle string) (ActualContainerMetrics, error)
+	UpdateLimits(log lager.Logger, handle string, limits garden.Limits) error
+	Pause(log lager.Logger, handle string) error
+	Resume(log lager.Logger, handle string) error
 }
 
 type Networker interface {
-	Network(log lager.Logger, spec garden.ContainerSpec, pid int) error
+	Network(ctx context.Context, log lager.Logger, spec garden.ContainerSpec, pid int) error
 	Capacity() uint64
 	Destroy(log lager.Logger, handle string) error
 	NetIn(log lager.Logger, handle string, hostPort, containerPort uint32) (uint32, uint32, error)
 	BulkNetOut(log lager.Logger, handle string, rules []garden.NetOutRule) error
 	NetOut(log lager.Logger, handle string, rule garden.NetOutRule) error
+	LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error
 	Restore(log lager.Logger, handle string) error
 }
 
 type VolumeCreator interface {
-	Create(log lager.Logger, handle string, spec rootfs_spec.Spec) (DesiredImageSpec, error)
+	Create(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (DesiredImageSpec, error)
 	Destroy(log lager.Logger, handle string) error
 	Metrics(log lager.Logger, handle string, privileged bool) (garden.ContainerDiskStat, error)
 	GC(log lager.Logger) error
@@ -97,7 +110,17 @@ type PropertyManager interface {
 	Remove(handle string, name string) error
 	Get(handle string, name string) (string, bool)
 	MatchesAll(handle string, props garden.Properties) bool
+	Matching(props garden.Properties) []string
 	DestroyKeySpace(string) error
+	Handles() []string
+	Apply(handle string, batch properties.Batch) error
+}
+
+// PropertyBatcher is implemented by the containers of the gardener, so that
+// clients can make several changes to the properties of a container at once,
+// provided the properties they depend on have not been changed by others
+type PropertyBatcher interface {
+	ApplyProperties(batch properties.Batch) error
 }
 
 type Starter interface {
@@ -154,6 +177,9 @@ type ActualContainerSpec struct {
 	// Whether the container is stopped
 	Stopped bool
 
+	// Whether the container is paused
+	Paused bool
+
 	// Process IDs (not PIDs) of processes in the container
 	ProcessIDs []string
 
@@ -170,6 +196,7 @@ type ActualContainerSpec struct {
 type ActualContainerMetrics struct {
 	CPU    garden.ContainerCPUStat
 	Memory garden.ContainerMemoryStat
+	Pid    garden.ContainerPidStat
 }
 
 // Gardener orchestrates other components to implement the Garden API
@@ -200,15 +227,122 @@ type Gardener struct {
 	// MaxContainers limits the advertised container capacity
 	MaxContainers uint64
 
+	// ReservedMemory and ReservedDisk are held back for the host, so are not
+	// reported as allocatable by Capacity
+	ReservedMemory uint64
+	ReservedDisk   uint64
+
+	// MemoryOvercommitRatio is the multiple of the host's unreserved memory which
+	// the memory limits of all containers may add up to. When it is zero,
+	// creates are never refused on account of memory.
+	MemoryOvercommitRatio float64
+
 	Restorer Restorer
+
+	// OrphanSources remove resources left behind by containers which no longer
+	// exist when the gardener starts, keyed by a name used in the report
+	OrphanSources map[string]OrphanSource
+
+	// Profiles hold defaults for the specs of containers which pick them with
+	// the ProfileKey property
+	Profiles Profiles
+
+	// DefaultGraceTime is given to containers which ask for no grace time and
+	// whose profile gives none. The API server must not be given a default
+	// grace time of its own, or the profiles could never tell that a client
+	// asked for none.
+	DefaultGraceTime time.Duration
+
+	// Quotas caps the resources the containers of each tenant may use. When it
+	// is nil, tenants are not limited.
+	Quotas *Quotas
+
+	// Admitter rejects container specs which break site policy. When it is nil,
+	// every spec is admitted.
+	Admitter Admitter
+
+	// ReservedPropertyPrefixes are namespaces of properties which clients may
+	// not change, as well as the DefaultReservedPropertyPrefixes
+	ReservedPropertyPrefixes []string
+
+	// EventBus is told about changes in the lifecycle of containers
+	EventBus EventPublisher
+
+	// Tracer is told how long each stage of the creates and destroys takes.
+	// When it is nil, nothing is recorded.
+	Tracer Tracer
+
+	// BulkWorkers limits the number of containers BulkInfo and BulkMetrics
+	// work on at once, defaulting to DefaultBulkWorkers
+	BulkWorkers int
+
+	// Reaper destroys idle containers once their grace time has passed. When
+	// it is nil, expiring idle containers is left to the API server.
+	Reaper *Reaper
+
+	// BulkTimeout limits the time BulkInfo and BulkMetrics wait for each
+	// container, or is zero to wait indefinitely
+	BulkTimeout time.Duration
+
+	// CreateTimeouts limit the time each stage of Create may take
+	CreateTimeouts CreateTimeouts
+
+	reconcileMutex  sync.Mutex
+	reconcileReport ReconcileReport
+
+	// lifecycle rejects operations that conflict with a create or destroy in progress
+	lifecycle handleLifecycle
+
+	// handles holds the handles of the containers, so listing them needs no disk access
+	handles handleRegistry
+
+	// capacity holds the memory and disk limits committed to the containers
+	capacity capacityLedger
+
+	// bulkInFlight counts the BulkInfo and BulkMetrics calls still running for each container
+	bulkInFlight bulkInFlight
 }
 
 // Create creates a container by combining the results of networker.Network,
 // volumizer.Create and containzer.Create.
-func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err error) {
+func (g *Gardener) Create(spec garden.ContainerSpec) (garden.Container, error) {
+	return g.CreateContext(context.Background(), spec)
+}
+
+// CreateContext creates a container like Create, but gives up once ctx is done.
+// Whatever was created before then is cleaned up.
+func (g *Gardener) CreateContext(ctx context.Context, spec garden.ContainerSpec) (ctr garden.Container, err error) {
 	log := g.Logger.Session("create", lager.Data{"handle": spec.Handle})
 	log.Info("start")
 
+	doneDraining, err := g.lifecycle.drain.begin(false)
+	if err != nil {
+		return nil, err
+	}
+	defer doneDraining()
+
+	if spec.Handle == "" {
+		spec.Handle = g.UidGenerator.Generate()
+	}
+
+	if err := g.reservedProperties().checkSpec(spec.Properties); err != nil {
+		return nil, err
+	}
+
+	spec, err = g.Profiles.apply(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.GraceTime == 0 {
+		spec.GraceTime = g.DefaultGraceTime
+	}
+
+	if err := g.lifecycle.startCreating(spec.Handle); err != nil {
+		return nil, err
+	}
+	defer g.lifecycle.finish(spec.Handle)
+
 	knownHandles, err := g.Containerizer.Handles()
 	if err != nil {
 		return nil, err
@@ -222,8 +356,20 @@ func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err
 		return nil, err
 	}
 
-	if spec.Handle == "" {
-		spec.Handle = g.UidGenerator.Generate()
+	if g.Admitter != nil {
+		if err := g.Admitter.Admit(ctx, log.Session("admit"), spec); err != nil {
+			return nil, err
+		}
+	}
+
+	doneQuota, err := g.Quotas.reserveCreate(spec)
+	if err != nil {
+		return nil, err
+	}
+	defer doneQuota()
+
+	if err := g.commitCapacity(spec); err != nil {
+		return nil, err
 	}
 
 	defer func() {
@@ -242,6 +388,8 @@ func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err
 			log.Info("cleanedup")
 		} else {
 			log.Info("created")
+			g.handles.add(spec.Handle)
+			publish(g.EventBus, Event{Kind: ContainerCreatedEvent, Handle: spec.Handle})
 		}
 	}()
 
@@ -266,29 +414,37 @@ func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err
 	if rootFSURL.Scheme == RawRootFSScheme {
 		desiredImageSpec.RootFS = rootFSURL.Path
 	} else {
-		var err error
-		desiredImageSpec, err = g.VolumeCreator.Create(log.Session(volumeCreatorSession), spec.Handle, rootfs_spec.Spec{
-			RootFS:     rootFSURL,
-			Username:   spec.Image.Username,
-			Password:   spec.Image.Password,
-			QuotaSize:  int64(spec.Limits.Disk.ByteHard),
-			QuotaScope: spec.Limits.Disk.Scope,
-			Namespaced: !spec.Privileged,
-		})
-		if err != nil {
+		if err := g.trace("create", spec.Handle, "volume", func() error {
+			return runStage(ctx, spec.Handle, "volume", g.CreateTimeouts.Volume, func(ctx context.Context) error {
+				var err error
+				desiredImageSpec, err = g.VolumeCreator.Create(ctx, log.Session(volumeCreatorSession), spec.Handle, rootfs_spec.Spec{
+					RootFS:     rootFSURL,
+					Username:   spec.Image.Username,
+					Password:   spec.Image.Password,
+					QuotaSize:  int64(spec.Limits.Disk.ByteHard),
+					QuotaScope: spec.Limits.Disk.Scope,
+					Namespaced: !spec.Privileged,
+				})
+				return err
+			})
+		}); err != nil {
 			return nil, err
 		}
 	}
 
-	if err := g.Containerizer.Create(log, DesiredContainerSpec{
-		Handle:                 spec.Handle,
-		RootFSPath:             desiredImageSpec.RootFS,
-		Hostname:               spec.Handle,
-		Privileged:             spec.Privileged,
-		BindMounts:             spec.BindMounts,
-		DesiredImageSpecMounts: desiredImageSpec.Mounts,
-		Limits:                 spec.Limits,
-		Env:                    append(desiredImageSpec.Image.Config.Env, spec.Env...),
+	if err := g.trace("create", spec.Handle, "container", func() error {
+		return runStage(ctx, spec.Handle, "container", g.CreateTimeouts.Container, func(ctx context.Context) error {
+			return g.Containerizer.Create(ctx, log, DesiredContainerSpec{
+				Handle:                 spec.Handle,
+				RootFSPath:             desiredImageSpec.RootFS,
+				Hostname:               spec.Handle,
+				Privileged:             spec.Privileged,
+				BindMounts:             spec.BindMounts,
+				DesiredImageSpecMounts: desiredImageSpec.Mounts,
+				Limits:                 spec.Limits,
+				Env:                    append(desiredImageSpec.Image.Config.Env, spec.Env...),
+			})
+		})
 	}); err != nil {
 		return nil, err
 	}
@@ -298,35 +454,54 @@ func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err
 		return nil, err
 	}
 
-	if err = g.Networker.Network(log, spec, actualSpec.Pid); err != nil {
+	if err := g.trace("create", spec.Handle, "network", func() error {
+		return runStage(ctx, spec.Handle, "network", g.CreateTimeouts.Network, func(ctx context.Context) error {
+			return g.Networker.Network(ctx, log, spec, actualSpec.Pid)
+		})
+	}); err != nil {
 		return nil, err
 	}
+	publish(g.EventBus, Event{Kind: NetworkConfiguredEvent, Handle: spec.Handle})
 
 	container, err := g.Lookup(spec.Handle)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := g.trace("create", spec.Handle, "properties", func() error {
+		return g.setProperties(container, spec)
+	}); err != nil {
+		return nil, err
+	}
+
+	g.Reaper.Track(spec.Handle)
+
+	return container, nil
+}
+
+// setProperties records the properties of a new container, ending with its
+// state, so that it only matches property filters once it is fully created
+func (g *Gardener) setProperties(container garden.Container, spec garden.ContainerSpec) error {
 	if spec.GraceTime != 0 {
 		if err := container.SetGraceTime(spec.GraceTime); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	for name, value := range spec.Properties {
-		if err := container.SetProperty(name, value); err != nil {
-			return nil, err
-		}
+		g.PropertyManager.Set(spec.Handle, name, value)
 	}
 
-	if err := container.SetProperty("garden.state", "created"); err != nil {
-		return nil, err
-	}
+	g.recordCommitment(spec.Handle, spec.Limits)
 
-	return container, nil
+	g.PropertyManager.Set(spec.Handle, "garden.state", "created")
+	return nil
 }
 
+// Lookup is called by clients before each operation on a container, so it
+// counts as activity on the container
 func (g *Gardener) Lookup(handle string) (garden.Container, error) {
+	g.Reaper.Touch(handle)
 	return g.lookup(handle), nil
 }
 
@@ -338,7 +513,51 @@ func (g *Gardener) lookup(handle string) garden.Container {
 		volumeCreator:   g.VolumeCreator,
 		networker:       g.Networker,
 		propertyManager: g.PropertyManager,
+		admitter:        g.Admitter,
+		lifecycle:       &g.lifecycle,
+		eventBus:        g.EventBus,
+		reaper:          g.Reaper,
+		capacity:        &g.capacity,
+		memoryBudget:    g.memoryBudget(),
+		quotas:          g.Quotas,
+		reserved:        g.reservedProperties(),
+	}
+}
+
+// Pause freezes all of the processes in the container until it is resumed
+func (g *Gardener) Pause(handle string) error {
+	log := g.Logger.Session("pause", lager.Data{"handle": handle})
+
+	release, err := g.lifecycle.use(handle)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := g.Containerizer.Pause(log, handle); err != nil {
+		return err
+	}
+
+	publish(g.EventBus, Event{Kind: ContainerPausedEvent, Handle: handle})
+	return nil
+}
+
+// Resume thaws all of the processes in a paused container
+func (g *Gardener) Resume(handle string) error {
+	log := g.Logger.Session("resume", lager.Data{"handle": handle})
+
+	release, err := g.lifecycle.use(handle)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := g.Containerizer.Resume(log, handle); err != nil {
+		return err
 	}
+
+	publish(g.EventBus, Event{Kind: ContainerResumedEvent, Handle: handle})
+	return nil
 }
 
 func (g *Gardener) Destroy(handle string) error {
@@ -347,6 +566,15 @@ func (g *Gardener) Destroy(handle string) error {
 	log.Info("start")
 	defer log.Info("finished")
 
+	// destroys go on while draining, but are waited for
+	doneDraining, _ := g.lifecycle.drain.begin(true)
+	defer doneDraining()
+
+	if err := g.lifecycle.startDestroying(handle); err != nil {
+		return err
+	}
+	defer g.lifecycle.finish(handle)
+
 	handles, err := g.Containerizer.Handles()
 	if err != nil {
 		return err
@@ -356,49 +584,67 @@ func (g *Gardener) Destroy(handle string) error {
 		return garden.ContainerNotFoundError{Handle: handle}
 	}
 
-	return g.destroy(log, handle)
+	// the properties are gone once the container is, so capture them for the event now
+	props, _ := g.PropertyManager.All(handle)
+
+	if err := g.destroy(log, handle); err != nil {
+		return err
+	}
+	g.handles.remove(handle)
+	g.Reaper.Forget(handle)
+
+	publish(g.EventBus, Event{Kind: ContainerDestroyedEvent, Handle: handle, Properties: props})
+	return nil
 }
 
 // destroy idempotently destroys any resources associated with the given handle
 func (g *Gardener) destroy(log lager.Logger, handle string) error {
-	if err := g.Containerizer.Destroy(log, handle); err != nil {
+	if err := g.trace("destroy", handle, "container", func() error {
+		return g.Containerizer.Destroy(log, handle)
+	}); err != nil {
 		return err
 	}
 
-	if err := g.Networker.Destroy(log, handle); err != nil {
+	if err := g.trace("destroy", handle, "network", func() error {
+		return g.Networker.Destroy(log, handle)
+	}); err != nil {
 		return err
 	}
 
-	if err := g.VolumeCreator.Destroy(log.Session(volumeCreatorSession), handle); err != nil {
+	if err := g.trace("destroy", handle, "volume", func() error {
+		return g.VolumeCreator.Destroy(log.Session(volumeCreatorSession), handle)
+	}); err != nil {
 		return err
 	}
 
-	if err := g.PropertyManager.DestroyKeySpace(handle); err != nil {
+	if err := g.trace("destroy", handle, "properties", func() error {
+		return g.PropertyManager.DestroyKeySpace(handle)
+	}); err != nil {
 		return err
 	}
+	g.capacity.release(handle)
 
-	return g.Containerizer.RemoveBundle(log, handle)
+	return g.trace("destroy", handle, "bundle", func() error {
+		return g.Containerizer.RemoveBundle(log, handle)
+	})
 }
 
 func (g *Gardener) Stop() {}
 
+// GraceTime is used by the API server to expire idle containers, so it is
+// reported as zero when the gardener expires them itself
 func (g *Gardener) GraceTime(container garden.Container) time.Duration {
-	property, ok := g.PropertyManager.Get(container.Handle(), GraceTimeKey)
-	if !ok {
+	if g.Reaper != nil {
 		return 0
 	}
 
-	var graceTime time.Duration
-	_, err := fmt.Sscanf(property, "%d", &graceTime)
-	if err != nil {
-		return 0
-	}
-
-	return graceTime
+	return graceTime(g.PropertyManager, container.Handle())
 }
 
 func (g *Gardener) Ping() error { return nil }
 
+// Capacity reports the memory and disk which are left for new containers once
+// the host reservation and the limits of existing containers are taken away
 func (g *Gardener) Capacity() (garden.Capacity, error) {
 	mem, err := g.SysInfoProvider.TotalMemory()
 	if err != nil {
@@ -410,14 +656,16 @@ func (g *Gardener) Capacity() (garden.Capacity, error) {
 		return garden.Capacity{}, err
 	}
 
+	committedMem, committedDisk := g.capacity.committed(g.PropertyManager)
+
 	cap := g.Networker.Capacity()
 	if g.MaxContainers > 0 && g.MaxContainers < cap {
 		cap = g.MaxContainers
 	}
 
 	return garden.Capacity{
-		MemoryInBytes: mem,
-		DiskInBytes:   disk,
+		MemoryInBytes: allocatable(mem, g.ReservedMemory, committedMem),
+		DiskInBytes:   allocatable(disk, g.ReservedDisk, committedDisk),
 		MaxContainers: cap,
 	}, nil
 }
@@ -428,7 +676,7 @@ func (g *Gardener) Containers(props garden.Properties) ([]garden.Container, erro
 	log.Info("starting")
 	defer log.Info("finished")
 
-	handles, err := g.Containerizer.Handles()
+	handles, err := g.handles.list(g.Containerizer.Handles)
 	if err != nil {
 		log.Error("handles-failed", err)
 		return []garden.Container{}, err
@@ -439,10 +687,14 @@ func (g *Gardener) Containers(props garden.Properties) ([]garden.Container, erro
 	}
 	props["garden.state"] = "created"
 
+	matching := map[string]bool{}
+	for _, handle := range g.PropertyManager.Matching(props) {
+		matching[handle] = true
+	}
+
 	var containers []garden.Container
 	for _, handle := range handles {
-		matched := g.PropertyManager.MatchesAll(handle, props)
-		if matched {
+		if matching[handle] {
 			containers = append(containers, g.lookup(handle))
 		}
 	}
@@ -451,15 +703,18 @@ func (g *Gardener) Containers(props garden.Properties) ([]garden.Container, erro
 }
 
 func (g *Gardener) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
-	result := make(map[string]garden.ContainerInfoEntry)
-	for _, handle := range handles {
-		container := g.lookup(handle)
+	results := g.bulk(handles, func(handle string) (interface{}, error) {
+		return g.lookup(handle).Info()
+	})
 
+	result := make(map[string]garden.ContainerInfoEntry)
+	for handle, r := range results {
 		var infoErr *garden.Error = nil
-		info, err := container.Info()
-		if err != nil {
-			infoErr = garden.NewError(err.Error())
+		if r.err != nil {
+			infoErr = garden.NewError(r.err.Error())
 		}
+
+		info, _ := r.value.(garden.ContainerInfo)
 		result[handle] = garden.ContainerInfoEntry{
 			Info: info,
 			Err:  infoErr,
@@ -470,14 +725,18 @@ func (g *Gardener) BulkInfo(handles []string) (map[string]garden.ContainerInfoEn
 }
 
 func (g *Gardener) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	results := g.bulk(handles, func(handle string) (interface{}, error) {
+		return g.lookup(handle).Metrics()
+	})
+
 	result := make(map[string]garden.ContainerMetricsEntry)
-	for _, handle := range handles {
+	for handle, r := range results {
 		var e *garden.Error
-		m, err := g.lookup(handle).Metrics()
-		if err != nil {
-			e = garden.NewError(err.Error())
+		if r.err != nil {
+			e = garden.NewError(r.err.Error())
 		}
 
+		m, _ := r.value.(garden.Metrics)
 		result[handle] = garden.ContainerMetricsEntry{
 			Err:     e,
 			Metrics: m,
@@ -489,7 +748,7 @@ func (g *Gardener) BulkMetrics(handles []string) (map[string]garden.ContainerMet
 
 func (g *Gardener) checkDuplicateHandle(knownHandles []string, handle string) error {
 	if g.exists(knownHandles, handle) {
-		return fmt.Errorf("Handle '%s' already in use", handle)
+		return HandleAlreadyExistsError{Handle: handle}
 	}
 
 	return nil
@@ -505,6 +764,44 @@ func (g *Gardener) exists(handles []string, handle string) bool {
 	return false
 }
 
+// commitCapacity records the limits of a new container, refusing them if they
+// would take the committed memory past the overcommit ratio
+func (g *Gardener) commitCapacity(spec garden.ContainerSpec) error {
+	memoryLimit, err := g.memoryBudget().limit()
+	if err != nil {
+		return err
+	}
+
+	return g.capacity.commit(g.PropertyManager, spec.Handle, commitment{
+		memory: spec.Limits.Memory.LimitInBytes,
+		disk:   spec.Limits.Disk.ByteHard,
+	}, memoryLimit)
+}
+
+func (g *Gardener) memoryBudget() memoryBudget {
+	return memoryBudget{
+		sysInfo:         g.SysInfoProvider,
+		reserved:        g.ReservedMemory,
+		overcommitRatio: g.MemoryOvercommitRatio,
+	}
+}
+
+// recordCommitment stores the limits committed to a container in its
+// properties, so they are accounted for after a restart
+func (g *Gardener) recordCommitment(handle string, limits garden.Limits) {
+	if limits.Memory.LimitInBytes != 0 {
+		g.PropertyManager.Set(handle, CommittedMemoryKey, strconv.FormatUint(limits.Memory.LimitInBytes, 10))
+	}
+
+	if limits.Disk.ByteHard != 0 {
+		g.PropertyManager.Set(handle, CommittedDiskKey, strconv.FormatUint(limits.Disk.ByteHard, 10))
+	}
+
+	if limits.CPU.LimitInShares != 0 {
+		g.PropertyManager.Set(handle, CommittedCPUKey, strconv.FormatUint(limits.CPU.LimitInShares, 10))
+	}
+}
+
 func (g *Gardener) checkMaxContainers(handles []string) error {
 	if g.MaxContainers == 0 {
 		return nil
@@ -532,17 +829,30 @@ func (g *Gardener) Start() error {
 		return err
 	}
 
-	for _, handle := range g.Restorer.Restore(log, handles) {
-		destroyLog := log.Session("clean-up-container", lager.Data{"handle": handle})
-		destroyLog.Info("start")
+	report := g.reconcile(log, handles)
+	log.Info("report", lager.Data{"report": report})
 
-		if err := g.destroy(destroyLog, handle); err != nil {
-			destroyLog.Error("failed", err)
-			continue
-		}
+	// reconciling may have destroyed containers, so list them afresh next time
+	g.handles.reset()
+	g.capacity.reset()
 
-		destroyLog.Info("cleaned-up")
-	}
+	g.Reaper.Start(difference(handles, report.Destroyed))
+
+	g.reconcileMutex.Lock()
+	g.reconcileReport = report
+	g.reconcileMutex.Unlock()
 
 	return nil
 }
+
+// reservedProperties are the properties clients may not change. The tenant of
+// a container may only be given when it is created, so that clients cannot
+// move their containers out of the quota of their tenant.
+func (g *Gardener) reservedProperties() reservedProperties {
+	reserved := reservedProperties{prefixes: g.ReservedPropertyPrefixes}
+	if g.Quotas != nil {
+		reserved.createOnly = []string{g.Quotas.property}
+	}
+
+	return reserved
+}
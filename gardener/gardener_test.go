@@ -1,20 +1,25 @@
 package gardener_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/guardian/gardener"
 	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/guardian/properties"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pivotal-golang/clock/fakeclock"
 )
 
 var _ = Describe("Gardener", func() {
@@ -27,6 +32,7 @@ var _ = Describe("Gardener", func() {
 		sysinfoProvider *fakes.FakeSysInfoProvider
 		propertyManager *fakes.FakePropertyManager
 		restorer        *fakes.FakeRestorer
+		eventBus        *fakes.FakeEventPublisher
 
 		logger lager.Logger
 
@@ -43,6 +49,11 @@ var _ = Describe("Gardener", func() {
 		sysinfoProvider = new(fakes.FakeSysInfoProvider)
 		propertyManager = new(fakes.FakePropertyManager)
 		restorer = new(fakes.FakeRestorer)
+		eventBus = new(fakes.FakeEventPublisher)
+
+		process := new(gardenfakes.FakeProcess)
+		process.IDReturns("some-process")
+		containerizer.RunReturns(process, nil)
 
 		propertyManager.GetReturns("", true)
 		containerizer.HandlesReturns([]string{"some-handle"}, nil)
@@ -59,6 +70,7 @@ var _ = Describe("Gardener", func() {
 			PropertyManager: propertyManager,
 			Restorer:        restorer,
 			MaxContainers:   0,
+			EventBus:        eventBus,
 		}
 	})
 
@@ -98,7 +110,7 @@ var _ = Describe("Gardener", func() {
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(containerizer.CreateCallCount()).To(Equal(1))
-			_, spec := containerizer.CreateArgsForCall(0)
+			_, _, spec := containerizer.CreateArgsForCall(0)
 			Expect(spec.Handle).To(Equal("generated-handle"))
 		})
 
@@ -108,11 +120,30 @@ var _ = Describe("Gardener", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(containerizer.CreateCallCount()).To(Equal(1))
-				_, spec := containerizer.CreateArgsForCall(0)
+				_, _, spec := containerizer.CreateArgsForCall(0)
 				Expect(spec.Handle).To(Equal("handle"))
 			})
 		})
 
+		It("publishes events once the network is configured and the container is created", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{Handle: "handle"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(eventBus.PublishCallCount()).To(Equal(2))
+			Expect(eventBus.PublishArgsForCall(0)).To(Equal(gardener.Event{Kind: gardener.NetworkConfiguredEvent, Handle: "handle"}))
+			Expect(eventBus.PublishArgsForCall(1)).To(Equal(gardener.Event{Kind: gardener.ContainerCreatedEvent, Handle: "handle"}))
+		})
+
+		Context("when creating the container fails", func() {
+			It("does not publish a created event", func() {
+				containerizer.CreateReturns(errors.New("boom"))
+
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "handle"})
+				Expect(err).To(HaveOccurred())
+				Expect(eventBus.PublishCallCount()).To(Equal(0))
+			})
+		})
+
 		It("runs the graph cleanup", func() {
 			_, err := gdnr.Create(garden.ContainerSpec{})
 			Expect(err).NotTo(HaveOccurred())
@@ -144,7 +175,7 @@ var _ = Describe("Gardener", func() {
 				_, err := gdnr.Create(garden.ContainerSpec{RootFSPath: "/some/path"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(volumeCreator.CreateCallCount()).To(Equal(1))
-				_, _, fsSpec := volumeCreator.CreateArgsForCall(0)
+				_, _, _, fsSpec := volumeCreator.CreateArgsForCall(0)
 				Expect(fsSpec.RootFS.Path).To(Equal("/some/path"))
 			})
 		})
@@ -165,7 +196,7 @@ var _ = Describe("Gardener", func() {
 				_, err := gdnr.Create(garden.ContainerSpec{Image: garden.ImageRef{URI: "/some/path"}})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(volumeCreator.CreateCallCount()).To(Equal(1))
-				_, _, fsSpec := volumeCreator.CreateArgsForCall(0)
+				_, _, _, fsSpec := volumeCreator.CreateArgsForCall(0)
 				Expect(fsSpec.RootFS.Path).To(Equal("/some/path"))
 			})
 		})
@@ -189,7 +220,7 @@ var _ = Describe("Gardener", func() {
 
 			It("creates the container with the given path", func() {
 				Expect(containerizer.CreateCallCount()).To(Equal(1))
-				_, spec := containerizer.CreateArgsForCall(0)
+				_, _, spec := containerizer.CreateArgsForCall(0)
 				Expect(spec.RootFSPath).To(Equal("/banana"))
 			})
 
@@ -202,7 +233,7 @@ var _ = Describe("Gardener", func() {
 			_, err := gdnr.Create(garden.ContainerSpec{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(volumeCreator.CreateCallCount()).To(Equal(1))
-			_, _, fsSpec := volumeCreator.CreateArgsForCall(0)
+			_, _, _, fsSpec := volumeCreator.CreateArgsForCall(0)
 			Expect(fsSpec.Namespaced).To(BeTrue())
 		})
 
@@ -213,7 +244,7 @@ var _ = Describe("Gardener", func() {
 				})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(volumeCreator.CreateCallCount()).To(Equal(1))
-				_, _, fsSpec := volumeCreator.CreateArgsForCall(0)
+				_, _, _, fsSpec := volumeCreator.CreateArgsForCall(0)
 				Expect(fsSpec.Namespaced).To(BeFalse())
 			})
 		})
@@ -241,7 +272,7 @@ var _ = Describe("Gardener", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(containerizer.CreateCallCount()).To(Equal(1))
-			_, spec := containerizer.CreateArgsForCall(0)
+			_, _, spec := containerizer.CreateArgsForCall(0)
 			Expect(spec.Handle).To(Equal("bob"))
 			Expect(spec.Privileged).To(BeTrue())
 		})
@@ -250,7 +281,7 @@ var _ = Describe("Gardener", func() {
 			_, err := gdnr.Create(garden.ContainerSpec{Handle: "bob"})
 			Expect(err).NotTo(HaveOccurred())
 
-			_, spec := containerizer.CreateArgsForCall(0)
+			_, _, spec := containerizer.CreateArgsForCall(0)
 			Expect(spec.Hostname).To(Equal("bob"))
 		})
 
@@ -288,7 +319,7 @@ var _ = Describe("Gardener", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(volumeCreator.CreateCallCount()).To(Equal(1))
-				_, _, rpSpec := volumeCreator.CreateArgsForCall(0)
+				_, _, _, rpSpec := volumeCreator.CreateArgsForCall(0)
 				Expect(rpSpec.QuotaSize).To(BeEquivalentTo(spec.Limits.Disk.ByteHard))
 				Expect(rpSpec.QuotaScope).To(Equal(garden.DiskLimitScopeTotal))
 			})
@@ -306,7 +337,7 @@ var _ = Describe("Gardener", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(containerizer.CreateCallCount()).To(Equal(1))
-				_, desiredSpec := containerizer.CreateArgsForCall(0)
+				_, _, desiredSpec := containerizer.CreateArgsForCall(0)
 				Expect(desiredSpec.Limits.Pid.Max).To(BeNumerically("==", 1))
 			})
 		})
@@ -320,7 +351,7 @@ var _ = Describe("Gardener", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(networker.NetworkCallCount()).To(Equal(1))
-			_, spec, pid := networker.NetworkArgsForCall(0)
+			_, _, spec, pid := networker.NetworkArgsForCall(0)
 			Expect(spec).To(Equal(garden.ContainerSpec{
 				Handle: "bob",
 			}))
@@ -374,7 +405,7 @@ var _ = Describe("Gardener", func() {
 
 				Expect(containerizer.CreateCallCount()).To(Equal(1))
 
-				_, spec := containerizer.CreateArgsForCall(0)
+				_, _, spec := containerizer.CreateArgsForCall(0)
 				Expect(spec.Limits).To(Equal(memLimit))
 			})
 		})
@@ -399,7 +430,7 @@ var _ = Describe("Gardener", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(containerizer.CreateCallCount()).To(Equal(1))
-			_, spec := containerizer.CreateArgsForCall(0)
+			_, _, spec := containerizer.CreateArgsForCall(0)
 			Expect(spec.RootFSPath).To(Equal("rootfs"))
 			Expect(spec.Env).To(Equal([]string{"some-env"}))
 			Expect(spec.DesiredImageSpecMounts).To(Equal([]specs.Mount{{
@@ -419,7 +450,7 @@ var _ = Describe("Gardener", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(containerizer.CreateCallCount()).To(Equal(1))
-				_, spec := containerizer.CreateArgsForCall(0)
+				_, _, spec := containerizer.CreateArgsForCall(0)
 				Expect(spec.Env).To(Equal([]string{
 					"ENV.CONTAINER_ID=1",
 					"ENV.CONTAINER_NAME=garden",
@@ -472,6 +503,68 @@ var _ = Describe("Gardener", func() {
 			})
 		})
 
+		Describe("admission", func() {
+			var admitter *fakes.FakeAdmitter
+
+			BeforeEach(func() {
+				admitter = new(fakes.FakeAdmitter)
+				gdnr.Admitter = admitter
+			})
+
+			It("asks the admitter about the spec", func() {
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle-to-admit", Privileged: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(admitter.AdmitCallCount()).To(Equal(1))
+				_, _, spec := admitter.AdmitArgsForCall(0)
+				Expect(spec.Handle).To(Equal("some-handle-to-admit"))
+				Expect(spec.Privileged).To(BeTrue())
+			})
+
+			It("passes the generated handle when none is specified", func() {
+				uidGenerator.GenerateReturns("generated-handle")
+
+				_, err := gdnr.Create(garden.ContainerSpec{})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, spec := admitter.AdmitArgsForCall(0)
+				Expect(spec.Handle).To(Equal("generated-handle"))
+			})
+
+			Context("when the admitter denies the spec", func() {
+				BeforeEach(func() {
+					admitter.AdmitReturns(gardener.AdmissionDeniedError{Handle: "denied", Reason: "privileged containers are not allowed"})
+				})
+
+				It("returns the denial", func() {
+					_, err := gdnr.Create(garden.ContainerSpec{Handle: "denied"})
+					Expect(err).To(MatchError(gardener.AdmissionDeniedError{Handle: "denied", Reason: "privileged containers are not allowed"}))
+				})
+
+				It("does not allocate any resources", func() {
+					gdnr.Create(garden.ContainerSpec{Handle: "denied"})
+
+					Expect(volumeCreator.GCCallCount()).To(Equal(0))
+					Expect(volumeCreator.CreateCallCount()).To(Equal(0))
+					Expect(containerizer.CreateCallCount()).To(Equal(0))
+					Expect(networker.NetworkCallCount()).To(Equal(0))
+				})
+
+				It("does not try to clean up", func() {
+					gdnr.Create(garden.ContainerSpec{Handle: "denied"})
+
+					Expect(containerizer.DestroyCallCount()).To(Equal(0))
+					Expect(volumeCreator.DestroyCallCount()).To(Equal(0))
+					Expect(networker.DestroyCallCount()).To(Equal(0))
+				})
+
+				It("does not publish a created event", func() {
+					gdnr.Create(garden.ContainerSpec{Handle: "denied"})
+					Expect(eventBus.PublishCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when containerizer.Handles() returns an error", func() {
 			BeforeEach(func() {
 				containerizer.HandlesReturns(nil, errors.New("error-fetching-handles"))
@@ -542,7 +635,7 @@ var _ = Describe("Gardener", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(containerizer.CreateCallCount()).To(Equal(1))
-				_, spec := containerizer.CreateArgsForCall(0)
+				_, _, spec := containerizer.CreateArgsForCall(0)
 				Expect(spec.BindMounts).To(Equal(bindMounts))
 			})
 		})
@@ -582,6 +675,18 @@ var _ = Describe("Gardener", func() {
 				Expect(io).To(Equal(origIO))
 			})
 
+			It("publishes a process started event", func() {
+				_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(eventBus.PublishCallCount()).To(Equal(1))
+				Expect(eventBus.PublishArgsForCall(0)).To(Equal(gardener.Event{
+					Kind:      gardener.ProcessStartedEvent,
+					Handle:    "banana",
+					ProcessID: "some-process",
+				}))
+			})
+
 			Context("when the containerizer fails to run a process", func() {
 				BeforeEach(func() {
 					containerizer.RunReturns(nil, errors.New("lost my banana"))
@@ -591,6 +696,11 @@ var _ = Describe("Gardener", func() {
 					_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
 					Expect(err).To(MatchError("lost my banana"))
 				})
+
+				It("does not publish an event", func() {
+					container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+					Expect(eventBus.PublishCallCount()).To(Equal(0))
+				})
 			})
 		})
 
@@ -777,15 +887,176 @@ var _ = Describe("Gardener", func() {
 			Expect(handle).To(Equal("container2"))
 		})
 
+		It("lists the containers which survived restoring", func() {
+			_, err := gdnr.Containers(nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			restorer.RestoreReturns([]string{"container2"})
+			Expect(gdnr.Start()).To(Succeed())
+
+			containerizer.HandlesReturns([]string{"container1"}, nil)
+			propertyManager.MatchingReturns([]string{"container1", "container2"})
+
+			c, err := gdnr.Containers(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(HaveLen(1))
+			Expect(c[0].Handle()).To(Equal("container1"))
+		})
+
 		It("should return the error when it failes to get a list of handles", func() {
 			containerizer.HandlesReturns([]string{}, errors.New("banana"))
 			Expect(gdnr.Start()).To(MatchError("banana"))
 		})
+
+		Context("when there are properties for a container without a depot directory", func() {
+			BeforeEach(func() {
+				propertyManager.HandlesReturns([]string{"container1", "lost-container"})
+			})
+
+			It("destroys the container", func() {
+				Expect(gdnr.Start()).To(Succeed())
+
+				Expect(networker.DestroyCallCount()).To(Equal(1))
+				_, handle := networker.DestroyArgsForCall(0)
+				Expect(handle).To(Equal("lost-container"))
+
+				Expect(propertyManager.DestroyKeySpaceCallCount()).To(Equal(1))
+				Expect(propertyManager.DestroyKeySpaceArgsForCall(0)).To(Equal("lost-container"))
+			})
+		})
+
+		Describe("removing orphaned resources", func() {
+			var (
+				runtimeOrphans *fakes.FakeOrphanSource
+				chainOrphans   *fakes.FakeOrphanSource
+			)
+
+			BeforeEach(func() {
+				runtimeOrphans = new(fakes.FakeOrphanSource)
+				runtimeOrphans.OrphansReturns([]string{"orphan1", "orphan2"}, nil)
+				chainOrphans = new(fakes.FakeOrphanSource)
+				chainOrphans.OrphansReturns([]string{"chain1"}, nil)
+
+				gdnr.OrphanSources = map[string]gardener.OrphanSource{
+					"runtime":  runtimeOrphans,
+					"iptables": chainOrphans,
+				}
+
+				restorer.RestoreReturns([]string{"container2"})
+			})
+
+			It("looks for orphans which do not belong to the restored containers", func() {
+				Expect(gdnr.Start()).To(Succeed())
+
+				Expect(runtimeOrphans.OrphansCallCount()).To(Equal(1))
+				_, handles := runtimeOrphans.OrphansArgsForCall(0)
+				Expect(handles).To(Equal([]string{"container1"}))
+			})
+
+			It("removes each orphan", func() {
+				Expect(gdnr.Start()).To(Succeed())
+
+				Expect(runtimeOrphans.RemoveOrphanCallCount()).To(Equal(2))
+				_, id := runtimeOrphans.RemoveOrphanArgsForCall(0)
+				Expect(id).To(Equal("orphan1"))
+				_, id = runtimeOrphans.RemoveOrphanArgsForCall(1)
+				Expect(id).To(Equal("orphan2"))
+
+				Expect(chainOrphans.RemoveOrphanCallCount()).To(Equal(1))
+			})
+
+			It("reports what was reconciled", func() {
+				Expect(gdnr.Start()).To(Succeed())
+
+				Expect(gdnr.ReconcileReport()).To(Equal(gardener.ReconcileReport{
+					Restored:  []string{"container1"},
+					Destroyed: []string{"container2"},
+					Removed: map[string][]string{
+						"iptables": []string{"chain1"},
+						"runtime":  []string{"orphan1", "orphan2"},
+					},
+					Failures: []gardener.ReconcileFailure{},
+				}))
+			})
+
+			It("logs the report", func() {
+				Expect(gdnr.Start()).To(Succeed())
+
+				Expect(logger).To(gbytes.Say(`"report":{"restored":\["container1"\],"destroyed":\["container2"\]`))
+			})
+
+			Context("when destroying a container which could not be restored fails", func() {
+				BeforeEach(func() {
+					containerizer.DestroyReturns(errors.New("destroy failed"))
+				})
+
+				It("does not treat its resources as orphans", func() {
+					Expect(gdnr.Start()).To(Succeed())
+
+					_, handles := runtimeOrphans.OrphansArgsForCall(0)
+					Expect(handles).To(ConsistOf("container1", "container2"))
+				})
+
+				It("reports the failure", func() {
+					Expect(gdnr.Start()).To(Succeed())
+
+					Expect(gdnr.ReconcileReport().Failures).To(ConsistOf(gardener.ReconcileFailure{
+						Source: "container", ID: "container2", Error: "destroy failed",
+					}))
+				})
+			})
+
+			Context("when listing orphans fails", func() {
+				BeforeEach(func() {
+					runtimeOrphans.OrphansReturns(nil, errors.New("runc list failed"))
+				})
+
+				It("still removes orphans from the other sources", func() {
+					Expect(gdnr.Start()).To(Succeed())
+					Expect(chainOrphans.RemoveOrphanCallCount()).To(Equal(1))
+				})
+
+				It("reports the failure", func() {
+					Expect(gdnr.Start()).To(Succeed())
+
+					Expect(gdnr.ReconcileReport().Failures).To(ConsistOf(gardener.ReconcileFailure{
+						Source: "runtime", Error: "runc list failed",
+					}))
+				})
+			})
+
+			Context("when removing an orphan fails", func() {
+				BeforeEach(func() {
+					runtimeOrphans.RemoveOrphanStub = func(_ lager.Logger, id string) error {
+						if id == "orphan1" {
+							return errors.New("delete failed")
+						}
+
+						return nil
+					}
+				})
+
+				It("carries on removing the other orphans", func() {
+					Expect(gdnr.Start()).To(Succeed())
+
+					Expect(gdnr.ReconcileReport().Removed["runtime"]).To(Equal([]string{"orphan2"}))
+				})
+
+				It("reports the failure", func() {
+					Expect(gdnr.Start()).To(Succeed())
+
+					Expect(gdnr.ReconcileReport().Failures).To(ConsistOf(gardener.ReconcileFailure{
+						Source: "runtime", ID: "orphan1", Error: "delete failed",
+					}))
+				})
+			})
+		})
 	})
 
 	Describe("listing containers", func() {
 		BeforeEach(func() {
 			containerizer.HandlesReturns([]string{"banana", "banana2", "cola"}, nil)
+			propertyManager.MatchingReturns([]string{"banana", "banana2", "cola"})
 		})
 
 		itOnlyMatchesFullyCreatedContainers := func(props garden.Properties) {
@@ -793,11 +1064,75 @@ var _ = Describe("Gardener", func() {
 				_, err := gdnr.Containers(props)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, props := propertyManager.MatchesAllArgsForCall(0)
+				props := propertyManager.MatchingArgsForCall(0)
 				Expect(props).To(HaveKeyWithValue("garden.state", "created"))
 			})
 		}
 
+		It("only lists the handles from the containerizer once", func() {
+			_, err := gdnr.Containers(nil)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = gdnr.Containers(nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(containerizer.HandlesCallCount()).To(Equal(1))
+		})
+
+		It("does not return containers which only have matching properties", func() {
+			propertyManager.MatchingReturns([]string{"banana", "ghost"})
+
+			c, err := gdnr.Containers(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(HaveLen(1))
+			Expect(c[0].Handle()).To(Equal("banana"))
+		})
+
+		Context("when a container is created", func() {
+			It("is listed", func() {
+				_, err := gdnr.Containers(nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = gdnr.Create(garden.ContainerSpec{Handle: "apple"})
+				Expect(err).NotTo(HaveOccurred())
+
+				propertyManager.MatchingReturns([]string{"apple", "banana"})
+				c, err := gdnr.Containers(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c).To(HaveLen(2))
+				Expect(c[0].Handle()).To(Equal("apple"))
+				Expect(c[1].Handle()).To(Equal("banana"))
+			})
+		})
+
+		Context("when a container is destroyed", func() {
+			It("is no longer listed", func() {
+				_, err := gdnr.Containers(nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(gdnr.Destroy("banana")).To(Succeed())
+
+				c, err := gdnr.Containers(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c).To(HaveLen(2))
+				Expect(c[0].Handle()).To(Equal("banana2"))
+				Expect(c[1].Handle()).To(Equal("cola"))
+			})
+
+			Context("and destroying it fails", func() {
+				It("is still listed", func() {
+					_, err := gdnr.Containers(nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					containerizer.DestroyReturns(errors.New("boom"))
+					Expect(gdnr.Destroy("banana")).NotTo(Succeed())
+
+					c, err := gdnr.Containers(nil)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(c).To(HaveLen(3))
+				})
+			})
+		})
+
 		Context("when passed nil properties to match against", func() {
 			itOnlyMatchesFullyCreatedContainers(nil)
 		})
@@ -806,9 +1141,7 @@ var _ = Describe("Gardener", func() {
 			props := garden.Properties{"somename": "somevalue"}
 
 			It("only returns matching containers", func() {
-				propertyManager.MatchesAllStub = func(handle string, props garden.Properties) bool {
-					return handle != "banana"
-				}
+				propertyManager.MatchingReturns([]string{"cola", "banana2"})
 
 				c, err := gdnr.Containers(props)
 				Expect(err).NotTo(HaveOccurred())
@@ -859,6 +1192,61 @@ var _ = Describe("Gardener", func() {
 			Expect(handle).To(Equal("banana"))
 			Expect(kill).To(Equal(true))
 		})
+
+		It("publishes a container stopped event", func() {
+			container, err := gdnr.Lookup("banana")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(container.Stop(false)).To(Succeed())
+			Expect(eventBus.PublishCallCount()).To(Equal(1))
+			Expect(eventBus.PublishArgsForCall(0)).To(Equal(gardener.Event{Kind: gardener.ContainerStoppedEvent, Handle: "banana"}))
+		})
+	})
+
+	Describe("pausing and resuming a container", func() {
+		It("asks the containerizer to pause the container", func() {
+			Expect(gdnr.Pause("some-handle")).To(Succeed())
+
+			Expect(containerizer.PauseCallCount()).To(Equal(1))
+			_, handle := containerizer.PauseArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+		})
+
+		It("asks the containerizer to resume the container", func() {
+			Expect(gdnr.Resume("some-handle")).To(Succeed())
+
+			Expect(containerizer.ResumeCallCount()).To(Equal(1))
+			_, handle := containerizer.ResumeArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+		})
+
+		It("publishes container paused and resumed events", func() {
+			Expect(gdnr.Pause("some-handle")).To(Succeed())
+			Expect(gdnr.Resume("some-handle")).To(Succeed())
+
+			Expect(eventBus.PublishCallCount()).To(Equal(2))
+			Expect(eventBus.PublishArgsForCall(0)).To(Equal(gardener.Event{Kind: gardener.ContainerPausedEvent, Handle: "some-handle"}))
+			Expect(eventBus.PublishArgsForCall(1)).To(Equal(gardener.Event{Kind: gardener.ContainerResumedEvent, Handle: "some-handle"}))
+		})
+
+		Context("when the containerizer fails", func() {
+			BeforeEach(func() {
+				containerizer.PauseReturns(errors.New("runc pause: boom"))
+				containerizer.ResumeReturns(errors.New("runc resume: boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(gdnr.Pause("some-handle")).To(MatchError("runc pause: boom"))
+				Expect(gdnr.Resume("some-handle")).To(MatchError("runc resume: boom"))
+			})
+
+			It("does not publish any events", func() {
+				gdnr.Pause("some-handle")
+				gdnr.Resume("some-handle")
+
+				Expect(eventBus.PublishCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("Destroy", func() {
@@ -901,6 +1289,18 @@ var _ = Describe("Gardener", func() {
 			Expect(handle).To(Equal("some-handle"))
 		})
 
+		It("publishes a destroyed event with the properties the container had", func() {
+			propertyManager.AllReturns(garden.Properties{"foo": "bar"}, nil)
+
+			Expect(gdnr.Destroy("some-handle")).To(Succeed())
+			Expect(eventBus.PublishCallCount()).To(Equal(1))
+			Expect(eventBus.PublishArgsForCall(0)).To(Equal(gardener.Event{
+				Kind:       gardener.ContainerDestroyedEvent,
+				Handle:     "some-handle",
+				Properties: garden.Properties{"foo": "bar"},
+			}))
+		})
+
 		Context("when containerizer fails to destroy the container", func() {
 			BeforeEach(func() {
 				containerizer.DestroyReturns(errors.New("containerized deletion failed"))
@@ -982,6 +1382,351 @@ var _ = Describe("Gardener", func() {
 		})
 	})
 
+	Describe("conflicting operations on a handle", func() {
+		var (
+			blocker chan struct{}
+			done    chan struct{}
+		)
+
+		BeforeEach(func() {
+			blocker = make(chan struct{})
+			done = make(chan struct{})
+		})
+
+		AfterEach(func() {
+			close(blocker)
+			Eventually(done).Should(BeClosed())
+		})
+
+		Context("while the container is being created", func() {
+			BeforeEach(func() {
+				started := make(chan struct{})
+				containerizer.CreateStub = func(_ context.Context, _ lager.Logger, _ gardener.DesiredContainerSpec) error {
+					close(started)
+					<-blocker
+					return nil
+				}
+
+				go func() {
+					defer GinkgoRecover()
+					defer close(done)
+					_, err := gdnr.Create(garden.ContainerSpec{Handle: "new-handle"})
+					Expect(err).NotTo(HaveOccurred())
+				}()
+				Eventually(started).Should(BeClosed())
+			})
+
+			It("rejects a create with the same handle", func() {
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "new-handle"})
+				Expect(err).To(MatchError(gardener.HandleAlreadyExistsError{Handle: "new-handle"}))
+			})
+
+			It("rejects a destroy of the handle", func() {
+				Expect(gdnr.Destroy("new-handle")).To(MatchError(gardener.HandleBusyError{Handle: "new-handle", State: "creating"}))
+				Expect(containerizer.DestroyCallCount()).To(Equal(0))
+			})
+
+			It("rejects operations on the container", func() {
+				container, err := gdnr.Lookup("new-handle")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+				Expect(err).To(MatchError(gardener.HandleBusyError{Handle: "new-handle", State: "creating"}))
+				Expect(container.Stop(false)).To(MatchError(gardener.HandleBusyError{Handle: "new-handle", State: "creating"}))
+				Expect(containerizer.RunCallCount()).To(Equal(0))
+				Expect(containerizer.StopCallCount()).To(Equal(0))
+			})
+
+			It("rejects pausing and resuming the container", func() {
+				Expect(gdnr.Pause("new-handle")).To(MatchError(gardener.HandleBusyError{Handle: "new-handle", State: "creating"}))
+				Expect(gdnr.Resume("new-handle")).To(MatchError(gardener.HandleBusyError{Handle: "new-handle", State: "creating"}))
+				Expect(containerizer.PauseCallCount()).To(Equal(0))
+				Expect(containerizer.ResumeCallCount()).To(Equal(0))
+			})
+
+			It("allows operations on other handles", func() {
+				container, err := gdnr.Lookup("other-handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(container.Stop(false)).To(Succeed())
+			})
+		})
+
+		Context("while the container is being destroyed", func() {
+			BeforeEach(func() {
+				started := make(chan struct{})
+				containerizer.DestroyStub = func(_ lager.Logger, _ string) error {
+					close(started)
+					<-blocker
+					return nil
+				}
+
+				go func() {
+					defer GinkgoRecover()
+					defer close(done)
+					Expect(gdnr.Destroy("some-handle")).To(Succeed())
+				}()
+				Eventually(started).Should(BeClosed())
+			})
+
+			It("rejects a create with the same handle", func() {
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+				Expect(err).To(MatchError(gardener.HandleBusyError{Handle: "some-handle", State: "destroying"}))
+			})
+
+			It("rejects another destroy of the handle", func() {
+				Expect(gdnr.Destroy("some-handle")).To(MatchError(gardener.HandleBusyError{Handle: "some-handle", State: "destroying"}))
+			})
+
+			It("rejects operations on the container", func() {
+				container, err := gdnr.Lookup("some-handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(container.NetOut(garden.NetOutRule{})).To(MatchError(gardener.HandleBusyError{Handle: "some-handle", State: "destroying"}))
+			})
+		})
+
+		Context("while an operation is running on the container", func() {
+			BeforeEach(func() {
+				started := make(chan struct{})
+				containerizer.StopStub = func(_ lager.Logger, _ string, _ bool) error {
+					close(started)
+					<-blocker
+					return nil
+				}
+
+				go func() {
+					defer GinkgoRecover()
+					defer close(done)
+					container, err := gdnr.Lookup("some-handle")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(container.Stop(false)).To(Succeed())
+				}()
+				Eventually(started).Should(BeClosed())
+			})
+
+			It("rejects a destroy of the handle", func() {
+				Expect(gdnr.Destroy("some-handle")).To(MatchError(gardener.HandleBusyError{Handle: "some-handle"}))
+			})
+
+			It("allows other operations on the container", func() {
+				container, err := gdnr.Lookup("some-handle")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		It("allows the handle to be destroyed once it has been created", func() {
+			close(done)
+			_, err := gdnr.Create(garden.ContainerSpec{Handle: "new-handle"})
+			Expect(err).NotTo(HaveOccurred())
+
+			containerizer.HandlesReturns([]string{"new-handle"}, nil)
+			Expect(gdnr.Destroy("new-handle")).To(Succeed())
+		})
+	})
+
+	Describe("capacity accounting", func() {
+		var props *properties.Manager
+
+		create := func(handle string, memory, disk uint64) error {
+			_, err := gdnr.Create(garden.ContainerSpec{
+				Handle: handle,
+				Limits: garden.Limits{
+					Memory: garden.MemoryLimits{LimitInBytes: memory},
+					Disk:   garden.DiskLimits{ByteHard: disk},
+				},
+			})
+			return err
+		}
+
+		BeforeEach(func() {
+			props = properties.NewManager()
+			gdnr.PropertyManager = props
+			gdnr.ReservedMemory = 100
+			gdnr.ReservedDisk = 200
+
+			sysinfoProvider.TotalMemoryReturns(1000, nil)
+			sysinfoProvider.TotalDiskReturns(2000, nil)
+			containerizer.HandlesReturns([]string{}, nil)
+		})
+
+		It("takes the host reservation away from the capacity", func() {
+			capacity, err := gdnr.Capacity()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(capacity.MemoryInBytes).To(BeEquivalentTo(900))
+			Expect(capacity.DiskInBytes).To(BeEquivalentTo(1800))
+		})
+
+		It("takes the limits of created containers away from the capacity", func() {
+			Expect(create("first", 300, 500)).To(Succeed())
+			Expect(create("second", 100, 0)).To(Succeed())
+
+			capacity, err := gdnr.Capacity()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(capacity.MemoryInBytes).To(BeEquivalentTo(500))
+			Expect(capacity.DiskInBytes).To(BeEquivalentTo(1300))
+		})
+
+		It("records the committed limits in the container's properties", func() {
+			Expect(create("first", 300, 500)).To(Succeed())
+
+			memory, _ := props.Get("first", gardener.CommittedMemoryKey)
+			Expect(memory).To(Equal("300"))
+			disk, _ := props.Get("first", gardener.CommittedDiskKey)
+			Expect(disk).To(Equal("500"))
+		})
+
+		It("gives the limits back when a container is destroyed", func() {
+			Expect(create("first", 300, 500)).To(Succeed())
+
+			containerizer.HandlesReturns([]string{"first"}, nil)
+			Expect(gdnr.Destroy("first")).To(Succeed())
+
+			capacity, err := gdnr.Capacity()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(capacity.MemoryInBytes).To(BeEquivalentTo(900))
+			Expect(capacity.DiskInBytes).To(BeEquivalentTo(1800))
+		})
+
+		It("gives the limits back when a create fails", func() {
+			networker.NetworkReturns(errors.New("network-failed"))
+			Expect(create("first", 300, 500)).NotTo(Succeed())
+
+			capacity, err := gdnr.Capacity()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(capacity.MemoryInBytes).To(BeEquivalentTo(900))
+		})
+
+		It("follows changes to a container's memory limit", func() {
+			Expect(create("first", 300, 0)).To(Succeed())
+
+			container, err := gdnr.Lookup("first")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 600})).To(Succeed())
+
+			capacity, err := gdnr.Capacity()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capacity.MemoryInBytes).To(BeEquivalentTo(300))
+
+			memory, _ := props.Get("first", gardener.CommittedMemoryKey)
+			Expect(memory).To(Equal("600"))
+		})
+
+		It("reports no capacity when more than the host's memory is committed", func() {
+			Expect(create("first", 2000, 0)).To(Succeed())
+
+			capacity, err := gdnr.Capacity()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capacity.MemoryInBytes).To(BeEquivalentTo(0))
+		})
+
+		It("loads the limits of existing containers from their properties", func() {
+			props.Set("existing", gardener.CommittedMemoryKey, "400")
+			props.Set("existing", gardener.CommittedDiskKey, "800")
+
+			capacity, err := gdnr.Capacity()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(capacity.MemoryInBytes).To(BeEquivalentTo(500))
+			Expect(capacity.DiskInBytes).To(BeEquivalentTo(1000))
+		})
+
+		Context("when a memory overcommit ratio is set", func() {
+			BeforeEach(func() {
+				gdnr.MemoryOvercommitRatio = 1.5
+			})
+
+			It("allows creates up to the ratio of the unreserved memory", func() {
+				Expect(create("first", 800, 0)).To(Succeed())
+				Expect(create("second", 550, 0)).To(Succeed())
+			})
+
+			Context("when a create would go past the ratio", func() {
+				BeforeEach(func() {
+					Expect(create("first", 800, 0)).To(Succeed())
+					volumeCreator.CreateReturns(gardener.DesiredImageSpec{}, nil)
+				})
+
+				It("refuses it", func() {
+					Expect(create("second", 551, 0)).To(MatchError(gardener.MemoryOvercommitError{
+						Handle:    "second",
+						Requested: 551,
+						Committed: 800,
+						Limit:     1350,
+					}))
+				})
+
+				It("does not allocate any resources", func() {
+					create("second", 551, 0)
+
+					Expect(volumeCreator.CreateCallCount()).To(Equal(1))
+					Expect(containerizer.CreateCallCount()).To(Equal(1))
+				})
+
+				It("still allows creates without a memory limit", func() {
+					Expect(create("second", 0, 0)).To(Succeed())
+				})
+			})
+
+			Context("when changing a container's memory limit would go past the ratio", func() {
+				var container garden.Container
+
+				BeforeEach(func() {
+					Expect(create("first", 800, 0)).To(Succeed())
+					Expect(create("second", 500, 0)).To(Succeed())
+
+					var err error
+					container, err = gdnr.Lookup("second")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("refuses it without changing the limit", func() {
+					Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 551})).To(MatchError(gardener.MemoryOvercommitError{
+						Handle:    "second",
+						Requested: 551,
+						Committed: 800,
+						Limit:     1350,
+					}))
+					Expect(containerizer.UpdateLimitsCallCount()).To(Equal(0))
+
+					memory, _ := props.Get("second", gardener.CommittedMemoryKey)
+					Expect(memory).To(Equal("500"))
+				})
+
+				It("allows changes up to the ratio", func() {
+					Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 550})).To(Succeed())
+				})
+
+				It("gives the memory back when the limit cannot be changed", func() {
+					containerizer.UpdateLimitsReturns(errors.New("update-failed"))
+					Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 550})).NotTo(Succeed())
+
+					Expect(create("third", 50, 0)).To(Succeed())
+				})
+			})
+
+			Context("when getting the total memory fails", func() {
+				BeforeEach(func() {
+					sysinfoProvider.TotalMemoryReturns(0, errors.New("whelp"))
+				})
+
+				It("fails the create", func() {
+					Expect(create("first", 1, 0)).To(MatchError("whelp"))
+				})
+			})
+		})
+
+		Context("when no memory overcommit ratio is set", func() {
+			It("never refuses creates on account of memory", func() {
+				Expect(create("first", 1000000, 0)).To(Succeed())
+			})
+		})
+	})
+
 	Describe("getting capacity", func() {
 		BeforeEach(func() {
 			sysinfoProvider.TotalMemoryReturns(999, nil)
@@ -1102,6 +1847,21 @@ var _ = Describe("Gardener", func() {
 			Expect(handle).To(Equal("some-handle"))
 			Expect(name).To(Equal("name"))
 		})
+
+		It("delegates to the property manager for ApplyProperties", func() {
+			batch := properties.Batch{
+				Preconditions: []properties.Precondition{{Name: "owner", Value: "me"}},
+				Set:           map[string]string{"owner": "you"},
+			}
+			propertyManager.ApplyReturns(errors.New("precondition-failed"))
+
+			err := container.(gardener.PropertyBatcher).ApplyProperties(batch)
+			Expect(err).To(MatchError("precondition-failed"))
+			Expect(propertyManager.ApplyCallCount()).To(Equal(1))
+			handle, applied := propertyManager.ApplyArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(applied).To(Equal(batch))
+		})
 	})
 
 	Describe("Info", func() {
@@ -1140,6 +1900,16 @@ var _ = Describe("Gardener", func() {
 			Expect(info.State).To(Equal("stopped"))
 		})
 
+		It("returns state as 'paused' when the actual container is paused", func() {
+			containerizer.InfoReturns(gardener.ActualContainerSpec{
+				Paused: true,
+			}, nil)
+
+			info, err := container.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.State).To(Equal("paused"))
+		})
+
 		It("returns the garden.network.container-ip property from the propertyManager as the ContainerIP", func() {
 			info, err := container.Info()
 			Expect(err).NotTo(HaveOccurred())
@@ -1183,6 +1953,43 @@ var _ = Describe("Gardener", func() {
 			})
 		})
 
+		Context("when the container only has IPv6 addresses", func() {
+			BeforeEach(func() {
+				properties = map[string]string{
+					gardener.ContainerIPv6Key: "fd00::2",
+					gardener.BridgeIPv6Key:    "fd00::1",
+				}
+			})
+
+			It("returns the IPv6 addresses", func() {
+				info, err := container.Info()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.ContainerIP).To(Equal("fd00::2"))
+				Expect(info.HostIP).To(Equal("fd00::1"))
+				Expect(info.ExternalIP).To(BeEmpty())
+			})
+
+			Context("and an external IPv6 address", func() {
+				BeforeEach(func() {
+					properties[gardener.ExternalIPv6Key] = "2001:db8::1"
+				})
+
+				It("returns it as the ExternalIP", func() {
+					info, err := container.Info()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(info.ExternalIP).To(Equal("2001:db8::1"))
+				})
+			})
+
+			Context("when getting the hostIP fails", func() {
+				It("should return the error", func() {
+					delete(properties, gardener.BridgeIPv6Key)
+					_, err := container.Info()
+					Expect(err).To(MatchError(MatchRegexp("no property found.*host-ipv6")))
+				})
+			})
+		})
+
 		It("returns the container path based on the info returned by the containerizer", func() {
 			containerizer.InfoReturns(gardener.ActualContainerSpec{
 				BundlePath: "/foo/bar/baz",
@@ -1305,6 +2112,59 @@ var _ = Describe("Gardener", func() {
 			Expect(infos["some-handle-2"].Err).NotTo(HaveOccurred())
 		})
 
+		Context("when a container does not respond within the timeout", func() {
+			It("returns a timeout error for that container only", func() {
+				release := make(chan struct{})
+				defer close(release)
+
+				containerizer.InfoStub = func(_ lager.Logger, handle string) (gardener.ActualContainerSpec, error) {
+					if handle == "some-handle-1" {
+						<-release
+					}
+					return gardener.ActualContainerSpec{}, nil
+				}
+				gdnr.BulkTimeout = 10 * time.Millisecond
+
+				infos, err := gdnr.BulkInfo([]string{"some-handle-1", "some-handle-2"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(infos["some-handle-1"].Err).To(MatchError("container 'some-handle-1' did not respond within 10ms"))
+				Expect(infos["some-handle-2"].Err).NotTo(HaveOccurred())
+			})
+
+			It("does not start more calls for that container than MaxBulkCallsPerHandle", func() {
+				release := make(chan struct{})
+
+				containerizer.InfoStub = func(_ lager.Logger, handle string) (gardener.ActualContainerSpec, error) {
+					<-release
+					return gardener.ActualContainerSpec{}, nil
+				}
+				gdnr.BulkTimeout = 10 * time.Millisecond
+
+				for i := 0; i < gardener.MaxBulkCallsPerHandle; i++ {
+					infos, err := gdnr.BulkInfo([]string{"some-handle-1"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(infos["some-handle-1"].Err).To(MatchError("container 'some-handle-1' did not respond within 10ms"))
+				}
+
+				infos, err := gdnr.BulkInfo([]string{"some-handle-1"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(infos["some-handle-1"].Err).To(MatchError(gardener.BulkBusyError{
+					Handle:   "some-handle-1",
+					InFlight: gardener.MaxBulkCallsPerHandle,
+				}.Error()))
+				Expect(containerizer.InfoCallCount()).To(Equal(gardener.MaxBulkCallsPerHandle))
+
+				close(release)
+
+				Eventually(func() *garden.Error {
+					infos, err := gdnr.BulkInfo([]string{"some-handle-1"})
+					Expect(err).NotTo(HaveOccurred())
+					return infos["some-handle-1"].Err
+				}).Should(BeNil())
+			})
+		})
+
 		Context("when info errors", func() {
 			It("returns the error", func() {
 				propertyManager.GetReturns("", false)
@@ -1352,6 +2212,7 @@ var _ = Describe("Gardener", func() {
 			containerizer.MetricsReturns(gardener.ActualContainerMetrics{
 				CPU:    cpuStat,
 				Memory: memoryStat,
+				Pid:    garden.ContainerPidStat{Current: 3, Max: 100},
 			}, nil)
 
 			volumeCreator.MetricsReturns(diskStat, nil)
@@ -1365,6 +2226,13 @@ var _ = Describe("Gardener", func() {
 			Expect(metrics.MemoryStat).To(Equal(memoryStat))
 		})
 
+		It("should return the number of processes from the containerizer", func() {
+			metrics, err := container.Metrics()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metrics.PidStat).To(Equal(garden.ContainerPidStat{Current: 3, Max: 100}))
+		})
+
 		It("should return the disk metrics from the volumizer", func() {
 			metrics, err := container.Metrics()
 			Expect(err).NotTo(HaveOccurred())
@@ -1456,6 +2324,93 @@ var _ = Describe("Gardener", func() {
 				Err: garden.NewError("potatoError"),
 			}))
 		})
+
+		Describe("fanning out BulkMetrics", func() {
+			var (
+				inFlight    int32
+				maxInFlight int32
+				release     chan struct{}
+			)
+
+			BeforeEach(func() {
+				inFlight, maxInFlight = 0, 0
+				release = make(chan struct{})
+
+				containerizer.MetricsStub = func(_ lager.Logger, id string) (gardener.ActualContainerMetrics, error) {
+					n := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+
+					for {
+						max := atomic.LoadInt32(&maxInFlight)
+						if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+							break
+						}
+					}
+
+					if id == "slow" {
+						<-release
+					}
+
+					return gardener.ActualContainerMetrics{}, nil
+				}
+			})
+
+			AfterEach(func() {
+				close(release)
+			})
+
+			It("works on no more containers at once than the number of workers", func() {
+				gdnr.BulkWorkers = 2
+
+				handles := []string{}
+				for i := 0; i < 10; i++ {
+					handles = append(handles, fmt.Sprintf("handle-%d", i))
+				}
+
+				metrics, err := gdnr.BulkMetrics(handles)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metrics).To(HaveLen(10))
+				Expect(atomic.LoadInt32(&maxInFlight)).To(BeNumerically("<=", 2))
+			})
+
+			It("works on containers concurrently", func() {
+				gdnr.BulkWorkers = 2
+
+				// "slow" is only released once another container is being worked on
+				containerizer.MetricsStub = func(_ lager.Logger, id string) (gardener.ActualContainerMetrics, error) {
+					if id == "slow" {
+						<-release
+					} else {
+						release <- struct{}{}
+					}
+					return gardener.ActualContainerMetrics{}, nil
+				}
+
+				done := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					defer close(done)
+
+					metrics, err := gdnr.BulkMetrics([]string{"slow", "fast"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(metrics).To(HaveLen(2))
+				}()
+
+				Eventually(done).Should(BeClosed())
+			})
+
+			Context("when a container does not respond within the timeout", func() {
+				It("returns a timeout error for that container only", func() {
+					gdnr.BulkTimeout = 10 * time.Millisecond
+
+					metrics, err := gdnr.BulkMetrics([]string{"slow", "some-handle"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(metrics["slow"].Err).To(MatchError("container 'slow' did not respond within 10ms"))
+					Expect(metrics["some-handle"].Err).To(BeNil())
+				})
+			})
+		})
 	})
 
 	Describe("Limits", func() {
@@ -1506,6 +2461,115 @@ var _ = Describe("Gardener", func() {
 				Expect(err).To(MatchError("some-error"))
 			})
 		})
+
+		It("updates the memory limit", func() {
+			Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 4096})).To(Succeed())
+
+			Expect(containerizer.UpdateLimitsCallCount()).To(Equal(1))
+			_, handle, limits := containerizer.UpdateLimitsArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(limits).To(Equal(garden.Limits{Memory: garden.MemoryLimits{LimitInBytes: 4096}}))
+		})
+
+		It("updates the CPU limit", func() {
+			Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 512})).To(Succeed())
+
+			Expect(containerizer.UpdateLimitsCallCount()).To(Equal(1))
+			_, handle, limits := containerizer.UpdateLimitsArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(limits).To(Equal(garden.Limits{CPU: garden.CPULimits{LimitInShares: 512}}))
+		})
+
+		Context("when the requested limit is zero", func() {
+			It("returns an error without updating the container", func() {
+				Expect(container.LimitMemory(garden.MemoryLimits{})).To(MatchError(ContainSubstring("LimitInBytes must be greater than zero")))
+				Expect(container.LimitCPU(garden.CPULimits{})).To(MatchError(ContainSubstring("LimitInShares must be greater than zero")))
+				Expect(containerizer.UpdateLimitsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the admitter can check limits", func() {
+			var admitter *fakes.FakeLimitsAdmitter
+
+			BeforeEach(func() {
+				admitter = new(fakes.FakeLimitsAdmitter)
+				gdnr.Admitter = admitter
+
+				var err error
+				container, err = gdnr.Lookup("some-handle")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("asks the admitter about the new limits", func() {
+				Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 4096})).To(Succeed())
+				Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 512})).To(Succeed())
+
+				Expect(admitter.AdmitLimitsCallCount()).To(Equal(2))
+				_, handle, limits := admitter.AdmitLimitsArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+				Expect(limits).To(Equal(garden.Limits{Memory: garden.MemoryLimits{LimitInBytes: 4096}}))
+				_, _, limits = admitter.AdmitLimitsArgsForCall(1)
+				Expect(limits).To(Equal(garden.Limits{CPU: garden.CPULimits{LimitInShares: 512}}))
+				Expect(containerizer.UpdateLimitsCallCount()).To(Equal(2))
+			})
+
+			Context("when the admitter denies the limits", func() {
+				BeforeEach(func() {
+					admitter.AdmitLimitsReturns(gardener.AdmissionDeniedError{Handle: "some-handle", Reason: "too much"})
+				})
+
+				It("returns the denial without updating the container", func() {
+					Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 4096})).To(MatchError(gardener.AdmissionDeniedError{Handle: "some-handle", Reason: "too much"}))
+					Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 512})).To(MatchError(gardener.AdmissionDeniedError{Handle: "some-handle", Reason: "too much"}))
+					Expect(containerizer.UpdateLimitsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when updating the limits fails", func() {
+			It("forwards the error", func() {
+				containerizer.UpdateLimitsReturns(errors.New("update-failed"))
+
+				Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 1})).To(MatchError("update-failed"))
+				Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 1})).To(MatchError("update-failed"))
+			})
+		})
+
+		It("refuses to change the disk limit", func() {
+			Expect(container.LimitDisk(garden.DiskLimits{ByteHard: 1})).To(MatchError(ContainSubstring("cannot be changed")))
+		})
+
+		It("asks the networker to limit the bandwidth", func() {
+			limits := garden.BandwidthLimits{RateInBytesPerSecond: 1000, BurstRateInBytesPerSecond: 200}
+			networker.LimitBandwidthReturns(errors.New("shape-failed"))
+
+			Expect(container.LimitBandwidth(limits)).To(MatchError("shape-failed"))
+			Expect(networker.LimitBandwidthCallCount()).To(Equal(1))
+			_, handle, actualLimits := networker.LimitBandwidthArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(actualLimits).To(Equal(limits))
+		})
+
+		It("gets the set bandwidth limits", func() {
+			propertyManager.GetStub = func(handle, key string) (string, bool) {
+				Expect(key).To(Equal(gardener.BandwidthLimitsKey))
+				return `{"rate":1000,"burst":200}`, true
+			}
+
+			currentBandwidthLimits, err := container.CurrentBandwidthLimits()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(currentBandwidthLimits).To(Equal(garden.BandwidthLimits{RateInBytesPerSecond: 1000, BurstRateInBytesPerSecond: 200}))
+		})
+
+		Context("when no bandwidth limits have been set", func() {
+			It("returns empty limits", func() {
+				propertyManager.GetReturns("", false)
+
+				currentBandwidthLimits, err := container.CurrentBandwidthLimits()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(currentBandwidthLimits).To(BeZero())
+			})
+		})
 	})
 
 	Describe("GraceTime", func() {
@@ -1545,5 +2609,60 @@ var _ = Describe("Gardener", func() {
 				Expect(gdnr.GraceTime(container)).To(BeZero())
 			})
 		})
+
+		Context("when the gardener reaps idle containers itself", func() {
+			var (
+				props     *properties.Manager
+				destroyer *fakes.FakeContainerDestroyer
+				clock     *fakeclock.FakeClock
+			)
+
+			BeforeEach(func() {
+				props = properties.NewManager()
+				props.Set("some-handle", gardener.GraceTimeKey, fmt.Sprintf("%d", time.Minute))
+				destroyer = new(fakes.FakeContainerDestroyer)
+				clock = fakeclock.NewFakeClock(time.Unix(0, 0))
+
+				gdnr.PropertyManager = props
+				gdnr.Reaper = gardener.NewReaper(logger, clock, props, destroyer)
+				gdnr.Reaper.Track("some-handle")
+			})
+
+			It("returns no grace time, so that the API server does not expire containers too", func() {
+				Expect(gdnr.GraceTime(container)).To(BeZero())
+			})
+
+			It("counts looking up a container as activity", func() {
+				clock.Increment(30 * time.Second)
+				_, err := gdnr.Lookup("some-handle")
+				Expect(err).NotTo(HaveOccurred())
+
+				lastActivity, _ := props.Get("some-handle", gardener.LastActivityKey)
+				Expect(lastActivity).To(Equal(time.Unix(30, 0).Format(time.RFC3339Nano)))
+			})
+
+			It("does not expire a container while a process is attached", func() {
+				process := new(gardenfakes.FakeProcess)
+				exited := make(chan struct{})
+				process.WaitStub = func() (int, error) {
+					<-exited
+					return 0, nil
+				}
+				containerizer.RunReturns(process, nil)
+
+				ctr, err := gdnr.Lookup("some-handle")
+				Expect(err).NotTo(HaveOccurred())
+				p, err := ctr.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+
+				go p.Wait()
+				clock.Increment(2 * time.Minute)
+				Consistently(destroyer.DestroyCallCount).Should(Equal(0))
+
+				close(exited)
+				clock.WaitForWatcherAndIncrement(time.Minute)
+				Eventually(destroyer.DestroyCallCount).Should(Equal(1))
+			})
+		})
 	})
 })
@@ -2,6 +2,7 @@
 package gardenerfakes
 
 import (
+	"context"
 	"io"
 	"sync"
 
@@ -11,9 +12,10 @@ import (
 )
 
 type FakeContainerizer struct {
-	CreateStub        func(log lager.Logger, spec gardener.DesiredContainerSpec) error
+	CreateStub        func(ctx context.Context, log lager.Logger, spec gardener.DesiredContainerSpec) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
+		ctx  context.Context
 		log  lager.Logger
 		spec gardener.DesiredContainerSpec
 	}
@@ -159,21 +161,59 @@ type FakeContainerizer struct {
 		result1 gardener.ActualContainerMetrics
 		result2 error
 	}
+	UpdateLimitsStub        func(log lager.Logger, handle string, limits garden.Limits) error
+	updateLimitsMutex       sync.RWMutex
+	updateLimitsArgsForCall []struct {
+		log    lager.Logger
+		handle string
+		limits garden.Limits
+	}
+	updateLimitsReturns struct {
+		result1 error
+	}
+	updateLimitsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PauseStub        func(log lager.Logger, handle string) error
+	pauseMutex       sync.RWMutex
+	pauseArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
+	pauseReturns struct {
+		result1 error
+	}
+	pauseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResumeStub        func(log lager.Logger, handle string) error
+	resumeMutex       sync.RWMutex
+	resumeArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
+	resumeReturns struct {
+		result1 error
+	}
+	resumeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeContainerizer) Create(log lager.Logger, spec gardener.DesiredContainerSpec) error {
+func (fake *FakeContainerizer) Create(ctx context.Context, log lager.Logger, spec gardener.DesiredContainerSpec) error {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		ctx  context.Context
 		log  lager.Logger
 		spec gardener.DesiredContainerSpec
-	}{log, spec})
-	fake.recordInvocation("Create", []interface{}{log, spec})
+	}{ctx, log, spec})
+	fake.recordInvocation("Create", []interface{}{ctx, log, spec})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(log, spec)
+		return fake.CreateStub(ctx, log, spec)
 	}
 	if specificReturn {
 		return ret.result1
@@ -187,10 +227,10 @@ func (fake *FakeContainerizer) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeContainerizer) CreateArgsForCall(i int) (lager.Logger, gardener.DesiredContainerSpec) {
+func (fake *FakeContainerizer) CreateArgsForCall(i int) (context.Context, lager.Logger, gardener.DesiredContainerSpec) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].log, fake.createArgsForCall[i].spec
+	return fake.createArgsForCall[i].ctx, fake.createArgsForCall[i].log, fake.createArgsForCall[i].spec
 }
 
 func (fake *FakeContainerizer) CreateReturns(result1 error) {
@@ -614,7 +654,9 @@ func (fake *FakeContainerizer) RemoveBundleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeContainerizer) Info(log lager.Logger, handle string) (gardener.ActualContainerSpec, error) {
+func (fake *FakeContainerizer) Info(log lager.Logger, handle string) (gardener.ActualContainerSpec,
+
+	error) {
 	fake.infoMutex.Lock()
 	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
 	fake.infoArgsForCall = append(fake.infoArgsForCall, struct {
@@ -666,7 +708,9 @@ func (fake *FakeContainerizer) InfoReturnsOnCall(i int, result1 gardener.ActualC
 	}{result1, result2}
 }
 
-func (fake *FakeContainerizer) Metrics(log lager.Logger, handle string) (gardener.ActualContainerMetrics, error) {
+func (fake *FakeContainerizer) Metrics(log lager.Logger, handle string) (gardener.ActualContainerMetrics,
+
+	error) {
 	fake.metricsMutex.Lock()
 	ret, specificReturn := fake.metricsReturnsOnCall[len(fake.metricsArgsForCall)]
 	fake.metricsArgsForCall = append(fake.metricsArgsForCall, struct {
@@ -718,6 +762,154 @@ func (fake *FakeContainerizer) MetricsReturnsOnCall(i int, result1 gardener.Actu
 	}{result1, result2}
 }
 
+func (fake *FakeContainerizer) UpdateLimits(log lager.Logger, handle string, limits garden.Limits) error {
+	fake.updateLimitsMutex.Lock()
+	ret, specificReturn := fake.updateLimitsReturnsOnCall[len(fake.updateLimitsArgsForCall)]
+	fake.updateLimitsArgsForCall = append(fake.updateLimitsArgsForCall, struct {
+		log    lager.Logger
+		handle string
+		limits garden.Limits
+	}{log, handle, limits})
+	fake.recordInvocation("UpdateLimits", []interface{}{log, handle, limits})
+	fake.updateLimitsMutex.Unlock()
+	if fake.UpdateLimitsStub != nil {
+		return fake.UpdateLimitsStub(log, handle, limits)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateLimitsReturns.result1
+}
+
+func (fake *FakeContainerizer) UpdateLimitsCallCount() int {
+	fake.updateLimitsMutex.RLock()
+	defer fake.updateLimitsMutex.RUnlock()
+	return len(fake.updateLimitsArgsForCall)
+}
+
+func (fake *FakeContainerizer) UpdateLimitsArgsForCall(i int) (lager.Logger, string, garden.Limits) {
+	fake.updateLimitsMutex.RLock()
+	defer fake.updateLimitsMutex.RUnlock()
+	return fake.updateLimitsArgsForCall[i].log, fake.updateLimitsArgsForCall[i].handle, fake.updateLimitsArgsForCall[i].limits
+}
+
+func (fake *FakeContainerizer) UpdateLimitsReturns(result1 error) {
+	fake.UpdateLimitsStub = nil
+	fake.updateLimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) UpdateLimitsReturnsOnCall(i int, result1 error) {
+	fake.UpdateLimitsStub = nil
+	if fake.updateLimitsReturnsOnCall == nil {
+		fake.updateLimitsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateLimitsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) Pause(log lager.Logger, handle string) error {
+	fake.pauseMutex.Lock()
+	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("Pause", []interface{}{log, handle})
+	fake.pauseMutex.Unlock()
+	if fake.PauseStub != nil {
+		return fake.PauseStub(log, handle)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pauseReturns.result1
+}
+
+func (fake *FakeContainerizer) PauseCallCount() int {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return len(fake.pauseArgsForCall)
+}
+
+func (fake *FakeContainerizer) PauseArgsForCall(i int) (lager.Logger, string) {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return fake.pauseArgsForCall[i].log, fake.pauseArgsForCall[i].handle
+}
+
+func (fake *FakeContainerizer) PauseReturns(result1 error) {
+	fake.PauseStub = nil
+	fake.pauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) PauseReturnsOnCall(i int, result1 error) {
+	fake.PauseStub = nil
+	if fake.pauseReturnsOnCall == nil {
+		fake.pauseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) Resume(log lager.Logger, handle string) error {
+	fake.resumeMutex.Lock()
+	ret, specificReturn := fake.resumeReturnsOnCall[len(fake.resumeArgsForCall)]
+	fake.resumeArgsForCall = append(fake.resumeArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("Resume", []interface{}{log, handle})
+	fake.resumeMutex.Unlock()
+	if fake.ResumeStub != nil {
+		return fake.ResumeStub(log, handle)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.resumeReturns.result1
+}
+
+func (fake *FakeContainerizer) ResumeCallCount() int {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return len(fake.resumeArgsForCall)
+}
+
+func (fake *FakeContainerizer) ResumeArgsForCall(i int) (lager.Logger, string) {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return fake.resumeArgsForCall[i].log, fake.resumeArgsForCall[i].handle
+}
+
+func (fake *FakeContainerizer) ResumeReturns(result1 error) {
+	fake.ResumeStub = nil
+	fake.resumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) ResumeReturnsOnCall(i int, result1 error) {
+	fake.ResumeStub = nil
+	if fake.resumeReturnsOnCall == nil {
+		fake.resumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeContainerizer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -743,6 +935,12 @@ func (fake *FakeContainerizer) Invocations() map[string][][]interface{} {
 	defer fake.infoMutex.RUnlock()
 	fake.metricsMutex.RLock()
 	defer fake.metricsMutex.RUnlock()
+	fake.updateLimitsMutex.RLock()
+	defer fake.updateLimitsMutex.RUnlock()
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/properties"
 )
 
 type FakePropertyManager struct {
@@ -67,6 +68,17 @@ type FakePropertyManager struct {
 	matchesAllReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	MatchingStub        func(props garden.Properties) []string
+	matchingMutex       sync.RWMutex
+	matchingArgsForCall []struct {
+		props garden.Properties
+	}
+	matchingReturns struct {
+		result1 []string
+	}
+	matchingReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	DestroyKeySpaceStub        func(string) error
 	destroyKeySpaceMutex       sync.RWMutex
 	destroyKeySpaceArgsForCall []struct {
@@ -78,6 +90,27 @@ type FakePropertyManager struct {
 	destroyKeySpaceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	HandlesStub        func() []string
+	handlesMutex       sync.RWMutex
+	handlesArgsForCall []struct{}
+	handlesReturns     struct {
+		result1 []string
+	}
+	handlesReturnsOnCall map[int]struct {
+		result1 []string
+	}
+	ApplyStub        func(handle string, batch properties.Batch) error
+	applyMutex       sync.RWMutex
+	applyArgsForCall []struct {
+		handle string
+		batch  properties.Batch
+	}
+	applyReturns struct {
+		result1 error
+	}
+	applyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -309,6 +342,54 @@ func (fake *FakePropertyManager) MatchesAllReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakePropertyManager) Matching(props garden.Properties) []string {
+	fake.matchingMutex.Lock()
+	ret, specificReturn := fake.matchingReturnsOnCall[len(fake.matchingArgsForCall)]
+	fake.matchingArgsForCall = append(fake.matchingArgsForCall, struct {
+		props garden.Properties
+	}{props})
+	fake.recordInvocation("Matching", []interface{}{props})
+	fake.matchingMutex.Unlock()
+	if fake.MatchingStub != nil {
+		return fake.MatchingStub(props)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.matchingReturns.result1
+}
+
+func (fake *FakePropertyManager) MatchingCallCount() int {
+	fake.matchingMutex.RLock()
+	defer fake.matchingMutex.RUnlock()
+	return len(fake.matchingArgsForCall)
+}
+
+func (fake *FakePropertyManager) MatchingArgsForCall(i int) garden.Properties {
+	fake.matchingMutex.RLock()
+	defer fake.matchingMutex.RUnlock()
+	return fake.matchingArgsForCall[i].props
+}
+
+func (fake *FakePropertyManager) MatchingReturns(result1 []string) {
+	fake.MatchingStub = nil
+	fake.matchingReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakePropertyManager) MatchingReturnsOnCall(i int, result1 []string) {
+	fake.MatchingStub = nil
+	if fake.matchingReturnsOnCall == nil {
+		fake.matchingReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.matchingReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakePropertyManager) DestroyKeySpace(arg1 string) error {
 	fake.destroyKeySpaceMutex.Lock()
 	ret, specificReturn := fake.destroyKeySpaceReturnsOnCall[len(fake.destroyKeySpaceArgsForCall)]
@@ -357,6 +438,95 @@ func (fake *FakePropertyManager) DestroyKeySpaceReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
+func (fake *FakePropertyManager) Handles() []string {
+	fake.handlesMutex.Lock()
+	ret, specificReturn := fake.handlesReturnsOnCall[len(fake.handlesArgsForCall)]
+	fake.handlesArgsForCall = append(fake.handlesArgsForCall, struct{}{})
+	fake.recordInvocation("Handles", []interface{}{})
+	fake.handlesMutex.Unlock()
+	if fake.HandlesStub != nil {
+		return fake.HandlesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.handlesReturns.result1
+}
+
+func (fake *FakePropertyManager) HandlesCallCount() int {
+	fake.handlesMutex.RLock()
+	defer fake.handlesMutex.RUnlock()
+	return len(fake.handlesArgsForCall)
+}
+
+func (fake *FakePropertyManager) HandlesReturns(result1 []string) {
+	fake.HandlesStub = nil
+	fake.handlesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakePropertyManager) HandlesReturnsOnCall(i int, result1 []string) {
+	fake.HandlesStub = nil
+	if fake.handlesReturnsOnCall == nil {
+		fake.handlesReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.handlesReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakePropertyManager) Apply(handle string, batch properties.Batch) error {
+	fake.applyMutex.Lock()
+	ret, specificReturn := fake.applyReturnsOnCall[len(fake.applyArgsForCall)]
+	fake.applyArgsForCall = append(fake.applyArgsForCall, struct {
+		handle string
+		batch  properties.Batch
+	}{handle, batch})
+	fake.recordInvocation("Apply", []interface{}{handle, batch})
+	fake.applyMutex.Unlock()
+	if fake.ApplyStub != nil {
+		return fake.ApplyStub(handle, batch)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.applyReturns.result1
+}
+
+func (fake *FakePropertyManager) ApplyCallCount() int {
+	fake.applyMutex.RLock()
+	defer fake.applyMutex.RUnlock()
+	return len(fake.applyArgsForCall)
+}
+
+func (fake *FakePropertyManager) ApplyArgsForCall(i int) (string, properties.Batch) {
+	fake.applyMutex.RLock()
+	defer fake.applyMutex.RUnlock()
+	return fake.applyArgsForCall[i].handle, fake.applyArgsForCall[i].batch
+}
+
+func (fake *FakePropertyManager) ApplyReturns(result1 error) {
+	fake.ApplyStub = nil
+	fake.applyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePropertyManager) ApplyReturnsOnCall(i int, result1 error) {
+	fake.ApplyStub = nil
+	if fake.applyReturnsOnCall == nil {
+		fake.applyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.applyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePropertyManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -370,8 +540,14 @@ func (fake *FakePropertyManager) Invocations() map[string][][]interface{} {
 	defer fake.getMutex.RUnlock()
 	fake.matchesAllMutex.RLock()
 	defer fake.matchesAllMutex.RUnlock()
+	fake.matchingMutex.RLock()
+	defer fake.matchingMutex.RUnlock()
 	fake.destroyKeySpaceMutex.RLock()
 	defer fake.destroyKeySpaceMutex.RUnlock()
+	fake.handlesMutex.RLock()
+	defer fake.handlesMutex.RUnlock()
+	fake.applyMutex.RLock()
+	defer fake.applyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
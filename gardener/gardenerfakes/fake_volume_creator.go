@@ -2,6 +2,7 @@
 package gardenerfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/garden"
@@ -11,9 +12,10 @@ import (
 )
 
 type FakeVolumeCreator struct {
-	CreateStub        func(log lager.Logger, handle string, spec rootfs_spec.Spec) (gardener.DesiredImageSpec, error)
+	CreateStub        func(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (gardener.DesiredImageSpec, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
+		ctx    context.Context
 		log    lager.Logger
 		handle string
 		spec   rootfs_spec.Spec
@@ -68,18 +70,19 @@ type FakeVolumeCreator struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeVolumeCreator) Create(log lager.Logger, handle string, spec rootfs_spec.Spec) (gardener.DesiredImageSpec, error) {
+func (fake *FakeVolumeCreator) Create(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (gardener.DesiredImageSpec, error) {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		ctx    context.Context
 		log    lager.Logger
 		handle string
 		spec   rootfs_spec.Spec
-	}{log, handle, spec})
-	fake.recordInvocation("Create", []interface{}{log, handle, spec})
+	}{ctx, log, handle, spec})
+	fake.recordInvocation("Create", []interface{}{ctx, log, handle, spec})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(log, handle, spec)
+		return fake.CreateStub(ctx, log, handle, spec)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -93,10 +96,10 @@ func (fake *FakeVolumeCreator) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeVolumeCreator) CreateArgsForCall(i int) (lager.Logger, string, rootfs_spec.Spec) {
+func (fake *FakeVolumeCreator) CreateArgsForCall(i int) (context.Context, lager.Logger, string, rootfs_spec.Spec) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].log, fake.createArgsForCall[i].handle, fake.createArgsForCall[i].spec
+	return fake.createArgsForCall[i].ctx, fake.createArgsForCall[i].log, fake.createArgsForCall[i].handle, fake.createArgsForCall[i].spec
 }
 
 func (fake *FakeVolumeCreator) CreateReturns(result1 gardener.DesiredImageSpec, result2 error) {
@@ -0,0 +1,139 @@
+package gardener
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	handleCreating   = "creating"
+	handleDestroying = "destroying"
+)
+
+// HandleAlreadyExistsError is returned when a container is created with a
+// handle that belongs to an existing container or to one still being created
+type HandleAlreadyExistsError struct {
+	Handle string
+}
+
+func (err HandleAlreadyExistsError) Error() string {
+	return fmt.Sprintf("Handle '%s' already in use", err.Handle)
+}
+
+// HandleBusyError is returned when an operation on a container conflicts with
+// another operation that is already in progress on the same handle
+type HandleBusyError struct {
+	Handle string
+	State  string
+}
+
+func (err HandleBusyError) Error() string {
+	if err.State == "" {
+		return fmt.Sprintf("container '%s' is busy", err.Handle)
+	}
+
+	return fmt.Sprintf("container '%s' is busy: %s", err.Handle, err.State)
+}
+
+// ContainerPausedError is returned when an operation which needs the processes
+// in a container to make progress is attempted while the container is paused
+type ContainerPausedError struct {
+	Handle string
+}
+
+func (err ContainerPausedError) Error() string {
+	return fmt.Sprintf("container '%s' is paused", err.Handle)
+}
+
+// handleLifecycle tracks the creates and destroys in flight for each handle,
+// along with the number of other operations currently using it, so that
+// conflicting operations are rejected rather than interleaved. The zero value
+// is ready to use.
+type handleLifecycle struct {
+	mu     sync.Mutex
+	states map[string]string
+	users  map[string]int
+
+	// drain counts every operation in flight, so a drain waits for operations
+	// on existing containers as well as for creates and destroys
+	drain drainer
+}
+
+func (l *handleLifecycle) startCreating(handle string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch l.states[handle] {
+	case "":
+	case handleCreating:
+		return HandleAlreadyExistsError{Handle: handle}
+	default:
+		return HandleBusyError{Handle: handle, State: l.states[handle]}
+	}
+
+	l.setState(handle, handleCreating)
+	return nil
+}
+
+func (l *handleLifecycle) startDestroying(handle string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if state, ok := l.states[handle]; ok {
+		return HandleBusyError{Handle: handle, State: state}
+	}
+
+	if l.users[handle] > 0 {
+		return HandleBusyError{Handle: handle}
+	}
+
+	l.setState(handle, handleDestroying)
+	return nil
+}
+
+// finish marks the create or destroy in progress for the handle as complete
+func (l *handleLifecycle) finish(handle string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.states, handle)
+}
+
+// use registers an operation on the handle, failing if the handle is being
+// created or destroyed. The returned function must be called once the
+// operation is complete. Operations go on while draining, but are waited for.
+func (l *handleLifecycle) use(handle string) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if state, ok := l.states[handle]; ok {
+		return nil, HandleBusyError{Handle: handle, State: state}
+	}
+
+	doneDraining, _ := l.drain.begin(true)
+
+	if l.users == nil {
+		l.users = make(map[string]int)
+	}
+	l.users[handle]++
+
+	return func() {
+		defer doneDraining()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.users[handle]--
+		if l.users[handle] == 0 {
+			delete(l.users, handle)
+		}
+	}, nil
+}
+
+func (l *handleLifecycle) setState(handle, state string) {
+	if l.states == nil {
+		l.states = make(map[string]string)
+	}
+
+	l.states[handle] = state
+}
@@ -0,0 +1,66 @@
+package gardener
+
+import (
+	"sort"
+	"sync"
+)
+
+// handleRegistry keeps the handles of the containers in memory, so that they
+// do not have to be listed from the containerizer each time they are needed.
+// It is loaded from the containerizer on first use and is then kept up to date
+// as containers are created and destroyed. The zero value is ready to use.
+type handleRegistry struct {
+	mu      sync.Mutex
+	handles map[string]struct{}
+}
+
+// list returns the registered handles in order, loading them first if needed
+func (r *handleRegistry) list(load func() ([]string, error)) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.handles == nil {
+		handles, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		r.handles = make(map[string]struct{}, len(handles))
+		for _, handle := range handles {
+			r.handles[handle] = struct{}{}
+		}
+	}
+
+	handles := make([]string, 0, len(r.handles))
+	for handle := range r.handles {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+
+	return handles, nil
+}
+
+func (r *handleRegistry) add(handle string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// if the handles have not been loaded yet, loading them will find it
+	if r.handles != nil {
+		r.handles[handle] = struct{}{}
+	}
+}
+
+func (r *handleRegistry) remove(handle string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.handles, handle)
+}
+
+// reset discards the registered handles so they are loaded again on next use
+func (r *handleRegistry) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handles = nil
+}
@@ -1,6 +1,7 @@
 package gardener
 
 import (
+	"context"
 	"errors"
 
 	"code.cloudfoundry.org/garden"
@@ -12,7 +13,7 @@ type NoopVolumeCreator struct{}
 
 var ErrGraphDisabled = errors.New("volume graph is disabled")
 
-func (NoopVolumeCreator) Create(lager.Logger, string, rootfs_spec.Spec) (DesiredImageSpec, error) {
+func (NoopVolumeCreator) Create(context.Context, lager.Logger, string, rootfs_spec.Spec) (DesiredImageSpec, error) {
 	return DesiredImageSpec{}, ErrGraphDisabled
 }
 
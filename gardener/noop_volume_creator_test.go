@@ -1,6 +1,8 @@
 package gardener_test
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden-shed/rootfs_spec"
 	"code.cloudfoundry.org/guardian/gardener"
@@ -23,7 +25,7 @@ var _ = Describe("NoopVolumeCreator", func() {
 
 	Describe("Create", func() {
 		It("returns ErrGraphDisabled", func() {
-			_, err := volumeCreator.Create(logger, "some-handle", rootfs_spec.Spec{})
+			_, err := volumeCreator.Create(context.Background(), logger, "some-handle", rootfs_spec.Spec{})
 			Expect(err).To(Equal(gardener.ErrGraphDisabled))
 		})
 	})
@@ -0,0 +1,48 @@
+package gardener
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/garden"
+)
+
+// ContainerPauser pauses and resumes containers, as the gardener does
+type ContainerPauser interface {
+	Pause(handle string) error
+	Resume(handle string) error
+}
+
+// NewPauseHandler pauses the container named by the handle query parameter on
+// a POST, or resumes it if resume is set, as the garden API has no route for
+// either
+func NewPauseHandler(pauser ContainerPauser, resume bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		handle := r.URL.Query().Get("handle")
+		if handle == "" {
+			http.Error(w, "the handle of the container must be given", http.StatusBadRequest)
+			return
+		}
+
+		action := pauser.Pause
+		if resume {
+			action = pauser.Resume
+		}
+
+		if err := action(handle); err != nil {
+			code := http.StatusInternalServerError
+			if _, ok := err.(garden.ContainerNotFoundError); ok {
+				code = http.StatusNotFound
+			}
+
+			http.Error(w, err.Error(), code)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
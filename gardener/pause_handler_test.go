@@ -0,0 +1,111 @@
+package gardener_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+)
+
+var _ = Describe("PauseHandler", func() {
+	var (
+		containerizer *fakes.FakeContainerizer
+		gdnr          *gardener.Gardener
+		events        <-chan gardener.Event
+		unsubscribe   func()
+	)
+
+	BeforeEach(func() {
+		containerizer = new(fakes.FakeContainerizer)
+		props := properties.NewManager()
+		eventBus := gardener.NewEventBus(props, fakeclock.NewFakeClock(time.Unix(1000, 0)))
+		events, unsubscribe = eventBus.Subscribe(func(gardener.Event) bool { return true })
+
+		gdnr = &gardener.Gardener{
+			Containerizer:   containerizer,
+			Networker:       new(fakes.FakeNetworker),
+			VolumeCreator:   new(fakes.FakeVolumeCreator),
+			SysInfoProvider: new(fakes.FakeSysInfoProvider),
+			UidGenerator:    new(fakes.FakeUidGenerator),
+			PropertyManager: props,
+			EventBus:        eventBus,
+			Logger:          lagertest.NewTestLogger("test"),
+		}
+	})
+
+	AfterEach(func() {
+		unsubscribe()
+	})
+
+	serve := func(resume bool, method, target string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler := gardener.NewPauseHandler(gdnr, resume)
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+		return recorder
+	}
+
+	It("pauses the container on a POST and publishes a container paused event", func() {
+		recorder := serve(false, "POST", "/pause?handle=some-handle")
+		Expect(recorder.Code).To(Equal(204))
+
+		Expect(containerizer.PauseCallCount()).To(Equal(1))
+		_, handle := containerizer.PauseArgsForCall(0)
+		Expect(handle).To(Equal("some-handle"))
+
+		var event gardener.Event
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Kind).To(Equal(gardener.ContainerPausedEvent))
+		Expect(event.Handle).To(Equal("some-handle"))
+	})
+
+	It("resumes the container on a POST and publishes a container resumed event", func() {
+		recorder := serve(true, "POST", "/resume?handle=some-handle")
+		Expect(recorder.Code).To(Equal(204))
+
+		Expect(containerizer.ResumeCallCount()).To(Equal(1))
+		Expect(containerizer.PauseCallCount()).To(Equal(0))
+
+		var event gardener.Event
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Kind).To(Equal(gardener.ContainerResumedEvent))
+		Expect(event.Handle).To(Equal("some-handle"))
+	})
+
+	It("refuses requests without a handle", func() {
+		Expect(serve(false, "POST", "/pause").Code).To(Equal(400))
+		Expect(containerizer.PauseCallCount()).To(Equal(0))
+	})
+
+	It("refuses other methods", func() {
+		Expect(serve(false, "GET", "/pause?handle=some-handle").Code).To(Equal(405))
+		Expect(containerizer.PauseCallCount()).To(Equal(0))
+	})
+
+	Context("when the container does not exist", func() {
+		It("responds with not found", func() {
+			containerizer.PauseReturns(garden.ContainerNotFoundError{Handle: "missing"})
+
+			recorder := serve(false, "POST", "/pause?handle=missing")
+			Expect(recorder.Code).To(Equal(404))
+		})
+	})
+
+	Context("when pausing fails", func() {
+		It("responds with the error and publishes no event", func() {
+			containerizer.PauseReturns(errors.New("runc pause: boom"))
+
+			recorder := serve(false, "POST", "/pause?handle=some-handle")
+			Expect(recorder.Code).To(Equal(500))
+			Expect(recorder.Body.String()).To(ContainSubstring("runc pause: boom"))
+			Consistently(events).ShouldNot(Receive())
+		})
+	})
+})
@@ -0,0 +1,119 @@
+package gardener
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+)
+
+// ProfileKey is the property with which a client picks the profile a
+// container is created from
+const ProfileKey = "garden.profile"
+
+// Profile holds defaults for the specs of containers created from it
+type Profile struct {
+	Limits     garden.Limits       `json:"limits"`
+	Env        []string            `json:"env"`
+	BindMounts []garden.BindMount  `json:"bind_mounts"`
+	NetOut     []garden.NetOutRule `json:"net_out"`
+	Privileged bool                `json:"privileged"`
+	GraceTime  time.Duration       `json:"-"`
+}
+
+// UnmarshalJSON reads the grace time of a profile as a duration string, such
+// as "5m"
+func (p *Profile) UnmarshalJSON(data []byte) error {
+	type profileFields Profile
+	fields := struct {
+		*profileFields
+		GraceTime string `json:"grace_time"`
+	}{profileFields: (*profileFields)(p)}
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if fields.GraceTime != "" {
+		graceTime, err := time.ParseDuration(fields.GraceTime)
+		if err != nil {
+			return fmt.Errorf("grace_time: %s", err)
+		}
+		p.GraceTime = graceTime
+	}
+
+	return nil
+}
+
+// Profiles are the profiles defined by the operator, by name
+type Profiles map[string]Profile
+
+// LoadProfiles reads Profiles from the JSON file at path
+func LoadProfiles(path string) (Profiles, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading profiles: %s", err)
+	}
+
+	var profiles Profiles
+	if err := json.Unmarshal(contents, &profiles); err != nil {
+		return nil, fmt.Errorf("parsing profiles %s: %s", path, err)
+	}
+
+	return profiles, nil
+}
+
+// UnknownProfileError is returned when a container spec picks a profile which
+// has not been defined
+type UnknownProfileError struct {
+	Name string
+}
+
+func (err UnknownProfileError) Error() string {
+	return fmt.Sprintf("unknown profile: %s", err.Name)
+}
+
+// apply merges the profile picked by the spec into it. Anything set in the
+// spec takes precedence: each group of limits in the spec replaces the one in
+// the profile, its env comes after that of the profile, and its bind mounts
+// and NetOut rules are added to those of the profile.
+func (profiles Profiles) apply(spec garden.ContainerSpec) (garden.ContainerSpec, error) {
+	name := spec.Properties[ProfileKey]
+	if name == "" {
+		return spec, nil
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return spec, UnknownProfileError{Name: name}
+	}
+
+	if spec.Limits.Bandwidth == (garden.BandwidthLimits{}) {
+		spec.Limits.Bandwidth = profile.Limits.Bandwidth
+	}
+	if spec.Limits.CPU == (garden.CPULimits{}) {
+		spec.Limits.CPU = profile.Limits.CPU
+	}
+	if spec.Limits.Disk == (garden.DiskLimits{}) {
+		spec.Limits.Disk = profile.Limits.Disk
+	}
+	if spec.Limits.Memory == (garden.MemoryLimits{}) {
+		spec.Limits.Memory = profile.Limits.Memory
+	}
+	if spec.Limits.Pid == (garden.PidLimits{}) {
+		spec.Limits.Pid = profile.Limits.Pid
+	}
+
+	spec.Env = append(append([]string{}, profile.Env...), spec.Env...)
+	spec.BindMounts = append(append([]garden.BindMount{}, profile.BindMounts...), spec.BindMounts...)
+	spec.NetOut = append(append([]garden.NetOutRule{}, profile.NetOut...), spec.NetOut...)
+	spec.Privileged = spec.Privileged || profile.Privileged
+
+	if spec.GraceTime == 0 {
+		spec.GraceTime = profile.GraceTime
+	}
+
+	return spec, nil
+}
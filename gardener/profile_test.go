@@ -0,0 +1,239 @@
+package gardener_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profiles", func() {
+	var (
+		containerizer   *fakes.FakeContainerizer
+		networker       *fakes.FakeNetworker
+		propertyManager *fakes.FakePropertyManager
+		admitter        *fakes.FakeAdmitter
+		gdnr            *gardener.Gardener
+
+		someNetOut  garden.NetOutRule
+		moreNetOut  garden.NetOutRule
+		webProfile  gardener.Profile
+		profileSpec garden.ContainerSpec
+	)
+
+	BeforeEach(func() {
+		containerizer = new(fakes.FakeContainerizer)
+		networker = new(fakes.FakeNetworker)
+		propertyManager = new(fakes.FakePropertyManager)
+		admitter = new(fakes.FakeAdmitter)
+
+		someNetOut = garden.NetOutRule{Networks: []garden.IPRange{garden.IPRangeFromIP(net.ParseIP("8.8.8.8"))}}
+		moreNetOut = garden.NetOutRule{Networks: []garden.IPRange{garden.IPRangeFromIP(net.ParseIP("9.9.9.9"))}}
+
+		webProfile = gardener.Profile{
+			Limits: garden.Limits{
+				Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				CPU:    garden.CPULimits{LimitInShares: 10},
+			},
+			Env:        []string{"PROFILE=web", "SHARED=profile"},
+			BindMounts: []garden.BindMount{{SrcPath: "/var/vcap/packages", DstPath: "/packages"}},
+			NetOut:     []garden.NetOutRule{someNetOut},
+			GraceTime:  time.Hour,
+		}
+
+		gdnr = &gardener.Gardener{
+			Containerizer:   containerizer,
+			Networker:       networker,
+			VolumeCreator:   new(fakes.FakeVolumeCreator),
+			SysInfoProvider: new(fakes.FakeSysInfoProvider),
+			UidGenerator:    new(fakes.FakeUidGenerator),
+			PropertyManager: propertyManager,
+			Admitter:        admitter,
+			Profiles:        gardener.Profiles{"web": webProfile},
+			Logger:          lagertest.NewTestLogger("test"),
+		}
+
+		profileSpec = garden.ContainerSpec{
+			Handle:     "some-handle",
+			Properties: garden.Properties{gardener.ProfileKey: "web"},
+		}
+	})
+
+	created := func() gardener.DesiredContainerSpec {
+		Expect(containerizer.CreateCallCount()).To(Equal(1))
+		_, _, spec := containerizer.CreateArgsForCall(0)
+		return spec
+	}
+
+	It("creates the container with the defaults of the profile", func() {
+		_, err := gdnr.Create(profileSpec)
+		Expect(err).NotTo(HaveOccurred())
+
+		spec := created()
+		Expect(spec.Limits).To(Equal(webProfile.Limits))
+		Expect(spec.Env).To(Equal([]string{"PROFILE=web", "SHARED=profile"}))
+		Expect(spec.BindMounts).To(Equal(webProfile.BindMounts))
+
+		_, _, networkSpec, _ := networker.NetworkArgsForCall(0)
+		Expect(networkSpec.NetOut).To(Equal([]garden.NetOutRule{someNetOut}))
+	})
+
+	It("sets the grace time of the profile", func() {
+		_, err := gdnr.Create(profileSpec)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(propertyManager.SetCallCount()).To(BeNumerically(">", 0))
+		handle, name, value := propertyManager.SetArgsForCall(0)
+		Expect(handle).To(Equal("some-handle"))
+		Expect(name).To(Equal(gardener.GraceTimeKey))
+		Expect(value).To(Equal("3600000000000"))
+	})
+
+	Context("when there is a default grace time", func() {
+		BeforeEach(func() {
+			gdnr.DefaultGraceTime = 5 * time.Minute
+		})
+
+		It("sets the grace time of the profile instead", func() {
+			_, err := gdnr.Create(profileSpec)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, name, value := propertyManager.SetArgsForCall(0)
+			Expect(name).To(Equal(gardener.GraceTimeKey))
+			Expect(value).To(Equal("3600000000000"))
+		})
+
+		It("sets it when neither the spec nor the profile gives a grace time", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{Handle: "plain-handle"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, name, value := propertyManager.SetArgsForCall(0)
+			Expect(name).To(Equal(gardener.GraceTimeKey))
+			Expect(value).To(Equal("300000000000"))
+		})
+	})
+
+	It("lets the spec override the profile", func() {
+		profileSpec.Limits.Memory = garden.MemoryLimits{LimitInBytes: 2048}
+		profileSpec.Env = []string{"SHARED=spec"}
+		profileSpec.BindMounts = []garden.BindMount{{SrcPath: "/data", DstPath: "/data"}}
+		profileSpec.NetOut = []garden.NetOutRule{moreNetOut}
+		profileSpec.GraceTime = time.Minute
+
+		_, err := gdnr.Create(profileSpec)
+		Expect(err).NotTo(HaveOccurred())
+
+		spec := created()
+		Expect(spec.Limits.Memory.LimitInBytes).To(BeEquivalentTo(2048))
+		Expect(spec.Limits.CPU.LimitInShares).To(BeEquivalentTo(10))
+		Expect(spec.Env).To(Equal([]string{"PROFILE=web", "SHARED=profile", "SHARED=spec"}))
+		Expect(spec.BindMounts).To(Equal([]garden.BindMount{
+			{SrcPath: "/var/vcap/packages", DstPath: "/packages"},
+			{SrcPath: "/data", DstPath: "/data"},
+		}))
+
+		_, _, networkSpec, _ := networker.NetworkArgsForCall(0)
+		Expect(networkSpec.NetOut).To(Equal([]garden.NetOutRule{someNetOut, moreNetOut}))
+
+		_, _, value := propertyManager.SetArgsForCall(0)
+		Expect(value).To(Equal("60000000000"))
+	})
+
+	It("makes the container privileged if the profile is", func() {
+		webProfile.Privileged = true
+		gdnr.Profiles = gardener.Profiles{"web": webProfile}
+
+		_, err := gdnr.Create(profileSpec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created().Privileged).To(BeTrue())
+	})
+
+	It("admits the merged spec", func() {
+		_, err := gdnr.Create(profileSpec)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, spec := admitter.AdmitArgsForCall(0)
+		Expect(spec.Limits).To(Equal(webProfile.Limits))
+	})
+
+	It("does not change specs which do not pick a profile", func() {
+		_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+		Expect(err).NotTo(HaveOccurred())
+
+		spec := created()
+		Expect(spec.Limits).To(Equal(garden.Limits{}))
+		Expect(spec.BindMounts).To(BeEmpty())
+	})
+
+	Context("when the profile does not exist", func() {
+		It("returns an error without creating anything", func() {
+			profileSpec.Properties[gardener.ProfileKey] = "missing"
+
+			_, err := gdnr.Create(profileSpec)
+			Expect(err).To(MatchError(gardener.UnknownProfileError{Name: "missing"}))
+			Expect(containerizer.CreateCallCount()).To(Equal(0))
+			Expect(containerizer.DestroyCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("LoadProfiles", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "profiles")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		writeProfiles := func(contents string) string {
+			path := filepath.Join(dir, "profiles.json")
+			Expect(ioutil.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+			return path
+		}
+
+		It("reads the profiles from the file", func() {
+			profiles, err := gardener.LoadProfiles(writeProfiles(`{
+				"web": {
+					"limits": {"memory_limits": {"limit_in_bytes": 1024}},
+					"env": ["PROFILE=web"],
+					"bind_mounts": [{"src_path": "/var/vcap/packages", "dst_path": "/packages"}],
+					"privileged": true,
+					"grace_time": "5m"
+				}
+			}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(profiles).To(Equal(gardener.Profiles{
+				"web": {
+					Limits:     garden.Limits{Memory: garden.MemoryLimits{LimitInBytes: 1024}},
+					Env:        []string{"PROFILE=web"},
+					BindMounts: []garden.BindMount{{SrcPath: "/var/vcap/packages", DstPath: "/packages"}},
+					Privileged: true,
+					GraceTime:  5 * time.Minute,
+				},
+			}))
+		})
+
+		It("returns an error when a grace time is not a duration", func() {
+			_, err := gardener.LoadProfiles(writeProfiles(`{"web": {"grace_time": "soon"}}`))
+			Expect(err).To(MatchError(ContainSubstring("grace_time")))
+		})
+
+		It("returns an error when the file does not exist", func() {
+			_, err := gardener.LoadProfiles(filepath.Join(dir, "missing.json"))
+			Expect(err).To(MatchError(ContainSubstring("reading profiles")))
+		})
+	})
+})
@@ -0,0 +1,97 @@
+package gardener
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/properties"
+)
+
+// ContainerLister lists containers, as the gardener does
+type ContainerLister interface {
+	Containers(props garden.Properties) ([]garden.Container, error)
+}
+
+// NewApplyPropertiesHandler applies the batch of property changes in the body
+// of a POST to the container named by the handle query parameter, as the
+// garden API can only change one property at a time. The body is the batch as
+// JSON:
+//
+//	{
+//	  "preconditions": [{"name": "owner", "value": "me"}, {"name": "lock", "absent": true}],
+//	  "set": {"lock": "mine"},
+//	  "remove": ["stale"]
+//	}
+//
+// It responds with conflict when a precondition does not hold, and forbidden
+// when the batch would change a reserved property.
+func NewApplyPropertiesHandler(lister ContainerLister) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		handle := r.URL.Query().Get("handle")
+		if handle == "" {
+			http.Error(w, "the handle of the container must be given", http.StatusBadRequest)
+			return
+		}
+
+		var batch properties.Batch
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			http.Error(w, "the body must be a batch of property changes: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		container, err := findContainer(lister, handle)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if container == nil {
+			http.Error(w, garden.ContainerNotFoundError{Handle: handle}.Error(), http.StatusNotFound)
+			return
+		}
+
+		batcher, ok := container.(PropertyBatcher)
+		if !ok {
+			http.Error(w, "the container cannot apply batches of property changes", http.StatusNotImplemented)
+			return
+		}
+
+		if err := batcher.ApplyProperties(batch); err != nil {
+			code := http.StatusInternalServerError
+			switch err.(type) {
+			case properties.PreconditionFailedError:
+				code = http.StatusConflict
+			case ReservedPropertyError:
+				code = http.StatusForbidden
+			}
+
+			http.Error(w, err.Error(), code)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// findContainer returns the container with the handle, or nil when there is
+// none
+func findContainer(lister ContainerLister, handle string) (garden.Container, error) {
+	containers, err := lister.Containers(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, container := range containers {
+		if container.Handle() == handle {
+			return container, nil
+		}
+	}
+
+	return nil, nil
+}
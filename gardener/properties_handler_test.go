@@ -0,0 +1,120 @@
+package gardener_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ApplyPropertiesHandler", func() {
+	var (
+		containerizer *fakes.FakeContainerizer
+		props         *properties.Manager
+		gdnr          *gardener.Gardener
+	)
+
+	BeforeEach(func() {
+		containerizer = new(fakes.FakeContainerizer)
+		containerizer.HandlesReturns([]string{"some-handle"}, nil)
+
+		props = properties.NewManager()
+		props.Set("some-handle", "garden.state", "created")
+		props.Set("some-handle", "owner", "me")
+		props.Set("some-handle", "stale", "yes")
+
+		gdnr = &gardener.Gardener{
+			Containerizer:   containerizer,
+			Networker:       new(fakes.FakeNetworker),
+			VolumeCreator:   new(fakes.FakeVolumeCreator),
+			SysInfoProvider: new(fakes.FakeSysInfoProvider),
+			UidGenerator:    new(fakes.FakeUidGenerator),
+			PropertyManager: props,
+			Logger:          lagertest.NewTestLogger("test"),
+		}
+	})
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler := gardener.NewApplyPropertiesHandler(gdnr)
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return recorder
+	}
+
+	It("applies the batch on a POST", func() {
+		recorder := serve("POST", "/properties/apply?handle=some-handle", `{
+			"preconditions": [{"name": "owner", "value": "me"}, {"name": "lock", "absent": true}],
+			"set": {"lock": "mine"},
+			"remove": ["stale"]
+		}`)
+		Expect(recorder.Code).To(Equal(204))
+
+		Expect(props.All("some-handle")).To(Equal(garden.Properties{
+			"garden.state": "created",
+			"owner":        "me",
+			"lock":         "mine",
+		}))
+	})
+
+	Context("when a precondition does not hold", func() {
+		It("responds with conflict and changes nothing", func() {
+			recorder := serve("POST", "/properties/apply?handle=some-handle", `{
+				"preconditions": [{"name": "owner", "value": "you"}],
+				"set": {"lock": "mine"}
+			}`)
+			Expect(recorder.Code).To(Equal(409))
+			Expect(recorder.Body.String()).To(ContainSubstring("precondition failed for some-handle:owner"))
+
+			_, ok := props.Get("some-handle", "lock")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("when the batch changes a reserved property", func() {
+		It("responds with forbidden and changes nothing", func() {
+			recorder := serve("POST", "/properties/apply?handle=some-handle", `{"remove": ["garden.state"]}`)
+			Expect(recorder.Code).To(Equal(403))
+
+			value, _ := props.Get("some-handle", "garden.state")
+			Expect(value).To(Equal("created"))
+		})
+	})
+
+	It("refuses requests without a handle", func() {
+		Expect(serve("POST", "/properties/apply", `{}`).Code).To(Equal(400))
+	})
+
+	It("refuses bodies which are not a batch", func() {
+		Expect(serve("POST", "/properties/apply?handle=some-handle", `banana`).Code).To(Equal(400))
+	})
+
+	It("refuses other methods", func() {
+		Expect(serve("GET", "/properties/apply?handle=some-handle", ``).Code).To(Equal(405))
+	})
+
+	Context("when the container does not exist", func() {
+		It("responds with not found, without creating properties for it", func() {
+			recorder := serve("POST", "/properties/apply?handle=missing", `{"set": {"lock": "mine"}}`)
+			Expect(recorder.Code).To(Equal(404))
+
+			Expect(props.All("missing")).To(BeEmpty())
+		})
+	})
+
+	Context("when the containers cannot be listed", func() {
+		It("responds with the error", func() {
+			containerizer.HandlesReturns(nil, errors.New("runc list: boom"))
+
+			recorder := serve("POST", "/properties/apply?handle=some-handle", `{"set": {"lock": "mine"}}`)
+			Expect(recorder.Code).To(Equal(500))
+			Expect(recorder.Body.String()).To(ContainSubstring("runc list: boom"))
+		})
+	})
+})
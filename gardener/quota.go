@@ -0,0 +1,338 @@
+package gardener
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+)
+
+// Quota caps the resources which the containers of a tenant may use between
+// them. Zero fields leave the resource unlimited. When memory or CPU is capped,
+// containers must be created with a limit on it, as a container without one
+// could use all of it.
+type Quota struct {
+	MaxContainers  uint64 `json:"max_containers,omitempty"`
+	MaxMemoryBytes uint64 `json:"max_memory_bytes,omitempty"`
+	MaxCPUShares   uint64 `json:"max_cpu_shares,omitempty"`
+	MaxMappedPorts uint64 `json:"max_mapped_ports,omitempty"`
+}
+
+// QuotaConfig gives the quota of each tenant, and the quota of any tenant
+// which is not listed
+type QuotaConfig struct {
+	Default Quota            `json:"default"`
+	Tenants map[string]Quota `json:"tenants"`
+}
+
+// LoadQuotaConfig reads a QuotaConfig from the JSON file at path
+func LoadQuotaConfig(path string) (QuotaConfig, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return QuotaConfig{}, fmt.Errorf("reading quotas: %s", err)
+	}
+
+	var config QuotaConfig
+	if err := json.Unmarshal(contents, &config); err != nil {
+		return QuotaConfig{}, fmt.Errorf("parsing quotas %s: %s", path, err)
+	}
+
+	return config, nil
+}
+
+// TenantUsage is what the containers of a tenant use between them
+type TenantUsage struct {
+	Containers  uint64 `json:"containers"`
+	MemoryBytes uint64 `json:"memory_bytes"`
+	CPUShares   uint64 `json:"cpu_shares"`
+	MappedPorts uint64 `json:"mapped_ports"`
+}
+
+func (u TenantUsage) add(other TenantUsage) TenantUsage {
+	return TenantUsage{
+		Containers:  u.Containers + other.Containers,
+		MemoryBytes: u.MemoryBytes + other.MemoryBytes,
+		CPUShares:   u.CPUShares + other.CPUShares,
+		MappedPorts: u.MappedPorts + other.MappedPorts,
+	}
+}
+
+// QuotaExceededError is returned when an operation would take a tenant past
+// its quota
+type QuotaExceededError struct {
+	Tenant   string
+	Resource string
+	Limit    uint64
+}
+
+func (err QuotaExceededError) Error() string {
+	return fmt.Sprintf("tenant '%s' would exceed its quota of %d %s", err.Tenant, err.Limit, err.Resource)
+}
+
+// UnlimitedContainerError is returned when a container would be created
+// without a limit on a resource which the quota of its tenant caps
+type UnlimitedContainerError struct {
+	Tenant   string
+	Resource string
+	Limit    uint64
+}
+
+func (err UnlimitedContainerError) Error() string {
+	return fmt.Sprintf("tenant '%s' has a quota of %d %s, so its containers must be created with a limit on them", err.Tenant, err.Limit, err.Resource)
+}
+
+// MissingTenantError is returned when a container is created without the
+// property which gives its tenant
+type MissingTenantError struct {
+	Property string
+}
+
+func (err MissingTenantError) Error() string {
+	return fmt.Sprintf("containers must be created with the '%s' property, which gives the tenant whose quota they count against", err.Property)
+}
+
+type pendingUsage struct {
+	tenant string
+	handle string
+	usage  TenantUsage
+
+	// replacing is set when usage replaces what the properties of the handle
+	// say it uses, rather than adding to it
+	replacing bool
+}
+
+// Quotas enforces the quota of each tenant, where the tenant of a container is
+// the value of one of its properties. Containers must be given that property
+// when they are created, and it cannot be changed after.
+//
+// What a tenant uses is worked out from the properties of its containers, so
+// it survives restarts. Operations in progress are counted from when they are
+// checked against the quota until they are done, so that concurrent ones
+// cannot take a tenant past its quota between them.
+type Quotas struct {
+	property   string
+	config     QuotaConfig
+	properties PropertyManager
+
+	mu      sync.Mutex
+	pending map[*pendingUsage]struct{}
+}
+
+func NewQuotas(property string, config QuotaConfig, properties PropertyManager) *Quotas {
+	return &Quotas{
+		property:   property,
+		config:     config,
+		properties: properties,
+		pending:    make(map[*pendingUsage]struct{}),
+	}
+}
+
+// reserveCreate counts a container being created against the quota of its
+// tenant. The returned function must be called once the create is done.
+func (q *Quotas) reserveCreate(spec garden.ContainerSpec) (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	tenant := spec.Properties[q.property]
+	if tenant == "" {
+		return nil, MissingTenantError{Property: q.property}
+	}
+
+	quota := q.quota(tenant)
+	if quota.MaxMemoryBytes != 0 && spec.Limits.Memory.LimitInBytes == 0 {
+		return nil, UnlimitedContainerError{Tenant: tenant, Resource: "bytes of memory", Limit: quota.MaxMemoryBytes}
+	}
+	if quota.MaxCPUShares != 0 && spec.Limits.CPU.LimitInShares == 0 {
+		return nil, UnlimitedContainerError{Tenant: tenant, Resource: "cpu shares", Limit: quota.MaxCPUShares}
+	}
+
+	return q.reserve(tenant, spec.Handle, TenantUsage{
+		Containers:  1,
+		MemoryBytes: spec.Limits.Memory.LimitInBytes,
+		CPUShares:   spec.Limits.CPU.LimitInShares,
+		MappedPorts: uint64(len(spec.NetIn)),
+	}, true)
+}
+
+// reservePort counts a port being mapped to a container against the quota of
+// its tenant. The returned function must be called once the port is mapped.
+func (q *Quotas) reservePort(handle string) (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	return q.reserve(q.tenant(handle), handle, TenantUsage{MappedPorts: 1}, false)
+}
+
+// reserveMemory and reserveCPU count a change to the limits of a container
+// against the quota of its tenant. The returned function must be called once
+// the limit is changed.
+func (q *Quotas) reserveMemory(handle string, bytes uint64) (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	usage := q.handleUsage(handle)
+	usage.MemoryBytes = bytes
+	return q.reserve(q.tenant(handle), handle, usage, true)
+}
+
+func (q *Quotas) reserveCPU(handle string, shares uint64) (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	usage := q.handleUsage(handle)
+	usage.CPUShares = shares
+	return q.reserve(q.tenant(handle), handle, usage, true)
+}
+
+func (q *Quotas) reserve(tenant, handle string, usage TenantUsage, replacing bool) (func(), error) {
+	if tenant == "" {
+		return func() {}, nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var current TenantUsage
+	exclude := ""
+	if replacing {
+		current = q.handleUsage(handle)
+		exclude = handle
+	}
+
+	total := q.usage(tenant, exclude).add(usage)
+	quota := q.quota(tenant)
+
+	for _, check := range []struct {
+		resource         string
+		requested, total uint64
+		current, limit   uint64
+	}{
+		{"containers", usage.Containers, total.Containers, current.Containers, quota.MaxContainers},
+		{"bytes of memory", usage.MemoryBytes, total.MemoryBytes, current.MemoryBytes, quota.MaxMemoryBytes},
+		{"cpu shares", usage.CPUShares, total.CPUShares, current.CPUShares, quota.MaxCPUShares},
+		{"mapped ports", usage.MappedPorts, total.MappedPorts, current.MappedPorts, quota.MaxMappedPorts},
+	} {
+		// a tenant already over its quota may still give resources back
+		if check.limit != 0 && check.requested > check.current && check.total > check.limit {
+			return nil, QuotaExceededError{Tenant: tenant, Resource: check.resource, Limit: check.limit}
+		}
+	}
+
+	pending := &pendingUsage{tenant: tenant, handle: handle, usage: usage, replacing: replacing}
+	q.pending[pending] = struct{}{}
+
+	return func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		delete(q.pending, pending)
+	}, nil
+}
+
+// Usage returns what each tenant with containers uses
+func (q *Quotas) Usage() map[string]TenantUsage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	tenants := map[string]bool{}
+	for _, handle := range q.properties.Handles() {
+		if tenant := q.tenant(handle); tenant != "" {
+			tenants[tenant] = true
+		}
+	}
+	for pending := range q.pending {
+		tenants[pending.tenant] = true
+	}
+
+	usage := make(map[string]TenantUsage, len(tenants))
+	for tenant := range tenants {
+		usage[tenant] = q.usage(tenant, "")
+	}
+
+	return usage
+}
+
+// ServeHTTP writes the quota and usage of each tenant as JSON
+func (q *Quotas) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	type tenantReport struct {
+		Quota Quota       `json:"quota"`
+		Usage TenantUsage `json:"usage"`
+	}
+
+	report := map[string]tenantReport{}
+	for tenant, usage := range q.Usage() {
+		report[tenant] = tenantReport{Quota: q.quota(tenant), Usage: usage}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// usage returns what a tenant uses, leaving out what the properties of the
+// excluded handle say it uses. It must be called with the mutex held.
+func (q *Quotas) usage(tenant, exclude string) TenantUsage {
+	replaced := map[string]bool{exclude: true}
+	var usage TenantUsage
+
+	for pending := range q.pending {
+		if pending.tenant != tenant {
+			continue
+		}
+
+		usage = usage.add(pending.usage)
+		if pending.replacing {
+			replaced[pending.handle] = true
+		}
+	}
+
+	for _, handle := range q.properties.Matching(garden.Properties{q.property: tenant}) {
+		if !replaced[handle] {
+			usage = usage.add(q.handleUsage(handle))
+		}
+	}
+
+	return usage
+}
+
+// handleUsage returns what the properties of a container say it uses, which
+// is nothing for a container which is still being created
+func (q *Quotas) handleUsage(handle string) TenantUsage {
+	if q.tenant(handle) == "" {
+		return TenantUsage{}
+	}
+
+	usage := TenantUsage{
+		Containers:  1,
+		MemoryBytes: committedBytes(q.properties, handle, CommittedMemoryKey),
+		CPUShares:   committedBytes(q.properties, handle, CommittedCPUKey),
+	}
+
+	if mappings, ok := q.properties.Get(handle, MappedPortsKey); ok {
+		var ports []garden.PortMapping
+		if err := json.Unmarshal([]byte(mappings), &ports); err == nil {
+			usage.MappedPorts = uint64(len(ports))
+		}
+	}
+
+	return usage
+}
+
+func (q *Quotas) tenant(handle string) string {
+	tenant, _ := q.properties.Get(handle, q.property)
+	return tenant
+}
+
+func (q *Quotas) quota(tenant string) Quota {
+	if quota, ok := q.config.Tenants[tenant]; ok {
+		return quota
+	}
+
+	return q.config.Default
+}
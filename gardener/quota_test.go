@@ -0,0 +1,372 @@
+package gardener_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quotas", func() {
+	var (
+		props         *properties.Manager
+		containerizer *fakes.FakeContainerizer
+		networker     *fakes.FakeNetworker
+		quotas        *gardener.Quotas
+		gdnr          *gardener.Gardener
+	)
+
+	create := func(handle, tenant string, limits garden.Limits) error {
+		_, err := gdnr.Create(garden.ContainerSpec{
+			Handle:     handle,
+			Properties: garden.Properties{"tenant": tenant},
+			Limits:     limits,
+		})
+		return err
+	}
+
+	limits := func(bytes, shares uint64) garden.Limits {
+		return garden.Limits{
+			Memory: garden.MemoryLimits{LimitInBytes: bytes},
+			CPU:    garden.CPULimits{LimitInShares: shares},
+		}
+	}
+
+	BeforeEach(func() {
+		props = properties.NewManager()
+		containerizer = new(fakes.FakeContainerizer)
+		networker = new(fakes.FakeNetworker)
+
+		quotas = gardener.NewQuotas("tenant", gardener.QuotaConfig{
+			Default: gardener.Quota{MaxContainers: 2},
+			Tenants: map[string]gardener.Quota{
+				"big": {
+					MaxContainers:  10,
+					MaxMemoryBytes: 1000,
+					MaxCPUShares:   100,
+					MaxMappedPorts: 2,
+				},
+			},
+		}, props)
+
+		gdnr = &gardener.Gardener{
+			Containerizer:   containerizer,
+			Networker:       networker,
+			VolumeCreator:   new(fakes.FakeVolumeCreator),
+			SysInfoProvider: new(fakes.FakeSysInfoProvider),
+			UidGenerator:    new(fakes.FakeUidGenerator),
+			PropertyManager: props,
+			Quotas:          quotas,
+			Logger:          lagertest.NewTestLogger("test"),
+		}
+	})
+
+	It("caps the number of containers of a tenant", func() {
+		Expect(create("first", "small", garden.Limits{})).To(Succeed())
+		Expect(create("second", "small", garden.Limits{})).To(Succeed())
+
+		err := create("third", "small", garden.Limits{})
+		Expect(err).To(MatchError(gardener.QuotaExceededError{Tenant: "small", Resource: "containers", Limit: 2}))
+		Expect(containerizer.CreateCallCount()).To(Equal(2))
+	})
+
+	It("counts each tenant separately", func() {
+		Expect(create("first", "small", garden.Limits{})).To(Succeed())
+		Expect(create("second", "small", garden.Limits{})).To(Succeed())
+		Expect(create("third", "other", garden.Limits{})).To(Succeed())
+	})
+
+	It("refuses containers without a tenant", func() {
+		_, err := gdnr.Create(garden.ContainerSpec{Handle: "untagged"})
+		Expect(err).To(MatchError(gardener.MissingTenantError{Property: "tenant"}))
+		Expect(containerizer.CreateCallCount()).To(Equal(0))
+	})
+
+	Describe("the tenant of a container", func() {
+		var container garden.Container
+
+		BeforeEach(func() {
+			Expect(create("first", "small", garden.Limits{})).To(Succeed())
+
+			var err error
+			container, err = gdnr.Lookup("first")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("cannot be changed, so the container cannot move to another quota", func() {
+			Expect(container.SetProperty("tenant", "other")).To(MatchError(gardener.ReservedPropertyError{Name: "tenant"}))
+
+			tenant, err := container.Property("tenant")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tenant).To(Equal("small"))
+		})
+
+		It("cannot be removed, so the container cannot escape its quota", func() {
+			Expect(container.RemoveProperty("tenant")).To(MatchError(gardener.ReservedPropertyError{Name: "tenant"}))
+
+			tenant, err := container.Property("tenant")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tenant).To(Equal("small"))
+		})
+
+		It("cannot be changed in a batch", func() {
+			err := container.(gardener.PropertyBatcher).ApplyProperties(properties.Batch{
+				Set: map[string]string{"tenant": "other"},
+			})
+			Expect(err).To(MatchError(gardener.ReservedPropertyError{Name: "tenant"}))
+		})
+
+		It("still counts against the quota of the tenant", func() {
+			Expect(create("second", "small", garden.Limits{})).To(Succeed())
+			Expect(create("third", "small", garden.Limits{})).NotTo(Succeed())
+		})
+	})
+
+	It("gives the container back when it is destroyed", func() {
+		Expect(create("first", "small", garden.Limits{})).To(Succeed())
+		Expect(create("second", "small", garden.Limits{})).To(Succeed())
+
+		containerizer.HandlesReturns([]string{"first", "second"}, nil)
+		Expect(gdnr.Destroy("first")).To(Succeed())
+
+		containerizer.HandlesReturns([]string{"second"}, nil)
+		Expect(create("third", "small", garden.Limits{})).To(Succeed())
+	})
+
+	It("gives the container back when its create fails", func() {
+		Expect(create("first", "small", garden.Limits{})).To(Succeed())
+
+		networker.NetworkReturns(errors.New("network-failed"))
+		Expect(create("second", "small", garden.Limits{})).NotTo(Succeed())
+
+		networker.NetworkReturns(nil)
+		Expect(create("third", "small", garden.Limits{})).To(Succeed())
+	})
+
+	It("caps the total memory limit of a tenant", func() {
+		Expect(create("first", "big", limits(600, 10))).To(Succeed())
+
+		err := create("second", "big", limits(401, 10))
+		Expect(err).To(MatchError(gardener.QuotaExceededError{Tenant: "big", Resource: "bytes of memory", Limit: 1000}))
+
+		Expect(create("third", "big", limits(400, 10))).To(Succeed())
+	})
+
+	It("caps the total CPU shares of a tenant", func() {
+		Expect(create("first", "big", limits(10, 60))).To(Succeed())
+
+		err := create("second", "big", limits(10, 41))
+		Expect(err).To(MatchError(gardener.QuotaExceededError{Tenant: "big", Resource: "cpu shares", Limit: 100}))
+	})
+
+	Describe("when the quota caps memory or CPU", func() {
+		It("refuses containers without a memory limit", func() {
+			err := create("first", "big", limits(0, 10))
+			Expect(err).To(MatchError(gardener.UnlimitedContainerError{Tenant: "big", Resource: "bytes of memory", Limit: 1000}))
+			Expect(containerizer.CreateCallCount()).To(Equal(0))
+		})
+
+		It("refuses containers without a CPU limit", func() {
+			err := create("first", "big", limits(10, 0))
+			Expect(err).To(MatchError(gardener.UnlimitedContainerError{Tenant: "big", Resource: "cpu shares", Limit: 100}))
+			Expect(containerizer.CreateCallCount()).To(Equal(0))
+		})
+
+		It("does not count refused containers", func() {
+			Expect(create("first", "big", limits(0, 0))).NotTo(Succeed())
+			Expect(quotas.Usage()).To(BeEmpty())
+		})
+	})
+
+	Describe("changing the limits of a container", func() {
+		var container garden.Container
+
+		BeforeEach(func() {
+			Expect(create("first", "big", garden.Limits{
+				Memory: garden.MemoryLimits{LimitInBytes: 600},
+				CPU:    garden.CPULimits{LimitInShares: 60},
+			})).To(Succeed())
+			Expect(create("second", "big", garden.Limits{
+				Memory: garden.MemoryLimits{LimitInBytes: 300},
+				CPU:    garden.CPULimits{LimitInShares: 30},
+			})).To(Succeed())
+
+			var err error
+			container, err = gdnr.Lookup("first")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("allows raising the limits within the quota", func() {
+			Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 700})).To(Succeed())
+			Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 70})).To(Succeed())
+		})
+
+		It("refuses raising the limits past the quota", func() {
+			Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 701})).To(MatchError(gardener.QuotaExceededError{Tenant: "big", Resource: "bytes of memory", Limit: 1000}))
+			Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 71})).To(MatchError(gardener.QuotaExceededError{Tenant: "big", Resource: "cpu shares", Limit: 100}))
+			Expect(containerizer.UpdateLimitsCallCount()).To(Equal(0))
+		})
+
+		It("counts the new limits", func() {
+			Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 100})).To(Succeed())
+			Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 10})).To(Succeed())
+
+			Expect(quotas.Usage()["big"]).To(Equal(gardener.TenantUsage{Containers: 2, MemoryBytes: 400, CPUShares: 40}))
+		})
+	})
+
+	Describe("mapping ports", func() {
+		var container garden.Container
+
+		BeforeEach(func() {
+			networker.NetInStub = func(_ lager.Logger, handle string, hostPort, containerPort uint32) (uint32, uint32, error) {
+				var mappings []garden.PortMapping
+				if value, ok := props.Get(handle, gardener.MappedPortsKey); ok {
+					Expect(json.Unmarshal([]byte(value), &mappings)).To(Succeed())
+				}
+				mappings = append(mappings, garden.PortMapping{HostPort: hostPort, ContainerPort: containerPort})
+
+				value, err := json.Marshal(mappings)
+				Expect(err).NotTo(HaveOccurred())
+				props.Set(handle, gardener.MappedPortsKey, string(value))
+
+				return hostPort, containerPort, nil
+			}
+
+			Expect(create("first", "big", limits(10, 10))).To(Succeed())
+
+			var err error
+			container, err = gdnr.Lookup("first")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("caps the number of ports mapped to the containers of a tenant", func() {
+			_, _, err := container.NetIn(1, 1)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = container.NetIn(2, 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = container.NetIn(3, 3)
+			Expect(err).To(MatchError(gardener.QuotaExceededError{Tenant: "big", Resource: "mapped ports", Limit: 2}))
+			Expect(networker.NetInCallCount()).To(Equal(2))
+		})
+
+		It("counts the ports mapped when containers are created", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{
+				Handle:     "second",
+				Properties: garden.Properties{"tenant": "big"},
+				Limits:     limits(10, 10),
+				NetIn:      []garden.NetIn{{HostPort: 1}, {HostPort: 2}, {HostPort: 3}},
+			})
+			Expect(err).To(MatchError(gardener.QuotaExceededError{Tenant: "big", Resource: "mapped ports", Limit: 2}))
+		})
+	})
+
+	It("does not let concurrent creates go past the quota", func() {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		created := 0
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				if create(string(rune('a'+i)), "small", garden.Limits{}) == nil {
+					mu.Lock()
+					created++
+					mu.Unlock()
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		Expect(created).To(Equal(2))
+	})
+
+	It("counts the containers in the properties after a restart", func() {
+		props.Set("existing", "tenant", "small")
+		props.Set("existing", gardener.CommittedMemoryKey, "100")
+
+		Expect(quotas.Usage()).To(Equal(map[string]gardener.TenantUsage{
+			"small": {Containers: 1, MemoryBytes: 100},
+		}))
+
+		Expect(create("first", "small", garden.Limits{})).To(Succeed())
+		Expect(create("second", "small", garden.Limits{})).NotTo(Succeed())
+	})
+
+	Describe("serving the usage", func() {
+		It("writes the quota and usage of each tenant", func() {
+			Expect(create("first", "big", limits(100, 10))).To(Succeed())
+			Expect(create("second", "small", garden.Limits{})).To(Succeed())
+
+			recorder := httptest.NewRecorder()
+			quotas.ServeHTTP(recorder, httptest.NewRequest("GET", "/quotas", nil))
+
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(recorder.Body.String()).To(MatchJSON(`{
+				"big": {
+					"quota": {"max_containers": 10, "max_memory_bytes": 1000, "max_cpu_shares": 100, "max_mapped_ports": 2},
+					"usage": {"containers": 1, "memory_bytes": 100, "cpu_shares": 10, "mapped_ports": 0}
+				},
+				"small": {
+					"quota": {"max_containers": 2},
+					"usage": {"containers": 1, "memory_bytes": 0, "cpu_shares": 0, "mapped_ports": 0}
+				}
+			}`))
+		})
+	})
+
+	Describe("LoadQuotaConfig", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "quotas")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("reads the quotas from the file", func() {
+			path := filepath.Join(dir, "quotas.json")
+			Expect(ioutil.WriteFile(path, []byte(`{
+				"default": {"max_containers": 5},
+				"tenants": {"some-team": {"max_memory_bytes": 1024, "max_mapped_ports": 3}}
+			}`), 0600)).To(Succeed())
+
+			config, err := gardener.LoadQuotaConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(Equal(gardener.QuotaConfig{
+				Default: gardener.Quota{MaxContainers: 5},
+				Tenants: map[string]gardener.Quota{
+					"some-team": {MaxMemoryBytes: 1024, MaxMappedPorts: 3},
+				},
+			}))
+		})
+
+		It("returns an error when the file is not valid JSON", func() {
+			path := filepath.Join(dir, "quotas.json")
+			Expect(ioutil.WriteFile(path, []byte("{"), 0600)).To(Succeed())
+
+			_, err := gardener.LoadQuotaConfig(path)
+			Expect(err).To(MatchError(ContainSubstring("parsing quotas")))
+		})
+	})
+})
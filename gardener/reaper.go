@@ -0,0 +1,284 @@
+package gardener
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/pivotal-golang/clock"
+)
+
+// LastActivityKey holds the time a container was last used by a client, so
+// that idle containers still expire after a restart
+const LastActivityKey = "garden.last-activity"
+
+// activityPersistFraction is the fraction of its grace time for which the
+// activity recorded in a container's properties may lag behind. The exact time
+// is kept in memory, so this only brings expiry forward after a restart, while
+// sparing a property write on every lookup of a busy container.
+const activityPersistFraction = 10
+
+//go:generate counterfeiter . ContainerDestroyer
+
+type ContainerDestroyer interface {
+	Destroy(handle string) error
+}
+
+// Reaper destroys containers which have been idle for longer than their grace
+// time. The time each container was last used is kept in its properties, so
+// the timers can be re-armed from where they left off when the gardener
+// starts. Containers with processes attached are never idle.
+type Reaper struct {
+	logger     lager.Logger
+	clock      clock.Clock
+	properties PropertyManager
+	destroyer  ContainerDestroyer
+
+	mu     sync.Mutex
+	timers map[string]chan struct{}
+	pins   map[string]int
+
+	// activity holds the exact time each container was last used, and
+	// persisted the time last written to its properties
+	activity  map[string]time.Time
+	persisted map[string]time.Time
+}
+
+func NewReaper(logger lager.Logger, clock clock.Clock, properties PropertyManager, destroyer ContainerDestroyer) *Reaper {
+	return &Reaper{
+		logger:     logger.Session("reaper"),
+		clock:      clock,
+		properties: properties,
+		destroyer:  destroyer,
+		timers:     map[string]chan struct{}{},
+		pins:       map[string]int{},
+		activity:   map[string]time.Time{},
+		persisted:  map[string]time.Time{},
+	}
+}
+
+// Start arms the timers of the given containers from their last recorded
+// activity. Containers without recorded activity are treated as just used.
+func (r *Reaper) Start(handles []string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, handle := range handles {
+		if _, ok := r.lastActivity(handle); !ok {
+			r.recordActivity(handle)
+		}
+
+		r.arm(handle)
+	}
+}
+
+// Track records activity on a new container, or one whose grace time has
+// changed, and arms its timer
+func (r *Reaper) Track(handle string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recordActivity(handle)
+	r.arm(handle)
+}
+
+// Touch records activity on a tracked container, restarting its timer
+func (r *Reaper) Touch(handle string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.timers[handle]; !ok {
+		return
+	}
+
+	r.recordActivity(handle)
+	r.arm(handle)
+}
+
+// Pin stops the container from expiring until the returned function is called
+func (r *Reaper) Pin(handle string) func() {
+	if r == nil {
+		return func() {}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pins[handle]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			r.pins[handle]--
+			if r.pins[handle] == 0 {
+				delete(r.pins, handle)
+			}
+
+			if _, ok := r.timers[handle]; ok {
+				r.recordActivity(handle)
+				r.arm(handle)
+			}
+		})
+	}
+}
+
+// Forget stops the timer of a container which has been destroyed
+func (r *Reaper) Forget(handle string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.disarm(handle)
+	delete(r.timers, handle)
+	delete(r.activity, handle)
+	delete(r.persisted, handle)
+}
+
+// arm replaces the timer of the container with one which fires at its
+// deadline. It must be called with the mutex held.
+func (r *Reaper) arm(handle string) {
+	r.disarm(handle)
+
+	stop := make(chan struct{})
+	r.timers[handle] = stop
+
+	graceTime := graceTime(r.properties, handle)
+	if graceTime == 0 || r.pins[handle] > 0 {
+		return
+	}
+
+	last, _ := r.lastActivity(handle)
+	timer := r.clock.NewTimer(last.Add(graceTime).Sub(r.clock.Now()))
+
+	go func() {
+		select {
+		case <-timer.C():
+			r.expire(handle, stop)
+		case <-stop:
+			timer.Stop()
+		}
+	}()
+}
+
+func (r *Reaper) disarm(handle string) {
+	if stop, ok := r.timers[handle]; ok {
+		close(stop)
+		r.timers[handle] = nil
+	}
+}
+
+func (r *Reaper) expire(handle string, stop chan struct{}) {
+	r.mu.Lock()
+
+	// the timer was replaced while it fired
+	if r.timers[handle] != stop {
+		r.mu.Unlock()
+		return
+	}
+
+	graceTime := graceTime(r.properties, handle)
+	last, _ := r.lastActivity(handle)
+	if r.pins[handle] > 0 || graceTime == 0 || r.clock.Now().Before(last.Add(graceTime)) {
+		r.mu.Unlock()
+		return
+	}
+
+	delete(r.timers, handle)
+	r.mu.Unlock()
+
+	log := r.logger.Session("reap", lager.Data{
+		"handle":        handle,
+		"reason":        "idle for longer than its grace time",
+		"grace-time":    graceTime.String(),
+		"last-activity": last,
+	})
+	log.Info("started")
+	defer log.Info("finished")
+
+	if err := r.destroyer.Destroy(handle); err != nil {
+		if _, ok := err.(garden.ContainerNotFoundError); ok {
+			return
+		}
+
+		// try again once the container has been idle for another grace time
+		log.Error("destroy-failed", err)
+		r.Track(handle)
+	}
+}
+
+// recordActivity notes that the container was just used. Containers without a
+// grace time never expire, so nothing is recorded for them. It must be called
+// with the mutex held.
+func (r *Reaper) recordActivity(handle string) {
+	graceTime := graceTime(r.properties, handle)
+	if graceTime == 0 {
+		delete(r.activity, handle)
+		return
+	}
+
+	now := r.clock.Now()
+	r.activity[handle] = now
+
+	if persisted, ok := r.persisted[handle]; ok && now.Sub(persisted) < graceTime/activityPersistFraction {
+		return
+	}
+
+	r.properties.Set(handle, LastActivityKey, now.Format(time.RFC3339Nano))
+	r.persisted[handle] = now
+}
+
+// lastActivity returns the time the container was last used, which is read
+// from its properties when it has not been used since the reaper started. It
+// must be called with the mutex held.
+func (r *Reaper) lastActivity(handle string) (time.Time, bool) {
+	if last, ok := r.activity[handle]; ok {
+		return last, true
+	}
+
+	value, ok := r.properties.Get(handle, LastActivityKey)
+	if !ok {
+		return r.clock.Now(), false
+	}
+
+	last, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return r.clock.Now(), false
+	}
+
+	return last, true
+}
+
+func graceTime(properties PropertyManager, handle string) time.Duration {
+	property, ok := properties.Get(handle, GraceTimeKey)
+	if !ok {
+		return 0
+	}
+
+	var graceTime time.Duration
+	_, err := fmt.Sscanf(property, "%d", &graceTime)
+	if err != nil {
+		return 0
+	}
+
+	return graceTime
+}
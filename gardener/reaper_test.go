@@ -0,0 +1,233 @@
+package gardener_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
+)
+
+var _ = Describe("Reaper", func() {
+	var (
+		logger     *lagertest.TestLogger
+		clock      *fakeclock.FakeClock
+		props      *properties.Manager
+		destroyer  *fakes.FakeContainerDestroyer
+		reaper     *gardener.Reaper
+		setGraceTo func(handle string, graceTime time.Duration)
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		clock = fakeclock.NewFakeClock(time.Unix(1000, 0))
+		props = properties.NewManager()
+		destroyer = new(fakes.FakeContainerDestroyer)
+		reaper = gardener.NewReaper(logger, clock, props, destroyer)
+
+		setGraceTo = func(handle string, graceTime time.Duration) {
+			props.Set(handle, gardener.GraceTimeKey, fmt.Sprintf("%d", graceTime))
+		}
+	})
+
+	destroyed := func() []string {
+		handles := []string{}
+		for i := 0; i < destroyer.DestroyCallCount(); i++ {
+			handles = append(handles, destroyer.DestroyArgsForCall(i))
+		}
+		return handles
+	}
+
+	Context("when a container is tracked", func() {
+		BeforeEach(func() {
+			setGraceTo("idle", time.Minute)
+			reaper.Track("idle")
+		})
+
+		It("records its activity", func() {
+			value, ok := props.Get("idle", gardener.LastActivityKey)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(time.Unix(1000, 0).Format(time.RFC3339Nano)))
+		})
+
+		It("destroys it once it has been idle for its grace time", func() {
+			clock.WaitForWatcherAndIncrement(59 * time.Second)
+			Consistently(destroyer.DestroyCallCount).Should(Equal(0))
+
+			clock.Increment(time.Second)
+			Eventually(destroyed).Should(Equal([]string{"idle"}))
+		})
+
+		It("logs why the container was destroyed", func() {
+			clock.WaitForWatcherAndIncrement(time.Minute)
+			Eventually(logger).Should(gbytes.Say("idle for longer than its grace time"))
+		})
+
+		Context("when it is touched", func() {
+			It("restarts its timer", func() {
+				clock.WaitForWatcherAndIncrement(30 * time.Second)
+				reaper.Touch("idle")
+
+				clock.WaitForWatcherAndIncrement(45 * time.Second)
+				Consistently(destroyer.DestroyCallCount).Should(Equal(0))
+
+				clock.Increment(15 * time.Second)
+				Eventually(destroyed).Should(Equal([]string{"idle"}))
+			})
+
+			It("only records the activity in its properties once a tenth of its grace time has passed", func() {
+				clock.WaitForWatcherAndIncrement(5 * time.Second)
+				reaper.Touch("idle")
+
+				value, _ := props.Get("idle", gardener.LastActivityKey)
+				Expect(value).To(Equal(time.Unix(1000, 0).Format(time.RFC3339Nano)))
+
+				clock.WaitForWatcherAndIncrement(time.Second)
+				reaper.Touch("idle")
+
+				value, _ = props.Get("idle", gardener.LastActivityKey)
+				Expect(value).To(Equal(time.Unix(1006, 0).Format(time.RFC3339Nano)))
+			})
+
+			It("restarts its timer from the exact time of the activity, even when it is not recorded", func() {
+				clock.WaitForWatcherAndIncrement(30 * time.Second)
+				reaper.Touch("idle")
+				clock.WaitForWatcherAndIncrement(5 * time.Second)
+				reaper.Touch("idle")
+
+				clock.WaitForWatcherAndIncrement(59 * time.Second)
+				Consistently(destroyer.DestroyCallCount).Should(Equal(0))
+
+				clock.Increment(time.Second)
+				Eventually(destroyed).Should(Equal([]string{"idle"}))
+			})
+		})
+
+		Context("when it is pinned", func() {
+			It("is not destroyed until it is unpinned and idle again", func() {
+				unpin := reaper.Pin("idle")
+
+				clock.Increment(2 * time.Minute)
+				Consistently(destroyer.DestroyCallCount).Should(Equal(0))
+
+				unpin()
+				clock.WaitForWatcherAndIncrement(time.Minute)
+				Eventually(destroyed).Should(Equal([]string{"idle"}))
+			})
+		})
+
+		Context("when it is forgotten", func() {
+			It("is not destroyed", func() {
+				reaper.Forget("idle")
+
+				clock.Increment(2 * time.Minute)
+				Consistently(destroyer.DestroyCallCount).Should(Equal(0))
+			})
+		})
+
+		Context("when destroying it fails", func() {
+			It("tries again after another grace time", func() {
+				destroyer.DestroyReturns(errors.New("busy"))
+
+				clock.WaitForWatcherAndIncrement(time.Minute)
+				Eventually(destroyer.DestroyCallCount).Should(Equal(1))
+
+				clock.WaitForWatcherAndIncrement(time.Minute)
+				Eventually(destroyer.DestroyCallCount).Should(Equal(2))
+			})
+		})
+	})
+
+	Context("when a container has no grace time", func() {
+		It("is never destroyed", func() {
+			reaper.Track("forever")
+
+			clock.Increment(time.Hour)
+			Consistently(destroyer.DestroyCallCount).Should(Equal(0))
+		})
+
+		It("does not write its activity to its properties as it is used", func() {
+			propertyManager := new(fakes.FakePropertyManager)
+			reaper = gardener.NewReaper(logger, clock, propertyManager, destroyer)
+
+			reaper.Track("forever")
+			for i := 0; i < 10; i++ {
+				clock.Increment(time.Minute)
+				reaper.Touch("forever")
+			}
+			reaper.Pin("forever")()
+
+			Expect(propertyManager.SetCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when touching a container which is not tracked", func() {
+		It("does not record activity for it", func() {
+			reaper.Touch("unknown")
+
+			_, ok := props.Get("unknown", gardener.LastActivityKey)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("Start", func() {
+		Context("when a container recorded activity before a restart", func() {
+			BeforeEach(func() {
+				setGraceTo("restored", time.Minute)
+				props.Set("restored", gardener.LastActivityKey, time.Unix(1000-40, 0).Format(time.RFC3339Nano))
+			})
+
+			It("destroys it once its grace time has passed since that activity", func() {
+				reaper.Start([]string{"restored"})
+
+				clock.WaitForWatcherAndIncrement(19 * time.Second)
+				Consistently(destroyer.DestroyCallCount).Should(Equal(0))
+
+				clock.Increment(time.Second)
+				Eventually(destroyed).Should(Equal([]string{"restored"}))
+			})
+
+			Context("when the grace time has already passed", func() {
+				It("destroys it straight away", func() {
+					setGraceTo("restored", 10*time.Second)
+					reaper.Start([]string{"restored"})
+
+					Eventually(destroyed).Should(Equal([]string{"restored"}))
+				})
+			})
+		})
+
+		Context("when a container has no recorded activity", func() {
+			It("treats it as just used", func() {
+				setGraceTo("unrecorded", time.Minute)
+				reaper.Start([]string{"unrecorded"})
+
+				clock.WaitForWatcherAndIncrement(59 * time.Second)
+				Consistently(destroyer.DestroyCallCount).Should(Equal(0))
+
+				clock.Increment(time.Second)
+				Eventually(destroyed).Should(Equal([]string{"unrecorded"}))
+			})
+		})
+	})
+
+	Context("when the reaper is nil", func() {
+		It("does nothing", func() {
+			var nilReaper *gardener.Reaper
+			Expect(func() {
+				nilReaper.Start([]string{"a"})
+				nilReaper.Track("a")
+				nilReaper.Touch("a")
+				nilReaper.Pin("a")()
+				nilReaper.Forget("a")
+			}).NotTo(Panic())
+		})
+	})
+})
@@ -0,0 +1,145 @@
+package gardener
+
+import (
+	"sort"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . OrphanSource
+
+// OrphanSource finds and removes resources which were left behind by
+// containers that no longer exist, e.g. iptables chains or runtime state
+// surviving a crash
+type OrphanSource interface {
+	Orphans(log lager.Logger, handles []string) ([]string, error)
+	RemoveOrphan(log lager.Logger, id string) error
+}
+
+// ReconcileReport describes what was done to bring the host back in line with
+// the depot when the gardener started
+type ReconcileReport struct {
+	// Restored contains the handles of the containers which were restored
+	Restored []string `json:"restored"`
+
+	// Destroyed contains the handles of the containers which could not be
+	// restored, or which only had properties left, and so were destroyed
+	Destroyed []string `json:"destroyed"`
+
+	// Removed contains the ids of the orphaned resources which were removed,
+	// keyed by the name of their OrphanSource
+	Removed map[string][]string `json:"removed"`
+
+	Failures []ReconcileFailure `json:"failures"`
+}
+
+type ReconcileFailure struct {
+	Source string `json:"source"`
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error"`
+}
+
+// ReconcileReport returns the report of the reconciliation done by Start
+func (g *Gardener) ReconcileReport() ReconcileReport {
+	g.reconcileMutex.Lock()
+	defer g.reconcileMutex.Unlock()
+
+	return g.reconcileReport
+}
+
+func (g *Gardener) reconcile(log lager.Logger, handles []string) ReconcileReport {
+	log = log.Session("reconcile")
+	log.Info("started")
+	defer log.Info("finished")
+
+	report := ReconcileReport{
+		Restored:  []string{},
+		Destroyed: []string{},
+		Removed:   map[string][]string{},
+		Failures:  []ReconcileFailure{},
+	}
+
+	unrestored := g.Restorer.Restore(log, handles)
+	live := difference(handles, unrestored)
+	report.Restored = append(report.Restored, live...)
+
+	for _, handle := range unrestored {
+		if !g.reconcileDestroy(log, handle, "container", &report) {
+			live = append(live, handle)
+		}
+	}
+
+	// properties are persisted separately from the depot, so containers whose
+	// depot directory went missing may still have networks and volumes to clean
+	// up
+	propertyHandles := difference(g.PropertyManager.Handles(), handles)
+	sort.Strings(propertyHandles)
+	for _, handle := range propertyHandles {
+		g.reconcileDestroy(log, handle, "properties", &report)
+	}
+
+	names := []string{}
+	for name := range g.OrphanSources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g.removeOrphans(log.Session("remove-orphans", lager.Data{"source": name}), name, live, &report)
+	}
+
+	return report
+}
+
+func (g *Gardener) reconcileDestroy(log lager.Logger, handle, source string, report *ReconcileReport) bool {
+	log = log.Session("clean-up-container", lager.Data{"handle": handle, "source": source})
+	log.Info("start")
+
+	if err := g.destroy(log, handle); err != nil {
+		log.Error("failed", err)
+		report.Failures = append(report.Failures, ReconcileFailure{Source: source, ID: handle, Error: err.Error()})
+		return false
+	}
+
+	log.Info("cleaned-up")
+	report.Destroyed = append(report.Destroyed, handle)
+	return true
+}
+
+func (g *Gardener) removeOrphans(log lager.Logger, name string, handles []string, report *ReconcileReport) {
+	source := g.OrphanSources[name]
+
+	ids, err := source.Orphans(log, handles)
+	if err != nil {
+		log.Error("listing-orphans-failed", err)
+		report.Failures = append(report.Failures, ReconcileFailure{Source: name, Error: err.Error()})
+		return
+	}
+
+	for _, id := range ids {
+		if err := source.RemoveOrphan(log, id); err != nil {
+			log.Error("removing-orphan-failed", err, lager.Data{"id": id})
+			report.Failures = append(report.Failures, ReconcileFailure{Source: name, ID: id, Error: err.Error()})
+			continue
+		}
+
+		log.Info("removed-orphan", lager.Data{"id": id})
+		report.Removed[name] = append(report.Removed[name], id)
+	}
+}
+
+func difference(a, b []string) []string {
+	exclude := make(map[string]bool)
+	for _, s := range b {
+		exclude[s] = true
+	}
+
+	result := []string{}
+	for _, s := range a {
+		if !exclude[s] {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
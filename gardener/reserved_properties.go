@@ -0,0 +1,81 @@
+package gardener
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultReservedPropertyPrefixes are the namespaces of the properties which
+// the gardener and its components keep, such as the state, grace time and
+// network of each container. Clients may read them but not change them.
+var DefaultReservedPropertyPrefixes = []string{"garden.", "kawasaki."}
+
+// ReservedPropertyError is returned when a client tries to set or remove a
+// property in a reserved namespace
+type ReservedPropertyError struct {
+	Name string
+}
+
+func (err ReservedPropertyError) Error() string {
+	return fmt.Sprintf("property '%s' is reserved and cannot be changed by clients", err.Name)
+}
+
+// reservedProperties are the properties clients may not change
+type reservedProperties struct {
+	// prefixes are the namespaces configured by the operator, which are
+	// reserved as well as the defaults
+	prefixes []string
+
+	// createOnly are properties which clients may give a container when it is
+	// created, but not change after, such as the tenant of the container
+	createOnly []string
+}
+
+func (reserved reservedProperties) check(name string) error {
+	for _, prefix := range DefaultReservedPropertyPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return ReservedPropertyError{Name: name}
+		}
+	}
+
+	for _, prefix := range reserved.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return ReservedPropertyError{Name: name}
+		}
+	}
+
+	for _, createOnly := range reserved.createOnly {
+		if name == createOnly {
+			return ReservedPropertyError{Name: name}
+		}
+	}
+
+	return nil
+}
+
+// checkSpec checks the properties a client asked a container to be created
+// with. The profile is picked with a reserved property, so it is allowed, as
+// are the properties which may only be given at create.
+func (reserved reservedProperties) checkSpec(properties map[string]string) error {
+	for name := range properties {
+		if name == ProfileKey || reserved.isCreateOnly(name) {
+			continue
+		}
+
+		if err := reserved.check(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (reserved reservedProperties) isCreateOnly(name string) bool {
+	for _, createOnly := range reserved.createOnly {
+		if name == createOnly {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,136 @@
+package gardener_test
+
+import (
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reserved properties", func() {
+	var (
+		containerizer   *fakes.FakeContainerizer
+		propertyManager *fakes.FakePropertyManager
+		gdnr            *gardener.Gardener
+	)
+
+	BeforeEach(func() {
+		containerizer = new(fakes.FakeContainerizer)
+		propertyManager = new(fakes.FakePropertyManager)
+
+		gdnr = &gardener.Gardener{
+			Containerizer:            containerizer,
+			Networker:                new(fakes.FakeNetworker),
+			VolumeCreator:            new(fakes.FakeVolumeCreator),
+			SysInfoProvider:          new(fakes.FakeSysInfoProvider),
+			UidGenerator:             new(fakes.FakeUidGenerator),
+			PropertyManager:          propertyManager,
+			ReservedPropertyPrefixes: []string{"operator."},
+			Logger:                   lagertest.NewTestLogger("test"),
+		}
+	})
+
+	Describe("SetProperty and RemoveProperty", func() {
+		var container garden.Container
+
+		BeforeEach(func() {
+			var err error
+			container, err = gdnr.Lookup("some-handle")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		for _, name := range []string{
+			gardener.ContainerIPKey,
+			gardener.GraceTimeKey,
+			"garden.state",
+			"kawasaki.subnet",
+			"operator.tenant",
+		} {
+			name := name
+
+			It("refuses to set "+name, func() {
+				Expect(container.SetProperty(name, "value")).To(MatchError(gardener.ReservedPropertyError{Name: name}))
+				Expect(propertyManager.SetCallCount()).To(Equal(0))
+			})
+
+			It("refuses to remove "+name, func() {
+				Expect(container.RemoveProperty(name)).To(MatchError(gardener.ReservedPropertyError{Name: name}))
+				Expect(propertyManager.RemoveCallCount()).To(Equal(0))
+			})
+		}
+
+		It("refuses batches which change reserved properties", func() {
+			batcher := container.(gardener.PropertyBatcher)
+
+			Expect(batcher.ApplyProperties(properties.Batch{
+				Set: map[string]string{"app": "x", "garden.state": "created"},
+			})).To(MatchError(gardener.ReservedPropertyError{Name: "garden.state"}))
+			Expect(batcher.ApplyProperties(properties.Batch{
+				Remove: []string{"kawasaki.subnet"},
+			})).To(MatchError(gardener.ReservedPropertyError{Name: "kawasaki.subnet"}))
+			Expect(propertyManager.ApplyCallCount()).To(Equal(0))
+		})
+
+		It("allows batches to depend on reserved properties", func() {
+			Expect(container.(gardener.PropertyBatcher).ApplyProperties(properties.Batch{
+				Preconditions: []properties.Precondition{{Name: "garden.state", Value: "created"}},
+				Set:           map[string]string{"app": "x"},
+			})).To(Succeed())
+			Expect(propertyManager.ApplyCallCount()).To(Equal(1))
+		})
+
+		It("allows properties outside the reserved namespaces", func() {
+			Expect(container.SetProperty("gardenish", "value")).To(Succeed())
+			Expect(container.RemoveProperty("gardenish")).To(Succeed())
+			Expect(propertyManager.SetCallCount()).To(Equal(1))
+			Expect(propertyManager.RemoveCallCount()).To(Equal(1))
+		})
+
+		It("still lets the grace time be set through SetGraceTime", func() {
+			Expect(container.SetGraceTime(5)).To(Succeed())
+			_, name, _ := propertyManager.SetArgsForCall(0)
+			Expect(name).To(Equal(gardener.GraceTimeKey))
+		})
+	})
+
+	Describe("Create", func() {
+		It("refuses specs with reserved properties before creating anything", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{
+				Handle:     "some-handle",
+				Properties: garden.Properties{"garden.state": "created"},
+			})
+			Expect(err).To(MatchError(gardener.ReservedPropertyError{Name: "garden.state"}))
+
+			Expect(containerizer.CreateCallCount()).To(Equal(0))
+			Expect(propertyManager.SetCallCount()).To(Equal(0))
+		})
+
+		It("allows the profile to be picked", func() {
+			gdnr.Profiles = gardener.Profiles{"small": {}}
+
+			_, err := gdnr.Create(garden.ContainerSpec{
+				Handle:     "some-handle",
+				Properties: garden.Properties{gardener.ProfileKey: "small"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("still records the properties the gardener keeps", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{
+				Handle: "some-handle",
+				Limits: garden.Limits{Memory: garden.MemoryLimits{LimitInBytes: 1024}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			names := []string{}
+			for i := 0; i < propertyManager.SetCallCount(); i++ {
+				_, name, _ := propertyManager.SetArgsForCall(i)
+				names = append(names, name)
+			}
+			Expect(names).To(ConsistOf(gardener.CommittedMemoryKey, "garden.state"))
+		})
+	})
+})
@@ -1,23 +1,43 @@
 package gardener
 
-import "code.cloudfoundry.org/lager"
+import (
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+)
 
 type restorer struct {
-	networker Networker
+	networker     Networker
+	containerizer Containerizer
 }
 
-func NewRestorer(networker Networker) Restorer {
+func NewRestorer(networker Networker, containerizer Containerizer) Restorer {
 	return &restorer{
-		networker: networker,
+		networker:     networker,
+		containerizer: containerizer,
 	}
 }
 
+// Restore returns the handles which cannot be restored, either because the
+// runtime no longer knows about the container or because its network could not
+// be restored. Only a garden.ContainerNotFoundError shows the container is
+// gone; when looking it up fails for any other reason it is restored as usual,
+// rather than destroying a container which may be fine.
 func (r *restorer) Restore(logger lager.Logger, handles []string) []string {
 	failedHandles := []string{}
 
 	for _, handle := range handles {
 		log := logger.Session("looking-for-properties", lager.Data{"handle": handle})
 
+		if _, err := r.containerizer.Info(log, handle); err != nil {
+			if _, ok := err.(garden.ContainerNotFoundError); ok {
+				log.Error("failed-finding-container-in-runtime", err)
+				failedHandles = append(failedHandles, handle)
+				continue
+			}
+
+			log.Error("failed-looking-up-container", err)
+		}
+
 		err := r.networker.Restore(logger, handle)
 		if err != nil {
 			log.Error("failed-restoring-container", err)
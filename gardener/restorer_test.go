@@ -3,6 +3,7 @@ package gardener_test
 import (
 	"errors"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/gardener"
 	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
 	"code.cloudfoundry.org/lager"
@@ -14,14 +15,16 @@ import (
 
 var _ = Describe("Restorer", func() {
 	var (
-		fakeNetworker *fakes.FakeNetworker
-		restorer      gardener.Restorer
-		logger        lager.Logger
+		fakeNetworker     *fakes.FakeNetworker
+		fakeContainerizer *fakes.FakeContainerizer
+		restorer          gardener.Restorer
+		logger            lager.Logger
 	)
 
 	BeforeEach(func() {
 		fakeNetworker = new(fakes.FakeNetworker)
-		restorer = gardener.NewRestorer(fakeNetworker)
+		fakeContainerizer = new(fakes.FakeContainerizer)
+		restorer = gardener.NewRestorer(fakeNetworker, fakeContainerizer)
 		logger = lagertest.NewTestLogger("test")
 	})
 
@@ -47,5 +50,45 @@ var _ = Describe("Restorer", func() {
 
 			Expect(restorer.Restore(logger, []string{"foo", "bar"})).To(Equal([]string{"bar"}))
 		})
+
+		Context("when the runtime no longer knows about a container", func() {
+			BeforeEach(func() {
+				fakeContainerizer.InfoStub = func(_ lager.Logger, handle string) (gardener.ActualContainerSpec, error) {
+					if handle == "foo" {
+						return gardener.ActualContainerSpec{}, garden.ContainerNotFoundError{Handle: handle}
+					}
+
+					return gardener.ActualContainerSpec{}, nil
+				}
+			})
+
+			It("returns its handle", func() {
+				Expect(restorer.Restore(logger, []string{"foo", "bar"})).To(Equal([]string{"foo"}))
+			})
+
+			It("does not restore its network", func() {
+				restorer.Restore(logger, []string{"foo", "bar"})
+
+				Expect(fakeNetworker.RestoreCallCount()).To(Equal(1))
+				_, handle := fakeNetworker.RestoreArgsForCall(0)
+				Expect(handle).To(Equal("bar"))
+			})
+		})
+
+		Context("when looking up a container fails for another reason", func() {
+			BeforeEach(func() {
+				fakeContainerizer.InfoReturns(gardener.ActualContainerSpec{}, errors.New("runc state timed out"))
+			})
+
+			It("does not return its handle, so that it is not destroyed", func() {
+				Expect(restorer.Restore(logger, []string{"foo", "bar"})).To(BeEmpty())
+			})
+
+			It("still restores its network", func() {
+				restorer.Restore(logger, []string{"foo", "bar"})
+
+				Expect(fakeNetworker.RestoreCallCount()).To(Equal(2))
+			})
+		})
 	})
 })
@@ -0,0 +1,203 @@
+package gardener
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/pivotal-golang/clock"
+)
+
+// Sweeper periodically removes resources leaked by creates and destroys which
+// failed halfway through. A resource is only removed once it has been orphaned
+// for longer than the grace period, so that resources belonging to operations
+// still in flight are left alone.
+type Sweeper struct {
+	logger          lager.Logger
+	sources         map[string]OrphanSource
+	containerizer   Containerizer
+	propertyManager PropertyManager
+	clock           clock.Clock
+	interval        time.Duration
+	gracePeriod     time.Duration
+	dryRun          bool
+
+	mu        sync.Mutex
+	firstSeen map[string]time.Time
+	removed   int
+	failed    int
+
+	stopped chan struct{}
+	done    chan struct{}
+}
+
+func NewSweeper(
+	logger lager.Logger,
+	sources map[string]OrphanSource,
+	containerizer Containerizer,
+	propertyManager PropertyManager,
+	clock clock.Clock,
+	interval, gracePeriod time.Duration,
+	dryRun bool,
+) *Sweeper {
+	return &Sweeper{
+		logger:          logger,
+		sources:         sources,
+		containerizer:   containerizer,
+		propertyManager: propertyManager,
+		clock:           clock,
+		interval:        interval,
+		gracePeriod:     gracePeriod,
+		dryRun:          dryRun,
+
+		firstSeen: map[string]time.Time{},
+		stopped:   make(chan struct{}),
+	}
+}
+
+func (s *Sweeper) Start() {
+	log := s.logger.Session("orphan-sweeper", lager.Data{
+		"interval":     s.interval.String(),
+		"grace-period": s.gracePeriod.String(),
+		"dry-run":      s.dryRun,
+	})
+	log.Info("starting")
+	ticker := s.clock.NewTicker(s.interval)
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		defer ticker.Stop()
+
+		log.Info("started")
+		defer log.Info("finished")
+
+		for {
+			select {
+			case <-ticker.C():
+				s.Sweep(log)
+			case <-s.stopped:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops sweeping, waiting for a sweep in progress to finish
+func (s *Sweeper) Stop() {
+	close(s.stopped)
+	if s.done != nil {
+		<-s.done
+	}
+}
+
+// Sweep looks for orphans in every source, removing those which have been
+// orphaned for longer than the grace period unless in dry-run mode
+func (s *Sweeper) Sweep(log lager.Logger) {
+	log = log.Session("sweep")
+	log.Debug("started")
+	defer log.Debug("finished")
+
+	handles, err := s.containerizer.Handles()
+	if err != nil {
+		log.Error("listing-handles-failed", err)
+		return
+	}
+
+	// a container may only exist in one of the depot and the properties while
+	// it is being created or destroyed
+	handles = append(handles, difference(s.propertyManager.Handles(), handles)...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	stillOrphaned := map[string]time.Time{}
+
+	names := []string{}
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sourceLog := log.Session("source", lager.Data{"source": name})
+		source := s.sources[name]
+
+		ids, err := source.Orphans(sourceLog, handles)
+		if err != nil {
+			sourceLog.Error("listing-orphans-failed", err)
+			s.failed++
+
+			// don't restart the grace period of orphans just because listing failed
+			for key, seen := range s.firstSeen {
+				if strings.HasPrefix(key, name+"/") {
+					stillOrphaned[key] = seen
+				}
+			}
+			continue
+		}
+
+		for _, id := range ids {
+			key := name + "/" + id
+
+			seen, ok := s.firstSeen[key]
+			if !ok {
+				seen = now
+			}
+
+			if now.Sub(seen) < s.gracePeriod {
+				stillOrphaned[key] = seen
+				continue
+			}
+
+			if s.dryRun {
+				sourceLog.Info("would-remove-orphan", lager.Data{"id": id, "first-seen": seen})
+				stillOrphaned[key] = seen
+				continue
+			}
+
+			if err := source.RemoveOrphan(sourceLog, id); err != nil {
+				sourceLog.Error("removing-orphan-failed", err, lager.Data{"id": id})
+				stillOrphaned[key] = seen
+				s.failed++
+				continue
+			}
+
+			sourceLog.Info("removed-orphan", lager.Data{"id": id, "first-seen": seen})
+			s.removed++
+		}
+	}
+
+	s.firstSeen = stillOrphaned
+}
+
+// OrphansRemoved returns the number of orphans removed since the sweeper was
+// created
+func (s *Sweeper) OrphansRemoved() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.removed
+}
+
+// OrphansPending returns the number of orphans found which have not been
+// removed, either because they are within the grace period, removing them
+// failed or the sweeper is in dry-run mode
+func (s *Sweeper) OrphansPending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.firstSeen)
+}
+
+// SweepFailures returns the number of times listing or removing an orphan has
+// failed since the sweeper was created
+func (s *Sweeper) SweepFailures() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.failed
+}
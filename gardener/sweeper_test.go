@@ -0,0 +1,227 @@
+package gardener_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/pivotal-golang/clock/fakeclock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sweeper", func() {
+	var (
+		containerizer   *fakes.FakeContainerizer
+		propertyManager *fakes.FakePropertyManager
+		chains          *fakes.FakeOrphanSource
+		veths           *fakes.FakeOrphanSource
+		clock           *fakeclock.FakeClock
+		logger          *lagertest.TestLogger
+		gracePeriod     time.Duration
+		dryRun          bool
+
+		sweeper *gardener.Sweeper
+	)
+
+	BeforeEach(func() {
+		containerizer = new(fakes.FakeContainerizer)
+		containerizer.HandlesReturns([]string{"handle1", "handle2"}, nil)
+		propertyManager = new(fakes.FakePropertyManager)
+		propertyManager.HandlesReturns([]string{"handle2", "handle3"})
+
+		chains = new(fakes.FakeOrphanSource)
+		chains.OrphansReturns([]string{"chain1", "chain2"}, nil)
+		veths = new(fakes.FakeOrphanSource)
+		veths.OrphansReturns([]string{"veth1"}, nil)
+
+		clock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		logger = lagertest.NewTestLogger("test")
+		gracePeriod = time.Minute
+		dryRun = false
+	})
+
+	JustBeforeEach(func() {
+		sweeper = gardener.NewSweeper(
+			logger,
+			map[string]gardener.OrphanSource{"iptables": chains, "veths": veths},
+			containerizer,
+			propertyManager,
+			clock,
+			time.Second,
+			gracePeriod,
+			dryRun,
+		)
+	})
+
+	It("looks for orphans which do not belong to containers in either the depot or the properties", func() {
+		sweeper.Sweep(logger)
+
+		Expect(chains.OrphansCallCount()).To(Equal(1))
+		_, handles := chains.OrphansArgsForCall(0)
+		Expect(handles).To(ConsistOf("handle1", "handle2", "handle3"))
+	})
+
+	It("does not remove orphans which have just been found", func() {
+		sweeper.Sweep(logger)
+
+		Expect(chains.RemoveOrphanCallCount()).To(Equal(0))
+		Expect(veths.RemoveOrphanCallCount()).To(Equal(0))
+		Expect(sweeper.OrphansPending()).To(Equal(3))
+	})
+
+	Context("when orphans are still orphaned after the grace period", func() {
+		JustBeforeEach(func() {
+			sweeper.Sweep(logger)
+			clock.Increment(gracePeriod)
+			chains.OrphansReturns([]string{"chain2", "chain3"}, nil)
+			sweeper.Sweep(logger)
+		})
+
+		It("removes them", func() {
+			Expect(chains.RemoveOrphanCallCount()).To(Equal(1))
+			_, id := chains.RemoveOrphanArgsForCall(0)
+			Expect(id).To(Equal("chain2"))
+
+			Expect(veths.RemoveOrphanCallCount()).To(Equal(1))
+			_, id = veths.RemoveOrphanArgsForCall(0)
+			Expect(id).To(Equal("veth1"))
+		})
+
+		It("counts what it removed", func() {
+			Expect(sweeper.OrphansRemoved()).To(Equal(2))
+			Expect(sweeper.OrphansPending()).To(Equal(1))
+		})
+
+		Context("when an orphan is found again later", func() {
+			It("waits for the grace period again", func() {
+				chains.OrphansReturns([]string{"chain1"}, nil)
+				sweeper.Sweep(logger)
+
+				Expect(chains.RemoveOrphanCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when in dry-run mode", func() {
+			BeforeEach(func() {
+				dryRun = true
+			})
+
+			It("does not remove them", func() {
+				Expect(chains.RemoveOrphanCallCount()).To(Equal(0))
+				Expect(veths.RemoveOrphanCallCount()).To(Equal(0))
+			})
+
+			It("logs what it would remove", func() {
+				Expect(logger.LogMessages()).To(ContainElement("test.sweep.source.would-remove-orphan"))
+			})
+
+			It("counts them as pending", func() {
+				Expect(sweeper.OrphansRemoved()).To(Equal(0))
+				Expect(sweeper.OrphansPending()).To(Equal(3))
+			})
+		})
+
+		Context("when removing an orphan fails", func() {
+			BeforeEach(func() {
+				veths.RemoveOrphanReturns(errors.New("boom"))
+			})
+
+			It("retries on the next sweep", func() {
+				sweeper.Sweep(logger)
+				Expect(veths.RemoveOrphanCallCount()).To(Equal(2))
+			})
+
+			It("counts the failure", func() {
+				Expect(sweeper.SweepFailures()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when listing the orphans of a source fails", func() {
+		It("still sweeps the other sources", func() {
+			chains.OrphansReturns(nil, errors.New("boom"))
+			sweeper.Sweep(logger)
+
+			Expect(veths.OrphansCallCount()).To(Equal(1))
+			Expect(sweeper.SweepFailures()).To(Equal(1))
+		})
+
+		It("does not restart the grace period of its orphans", func() {
+			sweeper.Sweep(logger)
+
+			clock.Increment(gracePeriod / 2)
+			chains.OrphansReturns(nil, errors.New("boom"))
+			sweeper.Sweep(logger)
+
+			clock.Increment(gracePeriod / 2)
+			chains.OrphansReturns([]string{"chain1"}, nil)
+			sweeper.Sweep(logger)
+
+			Expect(chains.RemoveOrphanCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when listing the handles fails", func() {
+		BeforeEach(func() {
+			containerizer.HandlesReturns(nil, errors.New("boom"))
+		})
+
+		It("does not look for orphans", func() {
+			sweeper.Sweep(logger)
+
+			Expect(chains.OrphansCallCount()).To(Equal(0))
+			Expect(veths.OrphansCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("Start", func() {
+		JustBeforeEach(func() {
+			sweeper.Start()
+		})
+
+		AfterEach(func() {
+			sweeper.Stop()
+		})
+
+		It("sweeps when the interval elapses", func() {
+			Consistently(chains.OrphansCallCount).Should(Equal(0))
+
+			clock.Increment(time.Second)
+			Eventually(chains.OrphansCallCount).Should(Equal(1))
+
+			clock.Increment(time.Second)
+			Eventually(chains.OrphansCallCount).Should(Equal(2))
+		})
+	})
+
+	Describe("Stop", func() {
+		It("waits for a sweep in progress", func() {
+			sweeping := make(chan struct{})
+			finishSweep := make(chan struct{})
+			chains.OrphansStub = func(lager.Logger, []string) ([]string, error) {
+				close(sweeping)
+				<-finishSweep
+				return nil, nil
+			}
+			sweeper.Start()
+
+			clock.Increment(time.Second)
+			Eventually(sweeping).Should(BeClosed())
+
+			stopped := make(chan struct{})
+			go func() {
+				sweeper.Stop()
+				close(stopped)
+			}()
+			Consistently(stopped).ShouldNot(BeClosed())
+
+			close(finishSweep)
+			Eventually(stopped).Should(BeClosed())
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package gardener
+
+import "time"
+
+// Outcomes of the stages of an operation
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomeTimeout = "timeout"
+)
+
+// Span records how long a stage of creating or destroying a container took,
+// and how it turned out
+type Span struct {
+	// Operation is "create" or "destroy"
+	Operation string
+	Stage     string
+	Handle    string
+
+	Start    time.Time
+	Duration time.Duration
+
+	Outcome string
+	Error   string
+}
+
+//go:generate counterfeiter . Tracer
+
+// Tracer is told about each stage of the creates and destroys
+type Tracer interface {
+	RecordSpan(span Span)
+}
+
+// Tracers tell every one of a number of tracers about each span
+type Tracers []Tracer
+
+func (tracers Tracers) RecordSpan(span Span) {
+	for _, tracer := range tracers {
+		tracer.RecordSpan(span)
+	}
+}
+
+// trace runs a stage of an operation on a container, telling the Tracer, if
+// there is one, how long it took and how it turned out
+func (g *Gardener) trace(operation, handle, stage string, fn func() error) error {
+	if g.Tracer == nil {
+		return fn()
+	}
+
+	start := time.Now()
+	err := fn()
+
+	span := Span{
+		Operation: operation,
+		Stage:     stage,
+		Handle:    handle,
+		Start:     start,
+		Duration:  time.Since(start),
+		Outcome:   outcome(err),
+	}
+	if err != nil {
+		span.Error = err.Error()
+	}
+	g.Tracer.RecordSpan(span)
+
+	return err
+}
+
+func outcome(err error) string {
+	switch err.(type) {
+	case nil:
+		return OutcomeSuccess
+	case CreateStageTimeoutError:
+		return OutcomeTimeout
+	default:
+		return OutcomeFailure
+	}
+}
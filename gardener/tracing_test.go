@@ -0,0 +1,151 @@
+package gardener_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden-shed/rootfs_spec"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		containerizer *fakes.FakeContainerizer
+		networker     *fakes.FakeNetworker
+		volumeCreator *fakes.FakeVolumeCreator
+		tracer        *fakes.FakeTracer
+		gdnr          *gardener.Gardener
+	)
+
+	BeforeEach(func() {
+		containerizer = new(fakes.FakeContainerizer)
+		networker = new(fakes.FakeNetworker)
+		volumeCreator = new(fakes.FakeVolumeCreator)
+		tracer = new(fakes.FakeTracer)
+
+		gdnr = &gardener.Gardener{
+			Containerizer:   containerizer,
+			Networker:       networker,
+			VolumeCreator:   volumeCreator,
+			SysInfoProvider: new(fakes.FakeSysInfoProvider),
+			UidGenerator:    new(fakes.FakeUidGenerator),
+			PropertyManager: new(fakes.FakePropertyManager),
+			Tracer:          tracer,
+			Logger:          lagertest.NewTestLogger("test"),
+		}
+	})
+
+	spans := func() []gardener.Span {
+		recorded := []gardener.Span{}
+		for i := 0; i < tracer.RecordSpanCallCount(); i++ {
+			recorded = append(recorded, tracer.RecordSpanArgsForCall(i))
+		}
+		return recorded
+	}
+
+	stages := func() []string {
+		names := []string{}
+		for _, span := range spans() {
+			names = append(names, span.Operation+"/"+span.Stage+"/"+span.Outcome)
+		}
+		return names
+	}
+
+	It("records a span for each stage of a create", func() {
+		volumeCreator.CreateStub = func(context.Context, lager.Logger, string, rootfs_spec.Spec) (gardener.DesiredImageSpec, error) {
+			time.Sleep(10 * time.Millisecond)
+			return gardener.DesiredImageSpec{}, nil
+		}
+
+		before := time.Now()
+		_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stages()).To(Equal([]string{
+			"create/volume/success",
+			"create/container/success",
+			"create/network/success",
+			"create/properties/success",
+		}))
+
+		volumeSpan := spans()[0]
+		Expect(volumeSpan.Handle).To(Equal("some-handle"))
+		Expect(volumeSpan.Start).To(BeTemporally(">=", before))
+		Expect(volumeSpan.Duration).To(BeNumerically(">=", 10*time.Millisecond))
+		Expect(volumeSpan.Error).To(BeEmpty())
+	})
+
+	It("records a span for each stage of a destroy", func() {
+		containerizer.HandlesReturns([]string{"some-handle"}, nil)
+		Expect(gdnr.Destroy("some-handle")).To(Succeed())
+
+		Expect(stages()).To(Equal([]string{
+			"destroy/container/success",
+			"destroy/network/success",
+			"destroy/volume/success",
+			"destroy/properties/success",
+			"destroy/bundle/success",
+		}))
+	})
+
+	Context("when a stage fails", func() {
+		BeforeEach(func() {
+			networker.NetworkReturns(errors.New("boom"))
+		})
+
+		It("records the failure, followed by the cleanup", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).To(MatchError("boom"))
+
+			Expect(stages()).To(Equal([]string{
+				"create/volume/success",
+				"create/container/success",
+				"create/network/failure",
+				"destroy/container/success",
+				"destroy/network/success",
+				"destroy/volume/success",
+				"destroy/properties/success",
+				"destroy/bundle/success",
+			}))
+			Expect(spans()[2].Error).To(Equal("boom"))
+		})
+	})
+
+	Context("when a stage times out", func() {
+		BeforeEach(func() {
+			gdnr.CreateTimeouts.Container = 10 * time.Millisecond
+			containerizer.CreateStub = func(ctx context.Context, _ lager.Logger, _ gardener.DesiredContainerSpec) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+		})
+
+		It("records the timeout", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).To(HaveOccurred())
+
+			Expect(spans()[1].Stage).To(Equal("container"))
+			Expect(spans()[1].Outcome).To(Equal(gardener.OutcomeTimeout))
+		})
+	})
+
+	Describe("Tracers", func() {
+		It("tells every tracer about each span", func() {
+			other := new(fakes.FakeTracer)
+			tracers := gardener.Tracers{tracer, other}
+
+			span := gardener.Span{Operation: "create", Stage: "volume"}
+			tracers.RecordSpan(span)
+
+			Expect(tracer.RecordSpanArgsForCall(0)).To(Equal(span))
+			Expect(other.RecordSpanArgsForCall(0)).To(Equal(span))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package gardener
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden-shed/rootfs_spec"
+	"code.cloudfoundry.org/lager"
+)
+
+// UncancellableVolumeCreator creates volumes without taking a context, as
+// garden-shed does
+type UncancellableVolumeCreator interface {
+	Create(log lager.Logger, handle string, spec rootfs_spec.Spec) (DesiredImageSpec, error)
+	Destroy(log lager.Logger, handle string) error
+	Metrics(log lager.Logger, handle string, privileged bool) (garden.ContainerDiskStat, error)
+	GC(log lager.Logger) error
+}
+
+// NewContextIgnoringVolumeCreator adapts an UncancellableVolumeCreator to a
+// VolumeCreator. Creates run to completion however long they take, so that
+// the volume can be destroyed safely, but fail if the context is done by then.
+func NewContextIgnoringVolumeCreator(volumeCreator UncancellableVolumeCreator) VolumeCreator {
+	return &contextIgnoringVolumeCreator{volumeCreator}
+}
+
+type contextIgnoringVolumeCreator struct {
+	UncancellableVolumeCreator
+}
+
+func (v *contextIgnoringVolumeCreator) Create(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (DesiredImageSpec, error) {
+	desiredImageSpec, err := v.UncancellableVolumeCreator.Create(log, handle, spec)
+	if err != nil {
+		return DesiredImageSpec{}, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return DesiredImageSpec{}, err
+	}
+
+	return desiredImageSpec, nil
+}
@@ -0,0 +1,62 @@
+package gardener_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/garden-shed/rootfs_spec"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContextIgnoringVolumeCreator", func() {
+	var (
+		uncancellable *fakes.FakeUncancellableVolumeCreator
+		volumeCreator gardener.VolumeCreator
+		logger        *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		uncancellable = new(fakes.FakeUncancellableVolumeCreator)
+		uncancellable.CreateReturns(gardener.DesiredImageSpec{RootFS: "/some/rootfs"}, nil)
+		volumeCreator = gardener.NewContextIgnoringVolumeCreator(uncancellable)
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	It("creates the volume", func() {
+		spec, err := volumeCreator.Create(context.Background(), logger, "some-handle", rootfs_spec.Spec{QuotaSize: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(gardener.DesiredImageSpec{RootFS: "/some/rootfs"}))
+
+		_, handle, rootfsSpec := uncancellable.CreateArgsForCall(0)
+		Expect(handle).To(Equal("some-handle"))
+		Expect(rootfsSpec).To(Equal(rootfs_spec.Spec{QuotaSize: 10}))
+	})
+
+	It("returns the error of the create", func() {
+		uncancellable.CreateReturns(gardener.DesiredImageSpec{}, errors.New("boom"))
+
+		_, err := volumeCreator.Create(context.Background(), logger, "some-handle", rootfs_spec.Spec{})
+		Expect(err).To(MatchError("boom"))
+	})
+
+	Context("when the context is done by the time the create finishes", func() {
+		It("still finishes the create, but fails", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := volumeCreator.Create(ctx, logger, "some-handle", rootfs_spec.Spec{})
+			Expect(err).To(Equal(context.Canceled))
+			Expect(uncancellable.CreateCallCount()).To(Equal(1))
+		})
+	})
+
+	It("destroys volumes", func() {
+		Expect(volumeCreator.Destroy(logger, "some-handle")).To(Succeed())
+		_, handle := uncancellable.DestroyArgsForCall(0)
+		Expect(handle).To(Equal("some-handle"))
+	})
+})
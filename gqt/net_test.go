@@ -1070,6 +1070,38 @@ var _ = Describe("IPTables Binary Flags", func() {
 	})
 })
 
+var _ = Describe("IPv6-only networking", func() {
+	var (
+		client *runner.RunningGarden
+	)
+
+	JustBeforeEach(func() {
+		client = runner.Start(config)
+	})
+
+	Context("when the network pool is an IPv6 range", func() {
+		BeforeEach(func() {
+			config.NetworkPool = "fd00::/120"
+		})
+
+		It("should fail to start the server, pointing at --network-pool-v6", func() {
+			Eventually(client).Should(gexec.Exit(1))
+			Expect(client.Err()).To(gbytes.Say("use --network-pool-v6"))
+		})
+	})
+
+	Context("when containers are only given IPv6 without an IPv6 network pool", func() {
+		BeforeEach(func() {
+			config.NetworkIPv6Only = boolptr(true)
+		})
+
+		It("should fail to start the server", func() {
+			Eventually(client).Should(gexec.Exit(1))
+			Expect(client.Err()).To(gbytes.Say("--network-ipv6-only requires --network-pool-v6"))
+		})
+	})
+})
+
 func externalIP(container garden.Container) string {
 	properties, err := container.Properties()
 	Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,72 @@
+package gqt_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/gqt/runner"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profiles", func() {
+	var (
+		client      *runner.RunningGarden
+		profilesDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		profilesDir, err = ioutil.TempDir("", "profiles")
+		Expect(err).NotTo(HaveOccurred())
+
+		profilesPath := filepath.Join(profilesDir, "profiles.json")
+		Expect(ioutil.WriteFile(profilesPath, []byte(`{"web": {"grace_time": "1h"}}`), 0600)).To(Succeed())
+
+		config.ProfilesFile = profilesPath
+		config.DefaultGraceTime = "5m"
+
+		client = runner.Start(config)
+	})
+
+	AfterEach(func() {
+		Expect(client.DestroyAndStop()).To(Succeed())
+		Expect(os.RemoveAll(profilesDir)).To(Succeed())
+	})
+
+	graceTimeOf := func(container garden.Container) string {
+		value, err := container.Property(gardener.GraceTimeKey)
+		Expect(err).NotTo(HaveOccurred())
+		return value
+	}
+
+	It("gives a container the grace time of its profile when it asks for none", func() {
+		container, err := client.Create(garden.ContainerSpec{
+			Properties: garden.Properties{gardener.ProfileKey: "web"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(graceTimeOf(container)).To(Equal("3600000000000"))
+	})
+
+	It("gives a container the grace time it asks for over that of its profile", func() {
+		container, err := client.Create(garden.ContainerSpec{
+			Properties: garden.Properties{gardener.ProfileKey: "web"},
+			GraceTime:  time.Minute,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(graceTimeOf(container)).To(Equal("60000000000"))
+	})
+
+	It("gives a container without a profile the default grace time", func() {
+		container, err := client.Create(garden.ContainerSpec{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(graceTimeOf(container)).To(Equal("300000000000"))
+	})
+})
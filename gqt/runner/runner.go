@@ -83,6 +83,9 @@ type GdnRunnerConfig struct {
 	AppArmor                       string   `flag:"apparmor"`
 	Tag                            string   `flag:"tag"`
 	NetworkPool                    string   `flag:"network-pool"`
+	NetworkIPv6Only                *bool    `flag:"network-ipv6-only"`
+	DefaultGraceTime               string   `flag:"default-grace-time"`
+	ProfilesFile                   string   `flag:"profiles-file"`
 }
 
 func (c GdnRunnerConfig) connectionInfo() (string, string) {
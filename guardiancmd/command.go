@@ -2,18 +2,22 @@ package guardiancmd
 
 import (
 	"errors"
+	"expvar"
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/idmapper"
 	"code.cloudfoundry.org/lager"
 
 	"code.cloudfoundry.org/garden/server"
+	"code.cloudfoundry.org/guardian/admission"
 	"code.cloudfoundry.org/guardian/bindata"
 	"code.cloudfoundry.org/guardian/gardener"
 	"code.cloudfoundry.org/guardian/imageplugin"
@@ -23,6 +27,7 @@ import (
 	"code.cloudfoundry.org/guardian/kawasaki/mtu"
 	"code.cloudfoundry.org/guardian/kawasaki/ports"
 	"code.cloudfoundry.org/guardian/kawasaki/subnets"
+	"code.cloudfoundry.org/guardian/kawasaki/tc"
 	"code.cloudfoundry.org/guardian/logging"
 	"code.cloudfoundry.org/guardian/metrics"
 	"code.cloudfoundry.org/guardian/netplugin"
@@ -143,6 +148,7 @@ type ServerCommand struct {
 	Containers struct {
 		Dir                      string `long:"depot" default:"/var/run/gdn/depot" description:"Directory in which to store container data."`
 		PropertiesPath           string `long:"properties-path" description:"Path in which to store properties."`
+		PropertiesSnapshotAfter  int    `long:"properties-snapshot-after" default:"1000" description:"Number of property changes to journal beside the properties before compacting them into a new snapshot, or 0 to only do so on shutdown."`
 		ConsoleSocketsPath       string `long:"console-sockets-path" description:"Path in which to store temporary sockets"`
 		CleanupProcessDirsOnWait bool   `long:"cleanup-process-dirs-on-wait" description:"Clean up proccess dirs on first invocation of wait"`
 
@@ -152,9 +158,24 @@ type ServerCommand struct {
 		GIDMapLength uint32 `long:"gid-map-length" description:"(rootless only) The number of numerical subordinate group IDs the user is allowed to map"`
 
 		DefaultRootFS              string        `long:"default-rootfs"     description:"Default rootfs to use when not specified on container creation."`
-		DefaultGraceTime           time.Duration `long:"default-grace-time" description:"Default time after which idle containers should expire."`
+		DefaultGraceTime           time.Duration `long:"default-grace-time" description:"Default time after which idle containers should expire, for containers which ask for none and whose profile gives none."`
 		DestroyContainersOnStartup bool          `long:"destroy-containers-on-startup" description:"Clean up all the existing containers on startup."`
 		ApparmorProfile            string        `long:"apparmor" description:"Apparmor profile to use for unprivileged container processes"`
+
+		OrphanSweepInterval time.Duration `long:"orphan-sweep-interval" default:"10m" description:"Interval on which to look for host resources leaked by failed creates and destroys, or 0 to disable."`
+		OrphanGracePeriod   time.Duration `long:"orphan-grace-period"   default:"5m"  description:"Time for which a host resource must have been orphaned before it is removed."`
+		OrphanSweepDryRun   bool          `long:"orphan-sweep-dry-run" description:"Log the orphaned host resources which would be removed without removing them."`
+
+		BulkWorkers int           `long:"bulk-workers" default:"16"  description:"Maximum number of containers to gather info or metrics for at once in bulk requests."`
+		BulkTimeout time.Duration `long:"bulk-timeout" default:"30s" description:"Time to wait for each container in bulk info and metrics requests before reporting an error for it, or 0 to wait indefinitely."`
+
+		VolumeCreateTimeout    time.Duration `long:"volume-create-timeout"    description:"Time to allow for creating the rootfs of a container before failing the create and cleaning up, or 0 to wait indefinitely."`
+		ContainerCreateTimeout time.Duration `long:"container-create-timeout" description:"Time to allow for runc to create a container before failing the create and cleaning up, or 0 to wait indefinitely."`
+		NetworkTimeout         time.Duration `long:"network-timeout"          description:"Time to allow for networking a container before failing the create and cleaning up, or 0 to wait indefinitely."`
+
+		ProfilesFile FileFlag `long:"profiles-file" description:"Path to a JSON file with named profiles of defaults for container specs, which clients pick with the garden.profile property."`
+
+		ReservedPropertyPrefixes []string `long:"reserved-property-prefix" description:"Prefix of container properties which clients may read but not change, as well as garden. and kawasaki. Can be specified multiple times."`
 	} `group:"Container Lifecycle"`
 
 	Bin struct {
@@ -167,6 +188,10 @@ type ServerCommand struct {
 		Init            FileFlag `long:"init-bin"       description:"Path execute as pid 1 inside each container."`
 		Newuidmap       string   `long:"newuidmap-bin"  default:"newuidmap" description:"Path to the 'newuidmap' binary."`
 		Newgidmap       string   `long:"newgidmap-bin"  default:"newgidmap" description:"Path to the 'newgidmap' binary."`
+		TC              string   `long:"tc-bin"         default:"tc" description:"Path to the 'tc' binary, used to limit container bandwidth."`
+
+		IP6Tables        FileFlag `long:"ip6tables-bin"          default:"/sbin/ip6tables" description:"path to the ip6tables binary, used when --network-pool-v6 is set"`
+		IP6TablesRestore FileFlag `long:"ip6tables-restore-bin"  default:"/sbin/ip6tables-restore" description:"path to the ip6tables-restore binary, used when --network-pool-v6 is set"`
 	} `group:"Binary Tools"`
 
 	Runtime struct {
@@ -193,7 +218,11 @@ type ServerCommand struct {
 	} `group:"Docker Image Fetching"`
 
 	Network struct {
-		Pool CIDRFlag `long:"network-pool" default:"10.254.0.0/22" description:"Network range to use for dynamically allocated container subnets."`
+		Pool CIDRFlag `long:"network-pool" default:"10.254.0.0/22" description:"IPv4 network range to use for dynamically allocated container subnets."`
+
+		PoolV6       CIDRFlag `long:"network-pool-v6" description:"IPv6 network range to use for dynamically allocated container subnets. When set, each container is given an IPv6 address alongside its IPv4 one. Containers whose network spec names only an IPv6 network are given only an IPv6 address."`
+		ExternalIPv6 IPFlag   `long:"external-ipv6"   description:"IPv6 address to use to reach container's mapped ports. Ports are only mapped over IPv6 when set."`
+		IPv6Only     bool     `long:"network-ipv6-only" description:"Give every container only an IPv6 address from --network-pool-v6, and refuse network specs naming an IPv4 network."`
 
 		AllowHostAccess bool       `long:"allow-host-access" description:"Allow network access to the host machine."`
 		DenyNetworks    []CIDRFlag `long:"deny-network"      description:"Network ranges to which traffic from containers will be denied. Can be specified multiple times."`
@@ -213,22 +242,56 @@ type ServerCommand struct {
 		PluginExtraArgs []string `long:"network-plugin-extra-arg" description:"Extra argument to pass to the network plugin. Can be specified multiple times."`
 	} `group:"Container Networking"`
 
+	Admission struct {
+		PolicyFile FileFlag `long:"admission-policy-file" description:"Path to a JSON file with the policy container specs must comply with to be created."`
+
+		Plugin          FileFlag `long:"admission-plugin"           description:"Path to admission plugin binary, which is given each container spec before the container is created."`
+		PluginExtraArgs []string `long:"admission-plugin-extra-arg" description:"Extra argument to pass to the admission plugin. Can be specified multiple times."`
+	} `group:"Admission Control"`
+
 	Limits struct {
 		CpuQuotaPerShare     uint64 `long:"cpu-quota-per-share" default:"0" description:"Maximum number of microseconds each cpu share assigned to a container allows per quota period"`
 		TCPMemoryLimit       uint64 `long:"tcp-memory-limit" default:"0" description:"Set hard limit for the tcp buf memory, value in bytes"`
 		DefaultBlockIOWeight uint16 `long:"default-container-blockio-weight" default:"0" description:"Default block IO weight assigned to a container"`
 		MaxContainers        uint64 `long:"max-containers" default:"0" description:"Maximum number of containers that can be created."`
+
+		ReservedMemory        uint64  `long:"reserved-memory"         default:"0" description:"Memory in bytes to hold back for the host when reporting the capacity available to containers."`
+		ReservedDisk          uint64  `long:"reserved-disk"           default:"0" description:"Disk in bytes to hold back for the host when reporting the capacity available to containers."`
+		MemoryOvercommitRatio float64 `long:"memory-overcommit-ratio" default:"0" description:"Multiple of the unreserved host memory which the memory limits of all containers may add up to, or 0 to allow any amount."`
+
+		QuotaProperty string   `long:"quota-property" description:"Container property naming the tenant a container belongs to, for enforcing per-tenant quotas. When set, containers must be created with this property, and it cannot be changed after."`
+		QuotaFile     FileFlag `long:"quota-file"     description:"Path to a JSON file with the default quota and the quota of each tenant. Containers of a tenant whose quota caps memory or cpu shares must be created with a limit on them."`
 	} `group:"Limits"`
 
 	Metrics struct {
 		EmissionInterval time.Duration `long:"metrics-emission-interval" default:"1m" description:"Interval on which to emit metrics."`
 
+		Sinks []string `long:"metrics-sink" default:"dropsonde" choice:"dropsonde" choice:"statsd" choice:"json-lines" description:"Where to emit metrics to. Can be specified multiple times."`
+
 		DropsondeOrigin      string `long:"dropsonde-origin"      default:"garden-linux"   description:"Origin identifier for Dropsonde-emitted metrics."`
 		DropsondeDestination string `long:"dropsonde-destination" default:"127.0.0.1:3457" description:"Destination for Dropsonde-emitted metrics."`
+
+		StatsdAddress string `long:"statsd-address" default:"127.0.0.1:8125" description:"Address of the statsd server to emit metrics to over UDP, with the statsd sink."`
+		StatsdPrefix  string `long:"statsd-prefix"  default:"garden"         description:"Prefix for the names of metrics emitted to statsd."`
+
+		JSONLinesFile string `long:"metrics-file" description:"Path to a file to append metrics to as lines of JSON, with the json-lines sink."`
+
+		EmitContainerMetrics bool     `long:"emit-container-metrics" description:"Emit the CPU, memory and disk usage of every container on the emission interval. Not sent to the dropsonde sink, whose metrics have no tags."`
+		TagProperties        []string `long:"metrics-tag-property"   description:"Container property to tag the emitted per-container metrics with. Can be specified multiple times."`
+
+		TraceFile string `long:"trace-file" description:"Path to a file to write the stages of each create and destroy to, in the Trace Event Format. The file is replaced on startup."`
+
+		PrometheusLabelProperties []string `long:"prometheus-label-property" description:"Container property to label the per-container metrics served to Prometheus with. Can be specified multiple times. Properties whose label name is handle, or that of an earlier property, are skipped."`
 	} `group:"Metrics"`
 
+	Drain struct {
+		OnShutdown     bool          `long:"drain-on-shutdown" description:"Drain before shutting down when signalled to stop, as when a drain is started from the debug server."`
+		Timeout        time.Duration `long:"drain-timeout" default:"1m" description:"Time to wait for the operations in flight to finish when draining."`
+		StopContainers bool          `long:"drain-stop-containers" description:"Stop all containers once draining has waited for the operations in flight."`
+	} `group:"Draining"`
+
 	Runc struct {
-		Root string `hidden:"true" long:"runc-root" default:"" description:"root directory for storage of container state (this should be located in tmpfs)"`
+		Root string `hidden:"true" long:"runc-root" default:"" description:"root directory for storage of container state (this should be located in tmpfs). Runc containers not known to gdn are only swept as orphans when this is set, as the default root is shared with any other user of runc on the host."`
 	} `group:"Runc Arguments"`
 }
 
@@ -354,13 +417,20 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 		}
 	}
 
-	networker, iptablesStarter, err := cmd.wireNetworker(logger, cmd.Containers.Dir, propManager, portPool)
+	networker, iptablesStarters, err := cmd.wireNetworker(logger, cmd.Containers.Dir, propManager, portPool)
 	if err != nil {
 		logger.Error("failed-to-wire-networker", err)
 		return err
 	}
 
-	restorer := gardener.NewRestorer(networker)
+	eventBus := gardener.NewEventBus(propManager, clock.NewClock())
+
+	containerizer := cmd.wireContainerizer(logger,
+		cmd.Containers.Dir, cmd.Bin.Dadoo.Path(), cmd.Runtime.Plugin,
+		cmd.Bin.NSTar.Path(), cmd.Bin.Tar.Path(),
+		cmd.Containers.ApparmorProfile, cmd.Bin.Newuidmap, cmd.Bin.Newgidmap, propManager, eventBus)
+
+	restorer := gardener.NewRestorer(networker, containerizer)
 	if cmd.Containers.DestroyContainersOnStartup {
 		restorer = &gardener.NoopRestorer{}
 	}
@@ -373,27 +443,72 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 		starters = append(starters, cmd.wireCgroupsStarter(logger))
 	}
 	if cmd.Network.Plugin.Path() == "" {
-		starters = append(starters, iptablesStarter)
+		starters = append(starters, iptablesStarters...)
 	}
 
 	var bulkStarter gardener.BulkStarter = gardener.NewBulkStarter(starters)
 
+	orphanSources := cmd.wireOrphanSources(logger, containerizer, volumeCreator, propManager)
+
+	profiles, err := cmd.wireProfiles()
+	if err != nil {
+		logger.Error("failed-to-wire-profiles", err)
+		return err
+	}
+
+	quotas, err := cmd.wireQuotas(propManager)
+	if err != nil {
+		logger.Error("failed-to-wire-quotas", err)
+		return err
+	}
+
+	admitter, err := cmd.wireAdmitter()
+	if err != nil {
+		logger.Error("failed-to-wire-admitter", err)
+		return err
+	}
+
+	stageHistograms, tracer, err := cmd.wireTracer(logger)
+	if err != nil {
+		logger.Error("failed-to-wire-tracer", err)
+		return err
+	}
+
 	backend := &gardener.Gardener{
 		UidGenerator:    cmd.wireUidGenerator(),
 		BulkStarter:     bulkStarter,
 		SysInfoProvider: sysinfo.NewResourcesProvider(cmd.Containers.Dir),
 		Networker:       networker,
 		VolumeCreator:   volumeCreator,
-		Containerizer: cmd.wireContainerizer(logger,
-			cmd.Containers.Dir, cmd.Bin.Dadoo.Path(), cmd.Runtime.Plugin,
-			cmd.Bin.NSTar.Path(), cmd.Bin.Tar.Path(),
-			cmd.Containers.ApparmorProfile, cmd.Bin.Newuidmap, cmd.Bin.Newgidmap, propManager),
+		Containerizer:   containerizer,
 		PropertyManager: propManager,
 		MaxContainers:   cmd.Limits.MaxContainers,
+		ReservedMemory:  cmd.Limits.ReservedMemory,
+		ReservedDisk:    cmd.Limits.ReservedDisk,
 		Restorer:        restorer,
+		OrphanSources:   orphanSources,
+		Profiles:        profiles,
+		Quotas:          quotas,
+		Admitter:        admitter,
+		EventBus:        eventBus,
+		Tracer:          tracer,
+		BulkWorkers:     cmd.Containers.BulkWorkers,
+		BulkTimeout:     cmd.Containers.BulkTimeout,
+
+		CreateTimeouts: gardener.CreateTimeouts{
+			Volume:    cmd.Containers.VolumeCreateTimeout,
+			Container: cmd.Containers.ContainerCreateTimeout,
+			Network:   cmd.Containers.NetworkTimeout,
+		},
+
+		MemoryOvercommitRatio:    cmd.Limits.MemoryOvercommitRatio,
+		ReservedPropertyPrefixes: cmd.Containers.ReservedPropertyPrefixes,
+		DefaultGraceTime:         cmd.Containers.DefaultGraceTime,
 
 		Logger: logger,
 	}
+	// the reaper destroys idle containers through the gardener, so that the usual cleanup happens
+	backend.Reaper = gardener.NewReaper(logger, clock.NewClock(), propManager, backend)
 
 	var listenNetwork, listenAddr string
 	if cmd.Server.BindIP != nil {
@@ -404,9 +519,15 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 		listenAddr = cmd.Server.BindSocket
 	}
 
-	gardenServer := server.New(listenNetwork, listenAddr, cmd.Containers.DefaultGraceTime, backend, logger.Session("api"))
+	apiMetrics := metrics.NewAPIMetrics()
+	// the gardener applies the default grace time, after any profile
+	gardenServer := server.New(listenNetwork, listenAddr, 0, metrics.NewInstrumentedBackend(backend, apiMetrics), logger.Session("api"))
 
-	cmd.initializeDropsonde(logger)
+	metricsSink, err := cmd.wireMetricsSink(logger)
+	if err != nil {
+		logger.Error("failed-to-wire-metrics-sink", err)
+		return err
+	}
 
 	metricsProvider := cmd.wireMetricsProvider(logger, cmd.Containers.Dir, cmd.Graph.Dir)
 
@@ -418,21 +539,71 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 		"depotDirs":     metricsProvider.DepotDirs,
 	}
 
-	periodicMetronMetrics := map[string]func() int{
+	periodicMetrics := map[string]func() int{
 		"DepotDirs": metricsProvider.DepotDirs,
 	}
 
 	if cmd.Image.Plugin == "" && cmd.Image.PrivilegedPlugin == "" {
-		periodicMetronMetrics["LoopDevices"] = metricsProvider.LoopDevices
-		periodicMetronMetrics["BackingStores"] = metricsProvider.BackingStores
+		periodicMetrics["LoopDevices"] = metricsProvider.LoopDevices
+		periodicMetrics["BackingStores"] = metricsProvider.BackingStores
 	}
 
-	metronNotifier := cmd.wireMetronNotifier(logger, periodicMetronMetrics)
-	metronNotifier.Start()
+	var sweeper *gardener.Sweeper
+	if cmd.Containers.OrphanSweepInterval > 0 {
+		sweeper = gardener.NewSweeper(
+			logger, orphanSources, containerizer, propManager, clock.NewClock(),
+			cmd.Containers.OrphanSweepInterval, cmd.Containers.OrphanGracePeriod, cmd.Containers.OrphanSweepDryRun,
+		)
+		sweeper.Start()
+
+		debugServerMetrics["orphansRemoved"] = sweeper.OrphansRemoved
+		debugServerMetrics["orphansPending"] = sweeper.OrphansPending
+		debugServerMetrics["orphanSweepFailures"] = sweeper.SweepFailures
+		periodicMetrics["OrphansRemoved"] = sweeper.OrphansRemoved
+		periodicMetrics["OrphansPending"] = sweeper.OrphansPending
+	}
+
+	notifier := cmd.wirePeriodicNotifier(logger, periodicMetrics, metricsSink)
+	notifier.Start()
+
+	if cmd.Metrics.EmitContainerMetrics {
+		containerNotifier := metrics.NewPeriodicContainerNotifier(
+			logger, backend, metricsSink, cmd.Metrics.TagProperties, cmd.Metrics.EmissionInterval, clock.NewClock(),
+		)
+		containerNotifier.Start()
+	}
+
+	// a drain can be started from the debug server as well as by a signal
+	drainRequested := make(chan struct{})
+	var drainOnce sync.Once
+	requestDrain := func() {
+		drainOnce.Do(func() { close(drainRequested) })
+	}
 
 	if cmd.Server.DebugBindIP != nil {
 		addr := fmt.Sprintf("%s:%d", cmd.Server.DebugBindIP.IP(), cmd.Server.DebugBindPort)
-		metrics.StartDebugServer(addr, reconfigurableSink, debugServerMetrics)
+		expvar.Publish("reconciliation", expvar.Func(func() interface{} {
+			return backend.ReconcileReport()
+		}))
+		debugHandlers := map[string]http.Handler{
+			"/events": gardener.NewEventStreamHandler(eventBus, logger),
+			"/drain":  gardener.NewDrainHandler(backend, requestDrain),
+			"/pause":  gardener.NewPauseHandler(backend, false),
+			"/resume": gardener.NewPauseHandler(backend, true),
+			"/stages": stageHistograms,
+			"/metrics": metrics.NewPrometheusHandler(
+				metrics.Metrics(debugServerMetrics),
+				apiMetrics,
+				stageHistograms,
+				metrics.NewContainerGauges(logger, backend, cmd.Metrics.PrometheusLabelProperties),
+			),
+		}
+		debugHandlers["/properties/watch"] = properties.NewWatchHandler(propManager, logger)
+		debugHandlers["/properties/apply"] = gardener.NewApplyPropertiesHandler(backend)
+		if quotas != nil {
+			debugHandlers["/quotas"] = quotas
+		}
+		metrics.StartDebugServer(addr, reconfigurableSink, debugServerMetrics, debugHandlers)
 	}
 
 	err = gardenServer.Start()
@@ -448,7 +619,23 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 		"addr":    listenAddr,
 	})
 
-	<-signals
+	drain := false
+	select {
+	case <-signals:
+		drain = cmd.Drain.OnShutdown
+	case <-drainRequested:
+		drain = true
+	}
+
+	if sweeper != nil {
+		sweeper.Stop()
+	}
+
+	if drain {
+		if err := backend.Drain(cmd.Drain.Timeout, cmd.Drain.StopContainers); err != nil {
+			logger.Error("failed-to-drain", err)
+		}
+	}
 
 	gardenServer.Stop()
 
@@ -461,7 +648,11 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 }
 
 func (cmd *ServerCommand) loadProperties(logger lager.Logger, propertiesPath string) (*properties.Manager, error) {
-	propManager, err := properties.Load(propertiesPath)
+	if propertiesPath == "" {
+		return properties.NewManager(), nil
+	}
+
+	propManager, err := properties.LoadJournaled(logger, propertiesPath, cmd.Containers.PropertiesSnapshotAfter)
 	if err != nil {
 		logger.Error("failed-to-load-properties", err, lager.Data{"propertiesPath": propertiesPath})
 		return &properties.Manager{}, err
@@ -472,7 +663,8 @@ func (cmd *ServerCommand) loadProperties(logger lager.Logger, propertiesPath str
 
 func (cmd *ServerCommand) saveProperties(logger lager.Logger, propertiesPath string, propManager *properties.Manager) {
 	if propertiesPath != "" {
-		err := properties.Save(propertiesPath, propManager)
+		// every change is already journaled, so this only compacts them into a snapshot
+		err := propManager.Close()
 		if err != nil {
 			logger.Error("failed-to-save-properties", err, lager.Data{"propertiesPath": propertiesPath})
 		}
@@ -491,10 +683,43 @@ func extractIPs(ipflags []IPFlag) []net.IP {
 	return ips
 }
 
-func (cmd *ServerCommand) wireNetworker(log lager.Logger, depotPath string, propManager kawasaki.ConfigStore, portPool *ports.PortPool) (gardener.Networker, gardener.Starter, error) {
-	externalIP, err := defaultExternalIP(cmd.Network.ExternalIP)
-	if err != nil {
-		return nil, nil, err
+// checkIPVersions refuses to start when an address or range is given in the
+// flag for the other IP version. IPv6 addresses come from --network-pool-v6,
+// alongside an IPv4 one or, with --network-ipv6-only, instead of it.
+func (cmd *ServerCommand) checkIPVersions(externalIP net.IP) error {
+	if cmd.Network.Pool.CIDR().IP.To4() == nil {
+		return fmt.Errorf("--network-pool %s is not an IPv4 range: use --network-pool-v6 to give containers an IPv6 address", cmd.Network.Pool.String())
+	}
+
+	if cmd.Network.IPv6Only && cmd.Network.PoolV6.CIDR() == nil {
+		return errors.New("--network-ipv6-only requires --network-pool-v6")
+	}
+
+	if externalIP != nil && externalIP.To4() == nil {
+		return fmt.Errorf("--external-ip %s is not an IPv4 address: use --external-ipv6 to map ports over IPv6", externalIP)
+	}
+
+	if cmd.Network.PoolV6.CIDR() != nil && cmd.Network.PoolV6.CIDR().IP.To4() != nil {
+		return fmt.Errorf("--network-pool-v6 %s is not an IPv6 range", cmd.Network.PoolV6.String())
+	}
+
+	if cmd.Network.ExternalIPv6.IP() != nil && cmd.Network.ExternalIPv6.IP().To4() != nil {
+		return fmt.Errorf("--external-ipv6 %s is not an IPv6 address", cmd.Network.ExternalIPv6.IP())
+	}
+
+	return nil
+}
+
+func (cmd *ServerCommand) wireNetworker(log lager.Logger, depotPath string, propManager kawasaki.ConfigStore, portPool *ports.PortPool) (gardener.Networker, []gardener.Starter, error) {
+	var externalIP net.IP
+	var err error
+	// containers with only IPv6 have no use for an IPv4 external address, but
+	// network plugins are always given one
+	if !cmd.Network.IPv6Only || cmd.Network.ExternalIP != nil || cmd.Network.Plugin.Path() != "" {
+		externalIP, err = defaultExternalIP(cmd.Network.ExternalIP)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	dnsServers := extractIPs(cmd.Network.DNSServers)
@@ -512,11 +737,20 @@ func (cmd *ServerCommand) wireNetworker(log lager.Logger, depotPath string, prop
 			cmd.Network.Plugin.Path(),
 			cmd.Network.PluginExtraArgs,
 		)
-		return externalNetworker, externalNetworker, nil
+		return externalNetworker, []gardener.Starter{externalNetworker}, nil
 	}
 
-	var denyNetworksList []string
+	if err := cmd.checkIPVersions(externalIP); err != nil {
+		return nil, nil, err
+	}
+
+	// ip6tables is given only the networks it can deny
+	var denyNetworksList, denyNetworksV6List []string
 	for _, network := range cmd.Network.DenyNetworks {
+		if network.CIDR().IP.To4() == nil {
+			denyNetworksV6List = append(denyNetworksV6List, network.String())
+			continue
+		}
 		denyNetworksList = append(denyNetworksList, network.String())
 	}
 
@@ -534,24 +768,159 @@ func (cmd *ServerCommand) wireNetworker(log lager.Logger, depotPath string, prop
 
 	containerMtu := cmd.Network.Mtu
 	if containerMtu == 0 {
-		containerMtu, err = mtu.MTU(externalIP.String())
+		mtuIP := externalIP
+		if mtuIP == nil {
+			mtuIP = cmd.Network.ExternalIPv6.IP()
+		}
+		if mtuIP == nil {
+			return nil, nil, errors.New("--mtu must be given when neither --external-ip nor --external-ipv6 is")
+		}
+
+		containerMtu, err = mtu.MTU(mtuIP.String())
 		if err != nil {
 			return nil, nil, err
 		}
 	}
 
-	networker := kawasaki.New(
+	starters := []gardener.Starter{ipTablesStarter}
+
+	var ipv6 kawasaki.IPv6
+	var ip6Tables *iptables.IPTablesController
+	if cmd.Network.PoolV6.CIDR() != nil {
+		ip6Tables = iptables.NewIPv6(cmd.Bin.IP6Tables.Path(), cmd.Bin.IP6TablesRestore.Path(), iptRunner, locksmith, chainPrefix)
+		nonLoggingIp6Tables := iptables.NewIPv6(cmd.Bin.IP6Tables.Path(), cmd.Bin.IP6TablesRestore.Path(), nonLoggingIptRunner, locksmith, chainPrefix)
+		starters = append(starters, iptables.NewStarter(nonLoggingIp6Tables, cmd.Network.AllowHostAccess, interfacePrefix, denyNetworksV6List, cmd.Containers.DestroyContainersOnStartup, log))
+
+		ipv6 = kawasaki.IPv6{
+			SubnetPool:     subnets.NewPool(cmd.Network.PoolV6.CIDR()),
+			ExternalIP:     cmd.Network.ExternalIPv6.IP(),
+			PortForwarder:  iptables.NewPortForwarder(ip6Tables),
+			FirewallOpener: iptables.NewFirewallOpener(iptables.NewIPv6RuleTranslator(), ip6Tables),
+			Only:           cmd.Network.IPv6Only,
+		}
+	}
+
+	networker := kawasaki.NewDualStack(
 		kawasaki.SpecParserFunc(kawasaki.ParseSpec),
 		subnets.NewPool(cmd.Network.Pool.CIDR()),
 		kawasaki.NewConfigCreator(idGenerator, interfacePrefix, chainPrefix, externalIP, dnsServers, additionalDNSServers, containerMtu),
 		propManager,
-		factory.NewDefaultConfigurer(ipTables, depotPath),
+		factory.NewDefaultConfigurer(ipTables, ip6Tables, depotPath),
 		portPool,
 		iptables.NewPortForwarder(ipTables),
 		iptables.NewFirewallOpener(ruleTranslator, ipTables),
+		tc.NewShaper(cmd.Bin.TC, &logging.Runner{CommandRunner: commandRunner(), Logger: log.Session("tc-runner")}),
+		ipv6,
 	)
 
-	return networker, ipTablesStarter, nil
+	return networker, starters, nil
+}
+
+func (cmd *ServerCommand) wireOrphanSources(log lager.Logger, containerizer *rundmc.Containerizer, volumeCreator gardener.VolumeCreator, propManager kawasaki.ConfigStore) map[string]gardener.OrphanSource {
+	sources := map[string]gardener.OrphanSource{
+		// process dirs are only looked up in the depot, so it needs no bundler
+		"processes": rundmc.NewProcessDirs(wireDepot(cmd.Containers.Dir, nil, nil)),
+	}
+
+	// every runc container in the default root would be taken for an orphan,
+	// including those of other users of runc on the host
+	if cmd.Runc.Root != "" {
+		sources["runtime"] = containerizer
+	}
+
+	// image plugins can list their images, so images left behind by lost
+	// containers are removed; the built-in graph collects its own garbage
+	if images, ok := volumeCreator.(gardener.OrphanSource); ok {
+		sources["images"] = images
+	}
+
+	// an external network plugin owns any host networking it creates
+	if cmd.Network.Plugin.Path() != "" {
+		return sources
+	}
+
+	interfacePrefix := fmt.Sprintf("w%s", cmd.Server.Tag)
+	chainPrefix := fmt.Sprintf("w-%s-", cmd.Server.Tag)
+
+	iptRunner := &logging.Runner{CommandRunner: commandRunner(), Logger: log.Session("iptables-runner")}
+	ipTables := iptables.New(cmd.Bin.IPTables.Path(), cmd.Bin.IPTablesRestore.Path(), iptRunner, &locksmithpkg.FileSystem{}, chainPrefix)
+
+	sources["iptables"] = kawasaki.NewOrphanedChains(ipTables, iptables.NewInstanceChainCreator(ipTables), propManager)
+	if cmd.Network.PoolV6.CIDR() != nil {
+		ip6Tables := iptables.NewIPv6(cmd.Bin.IP6Tables.Path(), cmd.Bin.IP6TablesRestore.Path(), iptRunner, &locksmithpkg.FileSystem{}, chainPrefix)
+		sources["ip6tables"] = kawasaki.NewOrphanedChains(ip6Tables, iptables.NewInstanceChainCreator(ip6Tables), propManager)
+	}
+	sources["bridges"] = factory.NewOrphanedBridges(interfacePrefix, propManager)
+	sources["veths"] = factory.NewOrphanedVeths(interfacePrefix, propManager)
+
+	return sources
+}
+
+func (cmd *ServerCommand) wireProfiles() (gardener.Profiles, error) {
+	if cmd.Containers.ProfilesFile.Path() == "" {
+		return nil, nil
+	}
+
+	return gardener.LoadProfiles(cmd.Containers.ProfilesFile.Path())
+}
+
+func (cmd *ServerCommand) wireQuotas(propManager gardener.PropertyManager) (*gardener.Quotas, error) {
+	if cmd.Limits.QuotaProperty == "" {
+		return nil, nil
+	}
+
+	var config gardener.QuotaConfig
+	if cmd.Limits.QuotaFile.Path() != "" {
+		var err error
+		config, err = gardener.LoadQuotaConfig(cmd.Limits.QuotaFile.Path())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return gardener.NewQuotas(cmd.Limits.QuotaProperty, config, propManager), nil
+}
+
+func (cmd *ServerCommand) wireTracer(log lager.Logger) (*metrics.StageHistograms, gardener.Tracer, error) {
+	stageHistograms := metrics.NewStageHistograms(nil)
+	tracers := gardener.Tracers{stageHistograms}
+
+	if cmd.Metrics.TraceFile != "" {
+		// the trace file is written to for as long as the server runs
+		traceWriter, _, err := metrics.OpenTraceFile(log, cmd.Metrics.TraceFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		tracers = append(tracers, traceWriter)
+	}
+
+	return stageHistograms, tracers, nil
+}
+
+func (cmd *ServerCommand) wireAdmitter() (gardener.Admitter, error) {
+	var admitters admission.Admitters
+
+	if cmd.Admission.PolicyFile.Path() != "" {
+		policy, err := admission.LoadPolicy(cmd.Admission.PolicyFile.Path())
+		if err != nil {
+			return nil, err
+		}
+		admitters = append(admitters, policy)
+	}
+
+	if cmd.Admission.Plugin.Path() != "" {
+		admitters = append(admitters, admission.NewExternalAdmitter(
+			commandRunner(),
+			cmd.Admission.Plugin.Path(),
+			cmd.Admission.PluginExtraArgs,
+		))
+	}
+
+	if len(admitters) == 0 {
+		return nil, nil
+	}
+
+	return admitters, nil
 }
 
 func (cmd *ServerCommand) wireImagePlugin() gardener.VolumeCreator {
@@ -587,7 +956,7 @@ func (cmd *ServerCommand) wireImagePlugin() gardener.VolumeCreator {
 
 func (cmd *ServerCommand) wireContainerizer(log lager.Logger,
 	depotPath, dadooPath, runtimePath, nstarPath, tarPath, appArmorProfile, newuidmapPath, newgidmapPath string,
-	properties gardener.PropertyManager) *rundmc.Containerizer {
+	properties gardener.PropertyManager, events gardener.EventPublisher) *rundmc.Containerizer {
 
 	rwm := "rwm"
 	character := "c"
@@ -667,6 +1036,12 @@ func (cmd *ServerCommand) wireContainerizer(log lager.Logger,
 		CommandRunner: cmdRunner,
 	}
 
+	limitsRule := bundlerules.Limits{
+		CpuQuotaPerShare: cmd.Limits.CpuQuotaPerShare,
+		TCPMemoryLimit:   int64(cmd.Limits.TCPMemoryLimit),
+		BlockIOWeight:    cmd.Limits.DefaultBlockIOWeight,
+	}
+
 	bundleRules := []rundmc.BundlerRule{
 		bundlerules.Base{
 			PrivilegedBase:   privilegedBundle,
@@ -677,11 +1052,7 @@ func (cmd *ServerCommand) wireContainerizer(log lager.Logger,
 			ContainerRootGID: gidMappings.Map(0),
 			MkdirChown:       chrootMkdir,
 		},
-		bundlerules.Limits{
-			CpuQuotaPerShare: cmd.Limits.CpuQuotaPerShare,
-			TCPMemoryLimit:   int64(cmd.Limits.TCPMemoryLimit),
-			BlockIOWeight:    cmd.Limits.DefaultBlockIOWeight,
-		},
+		limitsRule,
 		bundlerules.Mounts{},
 		bundlerules.Env{},
 		bundlerules.Hostname{},
@@ -710,15 +1081,16 @@ func (cmd *ServerCommand) wireContainerizer(log lager.Logger,
 			cmd.wireUidGenerator(),
 			cmdRunner,
 			cmd.Containers.CleanupProcessDirsOnWait,
+			events,
 		),
 	)
 
-	eventStore := rundmc.NewEventStore(properties)
+	eventStore := rundmc.NewEventStore(properties, events)
 	stateStore := rundmc.NewStateStore(properties)
 
 	nstar := rundmc.NewNstarRunner(nstarPath, tarPath, cmdRunner)
 	stopper := stopper.New(stopper.NewRuncStateCgroupPathResolver("/run/runc"), nil, retrier.New(retrier.ConstantBackoff(10, 1*time.Second), nil))
-	return rundmc.New(depot, runcrunner, &goci.BndlLoader{}, nstar, stopper, eventStore, stateStore, &preparerootfs.SymlinkRefusingFileCreator{})
+	return rundmc.New(depot, runcrunner, &goci.BndlLoader{}, nstar, stopper, eventStore, stateStore, &preparerootfs.SymlinkRefusingFileCreator{}, &goci.BundleSaver{}, limitsRule)
 }
 
 func (cmd *ServerCommand) wireMetricsProvider(log lager.Logger, depotPath, graphRoot string) *metrics.MetricsProvider {
@@ -730,12 +1102,42 @@ func (cmd *ServerCommand) wireMetricsProvider(log lager.Logger, depotPath, graph
 	return metrics.NewMetricsProvider(log, backingStoresPath, depotPath)
 }
 
-func (cmd *ServerCommand) wireMetronNotifier(log lager.Logger, metricsProvider metrics.Metrics) *metrics.PeriodicMetronNotifier {
-	return metrics.NewPeriodicMetronNotifier(
-		log, metricsProvider, cmd.Metrics.EmissionInterval, clock.NewClock(),
+func (cmd *ServerCommand) wirePeriodicNotifier(log lager.Logger, metricsProvider metrics.Metrics, sink metrics.Sink) *metrics.PeriodicNotifier {
+	return metrics.NewPeriodicNotifier(
+		log, metricsProvider, sink, cmd.Metrics.EmissionInterval, clock.NewClock(),
 	)
 }
 
+func (cmd *ServerCommand) wireMetricsSink(log lager.Logger) (metrics.Sink, error) {
+	var sinks metrics.Sinks
+
+	for _, name := range cmd.Metrics.Sinks {
+		switch name {
+		case "dropsonde":
+			cmd.initializeDropsonde(log)
+			sinks = append(sinks, metrics.DropsondeSink{})
+		case "statsd":
+			sink, err := metrics.NewStatsdSink(cmd.Metrics.StatsdAddress, cmd.Metrics.StatsdPrefix)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case "json-lines":
+			if cmd.Metrics.JSONLinesFile == "" {
+				return nil, errors.New("--metrics-file is required by the json-lines metrics sink")
+			}
+			// the file is appended to for as long as the server runs
+			sink, _, err := metrics.OpenJSONLinesFile(cmd.Metrics.JSONLinesFile, clock.NewClock())
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		}
+	}
+
+	return sinks, nil
+}
+
 func (cmd *ServerCommand) initializeDropsonde(log lager.Logger) {
 	err := dropsonde.Initialize(cmd.Metrics.DropsondeDestination, cmd.Metrics.DropsondeOrigin)
 	if err != nil {
@@ -185,14 +185,14 @@ func (cmd *ServerCommand) wireVolumeCreator(logger lager.Logger, graphRoot strin
 		},
 	}
 
-	return rootfs_provider.NewCakeOrdinator(cake,
+	return gardener.NewContextIgnoringVolumeCreator(rootfs_provider.NewCakeOrdinator(cake,
 		repoFetcher,
 		layerCreator,
 		rootfs_provider.NewMetricsAdapter(quotaManager.GetUsage, quotaedGraphDriver.GetMntPath),
-		ovenCleaner)
+		ovenCleaner))
 }
 
-func (cmd *ServerCommand) wireExecRunner(dadooPath, runcPath, runcRoot string, processIDGen runrunc.UidGenerator, commandRunner commandrunner.CommandRunner, shouldCleanup bool) *dadoo.ExecRunner {
+func (cmd *ServerCommand) wireExecRunner(dadooPath, runcPath, runcRoot string, processIDGen runrunc.UidGenerator, commandRunner commandrunner.CommandRunner, shouldCleanup bool, events gardener.EventPublisher) *dadoo.ExecRunner {
 
 	pidFileReader := &dadoo.PidFileReader{
 		Clock:         clock.NewClock(),
@@ -208,6 +208,7 @@ func (cmd *ServerCommand) wireExecRunner(dadooPath, runcPath, runcRoot string, p
 		pidFileReader,
 		commandRunner,
 		shouldCleanup,
+		events,
 	)
 }
 
@@ -1,6 +1,7 @@
 package imageplugin
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strconv"
@@ -18,7 +19,7 @@ type DefaultCommandCreator struct {
 	ExtraArgs []string
 }
 
-func (cc *DefaultCommandCreator) CreateCommand(log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error) {
+func (cc *DefaultCommandCreator) CreateCommand(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error) {
 	args := append(cc.ExtraArgs, "create")
 
 	if spec.QuotaSize > 0 {
@@ -40,7 +41,7 @@ func (cc *DefaultCommandCreator) CreateCommand(log lager.Logger, handle string,
 	rootfs := strings.Replace(spec.RootFS.String(), "#", ":", 1)
 
 	args = append(args, rootfs, handle)
-	return exec.Command(cc.BinPath, args...), nil
+	return exec.CommandContext(ctx, cc.BinPath, args...), nil
 }
 
 func (cc *DefaultCommandCreator) DestroyCommand(log lager.Logger, handle string) *exec.Cmd {
@@ -51,6 +52,10 @@ func (cc *DefaultCommandCreator) MetricsCommand(log lager.Logger, handle string)
 	return exec.Command(cc.BinPath, append(cc.ExtraArgs, "stats", handle)...)
 }
 
+func (cc *DefaultCommandCreator) ListCommand(log lager.Logger) *exec.Cmd {
+	return exec.Command(cc.BinPath, append(cc.ExtraArgs, "list")...)
+}
+
 func stringifyMapping(mapping specs.LinuxIDMapping) string {
 	return fmt.Sprintf("%d:%d:%d", mapping.ContainerID, mapping.HostID, mapping.Size)
 }
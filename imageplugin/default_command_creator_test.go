@@ -1,6 +1,7 @@
 package imageplugin_test
 
 import (
+	"context"
 	"net/url"
 	"os/exec"
 
@@ -32,11 +33,13 @@ var _ = Describe("DefaultCommandCreator", func() {
 
 	Describe("CreateCommand", func() {
 		var (
+			ctx       context.Context
 			createCmd *exec.Cmd
 			spec      rootfs_spec.Spec
 		)
 
 		BeforeEach(func() {
+			ctx = context.Background()
 			rootfsURL, err := url.Parse("/fake-registry/image")
 			Expect(err).NotTo(HaveOccurred())
 			spec = rootfs_spec.Spec{RootFS: rootfsURL}
@@ -44,7 +47,7 @@ var _ = Describe("DefaultCommandCreator", func() {
 
 		JustBeforeEach(func() {
 			var err error
-			createCmd, err = commandCreator.CreateCommand(nil, "test-handle", spec)
+			createCmd, err = commandCreator.CreateCommand(ctx, nil, "test-handle", spec)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -52,6 +55,18 @@ var _ = Describe("DefaultCommandCreator", func() {
 			Expect(createCmd.Path).To(Equal(binPath))
 		})
 
+		Context("when the context is done", func() {
+			BeforeEach(func() {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(context.Background())
+				cancel()
+			})
+
+			It("returns a command which does not start", func() {
+				Expect(createCmd.Start()).To(Equal(context.Canceled))
+			})
+		})
+
 		It("returns a command with the create action", func() {
 			Expect(createCmd.Args[1]).To(Equal("create"))
 		})
@@ -251,4 +266,32 @@ var _ = Describe("DefaultCommandCreator", func() {
 			Expect(metricsCmd.SysProcAttr).To(BeNil())
 		})
 	})
+
+	Describe("ListCommand", func() {
+		var (
+			listCmd *exec.Cmd
+		)
+
+		JustBeforeEach(func() {
+			listCmd = commandCreator.ListCommand(nil)
+		})
+
+		It("returns a command with the correct image plugin path", func() {
+			Expect(listCmd.Path).To(Equal(binPath))
+		})
+
+		It("returns a command with the list action", func() {
+			Expect(listCmd.Args[1:]).To(Equal([]string{"list"}))
+		})
+
+		Context("when extra args are provided", func() {
+			BeforeEach(func() {
+				extraArgs = []string{"foo", "bar"}
+			})
+
+			It("returns a command with the extra args as global args preceeding the action", func() {
+				Expect(listCmd.Args[1:]).To(Equal([]string{"foo", "bar", "list"}))
+			})
+		})
+	})
 })
@@ -2,9 +2,11 @@ package imageplugin
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/url"
 	"os/exec"
+	"strings"
 
 	"code.cloudfoundry.org/commandrunner"
 	"code.cloudfoundry.org/garden"
@@ -17,9 +19,14 @@ import (
 
 //go:generate counterfeiter . CommandCreator
 type CommandCreator interface {
-	CreateCommand(log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error)
+	CreateCommand(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error)
 	DestroyCommand(log lager.Logger, handle string) *exec.Cmd
 	MetricsCommand(log lager.Logger, handle string) *exec.Cmd
+
+	// ListCommand returns the command which lists the images the plugin
+	// holds, or nil when the plugin has no such command. See Orphans for
+	// the protocol the command follows.
+	ListCommand(log lager.Logger) *exec.Cmd
 }
 
 type ImagePlugin struct {
@@ -29,7 +36,7 @@ type ImagePlugin struct {
 	DefaultRootfs              string
 }
 
-func (p *ImagePlugin) Create(log lager.Logger, handle string, spec rootfs_spec.Spec) (gardener.DesiredImageSpec, error) {
+func (p *ImagePlugin) Create(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (gardener.DesiredImageSpec, error) {
 	log = log.Session("image-plugin-create", lager.Data{"handle": handle, "spec": spec})
 	log.Debug("start")
 	defer log.Debug("end")
@@ -49,9 +56,9 @@ func (p *ImagePlugin) Create(log lager.Logger, handle string, spec rootfs_spec.S
 		err       error
 	)
 	if spec.Namespaced {
-		createCmd, err = p.UnprivilegedCommandCreator.CreateCommand(log, handle, spec)
+		createCmd, err = p.UnprivilegedCommandCreator.CreateCommand(ctx, log, handle, spec)
 	} else {
-		createCmd, err = p.PrivilegedCommandCreator.CreateCommand(log, handle, spec)
+		createCmd, err = p.PrivilegedCommandCreator.CreateCommand(ctx, log, handle, spec)
 	}
 	if err != nil {
 		return gardener.DesiredImageSpec{}, errorwrapper.Wrap(err, "creating create command")
@@ -141,3 +148,73 @@ func (p *ImagePlugin) Metrics(log lager.Logger, handle string, namespaced bool)
 func (p *ImagePlugin) GC(log lager.Logger) error {
 	return nil
 }
+
+// Orphans returns the ids of the images held by either plugin which do not
+// belong to any of the given handles, e.g. because the container was lost in a
+// crash before its image was deleted.
+//
+// The plugins are asked for their images with the "list" action, which is run
+// as `<plugin> [extra args...] list`. It prints the id of each image, which is
+// the handle it was created for, one per line on stdout, and exits 0. Blank
+// lines are ignored. A plugin which exits non-zero, as one without the action
+// does, is taken to hold no orphans: the failure is logged, and its output is
+// not trusted for ids, so no image is removed on a failed listing.
+func (p *ImagePlugin) Orphans(log lager.Logger, handles []string) ([]string, error) {
+	log = log.Session("image-plugin-orphans")
+	log.Debug("start")
+	defer log.Debug("end")
+
+	known := make(map[string]bool)
+	for _, handle := range handles {
+		known[handle] = true
+	}
+
+	orphans := []string{}
+	for _, creator := range []CommandCreator{p.UnprivilegedCommandCreator, p.PrivilegedCommandCreator} {
+		ids, err := p.list(log, creator)
+		if err != nil {
+			log.Info("treating-plugin-as-holding-no-orphans", lager.Data{"error": err.Error()})
+			continue
+		}
+
+		for _, id := range ids {
+			if !known[id] {
+				known[id] = true
+				orphans = append(orphans, id)
+			}
+		}
+	}
+
+	return orphans, nil
+}
+
+// RemoveOrphan deletes an image which no container owns
+func (p *ImagePlugin) RemoveOrphan(log lager.Logger, id string) error {
+	return p.Destroy(log, id)
+}
+
+func (p *ImagePlugin) list(log lager.Logger, creator CommandCreator) ([]string, error) {
+	listCmd := creator.ListCommand(log)
+	if listCmd == nil {
+		return nil, nil
+	}
+
+	stdoutBuffer := bytes.NewBuffer([]byte{})
+	listCmd.Stdout = stdoutBuffer
+	listCmd.Stderr = lagregator.NewRelogger(log)
+
+	if err := p.CommandRunner.Run(listCmd); err != nil {
+		logData := lager.Data{"action": "list", "stdout": stdoutBuffer.String()}
+		log.Error("image-plugin-result", err, logData)
+		return nil, errorwrapper.Wrapf(err, "running image plugin list: %s", stdoutBuffer.String())
+	}
+
+	ids := []string{}
+	for _, line := range strings.Split(stdoutBuffer.String(), "\n") {
+		if id := strings.TrimSpace(line); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
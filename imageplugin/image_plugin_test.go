@@ -1,6 +1,7 @@
 package imageplugin_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -58,6 +59,8 @@ var _ = Describe("ImagePlugin", func() {
 		var (
 			cmd *exec.Cmd
 
+			ctx                context.Context
+			cancel             context.CancelFunc
 			handle             string
 			rootfsProviderSpec rootfs_spec.Spec
 			rootfs             string
@@ -78,6 +81,7 @@ var _ = Describe("ImagePlugin", func() {
 			fakeUnprivilegedCommandCreator.CreateCommandReturns(cmd, nil)
 			fakePrivilegedCommandCreator.CreateCommandReturns(cmd, nil)
 
+			ctx, cancel = context.WithCancel(context.Background())
 			handle = "test-handle"
 			rootfs = "docker:///busybox"
 			namespaced = true //assume unprivileged by default
@@ -114,7 +118,11 @@ var _ = Describe("ImagePlugin", func() {
 			rootfsURL, err := url.Parse(rootfs)
 			Expect(err).NotTo(HaveOccurred())
 			rootfsProviderSpec = rootfs_spec.Spec{RootFS: rootfsURL, Namespaced: namespaced}
-			createImageSpec, createErr = imagePlugin.Create(fakeLogger, handle, rootfsProviderSpec)
+			createImageSpec, createErr = imagePlugin.Create(ctx, fakeLogger, handle, rootfsProviderSpec)
+		})
+
+		AfterEach(func() {
+			cancel()
 		})
 
 		It("calls the unprivileged command creator to generate a create command", func() {
@@ -122,7 +130,8 @@ var _ = Describe("ImagePlugin", func() {
 			Expect(fakeUnprivilegedCommandCreator.CreateCommandCallCount()).To(Equal(1))
 			Expect(fakePrivilegedCommandCreator.CreateCommandCallCount()).To(Equal(0))
 
-			_, handleArg, specArg := fakeUnprivilegedCommandCreator.CreateCommandArgsForCall(0)
+			ctxArg, _, handleArg, specArg := fakeUnprivilegedCommandCreator.CreateCommandArgsForCall(0)
+			Expect(ctxArg).To(Equal(ctx))
 			Expect(handleArg).To(Equal(handle))
 			Expect(specArg).To(Equal(rootfsProviderSpec))
 		})
@@ -147,7 +156,7 @@ var _ = Describe("ImagePlugin", func() {
 				Expect(fakePrivilegedCommandCreator.CreateCommandCallCount()).To(Equal(1))
 				Expect(fakeUnprivilegedCommandCreator.CreateCommandCallCount()).To(Equal(0))
 
-				_, handleArg, specArg := fakePrivilegedCommandCreator.CreateCommandArgsForCall(0)
+				_, _, handleArg, specArg := fakePrivilegedCommandCreator.CreateCommandArgsForCall(0)
 				Expect(handleArg).To(Equal(handle))
 				Expect(specArg).To(Equal(rootfsProviderSpec))
 			})
@@ -172,7 +181,7 @@ var _ = Describe("ImagePlugin", func() {
 				Expect(createErr).NotTo(HaveOccurred())
 				Expect(fakeUnprivilegedCommandCreator.CreateCommandCallCount()).To(Equal(1))
 
-				_, _, specArg := fakeUnprivilegedCommandCreator.CreateCommandArgsForCall(0)
+				_, _, _, specArg := fakeUnprivilegedCommandCreator.CreateCommandArgsForCall(0)
 				Expect(specArg.RootFS.String()).To(Equal("/default-rootfs"))
 			})
 
@@ -578,4 +587,95 @@ var _ = Describe("ImagePlugin", func() {
 			})
 		})
 	})
+
+	Describe("Orphans", func() {
+		var (
+			unprivStdout string
+			privStdout   string
+			privErr      error
+
+			orphans    []string
+			orphansErr error
+		)
+
+		BeforeEach(func() {
+			fakeUnprivilegedCommandCreator.ListCommandReturns(exec.Command("unpriv-plugin", "list"))
+			fakePrivilegedCommandCreator.ListCommandReturns(exec.Command("priv-plugin", "list"))
+
+			unprivStdout = "live-handle\nlost-unpriv\n"
+			privStdout = "lost-priv\n\nlost-unpriv\n"
+			privErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeCommandRunner.WhenRunning(
+				fake_command_runner.CommandSpec{Path: "unpriv-plugin"},
+				func(cmd *exec.Cmd) error {
+					cmd.Stdout.Write([]byte(unprivStdout))
+					return nil
+				},
+			)
+
+			fakeCommandRunner.WhenRunning(
+				fake_command_runner.CommandSpec{Path: "priv-plugin"},
+				func(cmd *exec.Cmd) error {
+					cmd.Stdout.Write([]byte(privStdout))
+					return privErr
+				},
+			)
+
+			orphans, orphansErr = imagePlugin.Orphans(fakeLogger, []string{"live-handle"})
+		})
+
+		It("returns the images of both plugins which belong to no container, once each", func() {
+			Expect(orphansErr).NotTo(HaveOccurred())
+			Expect(orphans).To(Equal([]string{"lost-unpriv", "lost-priv"}))
+		})
+
+		Context("when a plugin does not implement list", func() {
+			BeforeEach(func() {
+				fakePrivilegedCommandCreator.ListCommandReturns(nil)
+			})
+
+			It("only lists the images of the other plugin", func() {
+				Expect(orphansErr).NotTo(HaveOccurred())
+				Expect(orphans).To(Equal([]string{"lost-unpriv"}))
+			})
+		})
+
+		Context("when a plugin exits non-zero, as one without the list action does", func() {
+			BeforeEach(func() {
+				privStdout = "No help topic for 'list'"
+				privErr = errors.New("exit status 3")
+			})
+
+			It("treats it as holding no orphans, without taking its output for ids", func() {
+				Expect(orphansErr).NotTo(HaveOccurred())
+				Expect(orphans).To(Equal([]string{"lost-unpriv"}))
+			})
+
+			It("logs the failure", func() {
+				Expect(fakeLogger).To(glager.ContainSequence(
+					glager.Info(
+						glager.Message("image-plugin.image-plugin-orphans.treating-plugin-as-holding-no-orphans"),
+					),
+				))
+			})
+		})
+	})
+
+	Describe("RemoveOrphan", func() {
+		It("destroys the image with both plugins", func() {
+			fakeUnprivilegedCommandCreator.DestroyCommandReturns(exec.Command("unpriv-plugin", "delete"))
+			fakePrivilegedCommandCreator.DestroyCommandReturns(exec.Command("priv-plugin", "delete"))
+
+			Expect(imagePlugin.RemoveOrphan(fakeLogger, "lost-handle")).To(Succeed())
+
+			_, handle := fakeUnprivilegedCommandCreator.DestroyCommandArgsForCall(0)
+			Expect(handle).To(Equal("lost-handle"))
+			_, handle = fakePrivilegedCommandCreator.DestroyCommandArgsForCall(0)
+			Expect(handle).To(Equal("lost-handle"))
+			Expect(fakeCommandRunner.ExecutedCommands()).To(HaveLen(2))
+		})
+	})
 })
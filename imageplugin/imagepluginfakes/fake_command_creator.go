@@ -2,6 +2,7 @@
 package imagepluginfakes
 
 import (
+	"context"
 	"os/exec"
 	"sync"
 
@@ -11,9 +12,10 @@ import (
 )
 
 type FakeCommandCreator struct {
-	CreateCommandStub        func(log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error)
+	CreateCommandStub        func(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error)
 	createCommandMutex       sync.RWMutex
 	createCommandArgsForCall []struct {
+		ctx    context.Context
 		log    lager.Logger
 		handle string
 		spec   rootfs_spec.Spec
@@ -50,22 +52,34 @@ type FakeCommandCreator struct {
 	metricsCommandReturnsOnCall map[int]struct {
 		result1 *exec.Cmd
 	}
+	ListCommandStub        func(log lager.Logger) *exec.Cmd
+	listCommandMutex       sync.RWMutex
+	listCommandArgsForCall []struct {
+		log lager.Logger
+	}
+	listCommandReturns struct {
+		result1 *exec.Cmd
+	}
+	listCommandReturnsOnCall map[int]struct {
+		result1 *exec.Cmd
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeCommandCreator) CreateCommand(log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error) {
+func (fake *FakeCommandCreator) CreateCommand(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error) {
 	fake.createCommandMutex.Lock()
 	ret, specificReturn := fake.createCommandReturnsOnCall[len(fake.createCommandArgsForCall)]
 	fake.createCommandArgsForCall = append(fake.createCommandArgsForCall, struct {
+		ctx    context.Context
 		log    lager.Logger
 		handle string
 		spec   rootfs_spec.Spec
-	}{log, handle, spec})
-	fake.recordInvocation("CreateCommand", []interface{}{log, handle, spec})
+	}{ctx, log, handle, spec})
+	fake.recordInvocation("CreateCommand", []interface{}{ctx, log, handle, spec})
 	fake.createCommandMutex.Unlock()
 	if fake.CreateCommandStub != nil {
-		return fake.CreateCommandStub(log, handle, spec)
+		return fake.CreateCommandStub(ctx, log, handle, spec)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -79,10 +93,10 @@ func (fake *FakeCommandCreator) CreateCommandCallCount() int {
 	return len(fake.createCommandArgsForCall)
 }
 
-func (fake *FakeCommandCreator) CreateCommandArgsForCall(i int) (lager.Logger, string, rootfs_spec.Spec) {
+func (fake *FakeCommandCreator) CreateCommandArgsForCall(i int) (context.Context, lager.Logger, string, rootfs_spec.Spec) {
 	fake.createCommandMutex.RLock()
 	defer fake.createCommandMutex.RUnlock()
-	return fake.createCommandArgsForCall[i].log, fake.createCommandArgsForCall[i].handle, fake.createCommandArgsForCall[i].spec
+	return fake.createCommandArgsForCall[i].ctx, fake.createCommandArgsForCall[i].log, fake.createCommandArgsForCall[i].handle, fake.createCommandArgsForCall[i].spec
 }
 
 func (fake *FakeCommandCreator) CreateCommandReturns(result1 *exec.Cmd, result2 error) {
@@ -205,6 +219,54 @@ func (fake *FakeCommandCreator) MetricsCommandReturnsOnCall(i int, result1 *exec
 	}{result1}
 }
 
+func (fake *FakeCommandCreator) ListCommand(log lager.Logger) *exec.Cmd {
+	fake.listCommandMutex.Lock()
+	ret, specificReturn := fake.listCommandReturnsOnCall[len(fake.listCommandArgsForCall)]
+	fake.listCommandArgsForCall = append(fake.listCommandArgsForCall, struct {
+		log lager.Logger
+	}{log})
+	fake.recordInvocation("ListCommand", []interface{}{log})
+	fake.listCommandMutex.Unlock()
+	if fake.ListCommandStub != nil {
+		return fake.ListCommandStub(log)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.listCommandReturns.result1
+}
+
+func (fake *FakeCommandCreator) ListCommandCallCount() int {
+	fake.listCommandMutex.RLock()
+	defer fake.listCommandMutex.RUnlock()
+	return len(fake.listCommandArgsForCall)
+}
+
+func (fake *FakeCommandCreator) ListCommandArgsForCall(i int) lager.Logger {
+	fake.listCommandMutex.RLock()
+	defer fake.listCommandMutex.RUnlock()
+	return fake.listCommandArgsForCall[i].log
+}
+
+func (fake *FakeCommandCreator) ListCommandReturns(result1 *exec.Cmd) {
+	fake.ListCommandStub = nil
+	fake.listCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeCommandCreator) ListCommandReturnsOnCall(i int, result1 *exec.Cmd) {
+	fake.ListCommandStub = nil
+	if fake.listCommandReturnsOnCall == nil {
+		fake.listCommandReturnsOnCall = make(map[int]struct {
+			result1 *exec.Cmd
+		})
+	}
+	fake.listCommandReturnsOnCall[i] = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
 func (fake *FakeCommandCreator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -214,6 +276,8 @@ func (fake *FakeCommandCreator) Invocations() map[string][][]interface{} {
 	defer fake.destroyCommandMutex.RUnlock()
 	fake.metricsCommandMutex.RLock()
 	defer fake.metricsCommandMutex.RUnlock()
+	fake.listCommandMutex.RLock()
+	defer fake.listCommandMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
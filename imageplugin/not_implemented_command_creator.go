@@ -1,6 +1,7 @@
 package imageplugin
 
 import (
+	"context"
 	"os/exec"
 
 	"code.cloudfoundry.org/garden-shed/rootfs_spec"
@@ -11,7 +12,7 @@ type NotImplementedCommandCreator struct {
 	Err error
 }
 
-func (cc *NotImplementedCommandCreator) CreateCommand(log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error) {
+func (cc *NotImplementedCommandCreator) CreateCommand(ctx context.Context, log lager.Logger, handle string, spec rootfs_spec.Spec) (*exec.Cmd, error) {
 	return nil, cc.Err
 }
 
@@ -22,3 +23,7 @@ func (cc *NotImplementedCommandCreator) DestroyCommand(log lager.Logger, handle
 func (cc *NotImplementedCommandCreator) MetricsCommand(log lager.Logger, handle string) *exec.Cmd {
 	return nil
 }
+
+func (cc *NotImplementedCommandCreator) ListCommand(log lager.Logger) *exec.Cmd {
+	return nil
+}
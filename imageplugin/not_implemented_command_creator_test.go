@@ -1,6 +1,7 @@
 package imageplugin_test
 
 import (
+	"context"
 	"errors"
 
 	"code.cloudfoundry.org/garden-shed/rootfs_spec"
@@ -22,7 +23,7 @@ var _ = Describe("NotImplementedCommandCreator", func() {
 
 	Describe("CreateCommand", func() {
 		It("returns nil and provided error", func() {
-			cmd, err := notImplementedCommandCreator.CreateCommand(nil, "", rootfs_spec.Spec{})
+			cmd, err := notImplementedCommandCreator.CreateCommand(context.Background(), nil, "", rootfs_spec.Spec{})
 			Expect(cmd).To(BeNil())
 			Expect(err).To(MatchError(errors.New("NOT IMPLEMENTED")))
 		})
@@ -39,4 +40,10 @@ var _ = Describe("NotImplementedCommandCreator", func() {
 			Expect(notImplementedCommandCreator.MetricsCommand(nil, "")).To(BeNil())
 		})
 	})
+
+	Describe("ListCommand", func() {
+		It("returns nil", func() {
+			Expect(notImplementedCommandCreator.ListCommand(nil)).To(BeNil())
+		})
+	})
 })
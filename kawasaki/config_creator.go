@@ -3,6 +3,7 @@ package kawasaki
 import (
 	"encoding/hex"
 	"fmt"
+	"hash/fnv"
 	"net"
 
 	"code.cloudfoundry.org/guardian/kawasaki/subnets"
@@ -36,6 +37,13 @@ type NetworkConfig struct {
 	PluginNameservers     []net.IP
 	OperatorNameservers   []net.IP
 	AdditionalNameservers []net.IP
+
+	// the IPv4 addresses above are nil for containers with only IPv6, and the
+	// IPv6 addresses of the container are nil unless IPv6 is enabled
+	ContainerIPv6 net.IP
+	BridgeIPv6    net.IP
+	ExternalIPv6  net.IP
+	SubnetV6      *net.IPNet
 }
 
 type Creator struct {
@@ -68,25 +76,50 @@ func NewConfigCreator(idGenerator IDGenerator, interfacePrefix, chainPrefix stri
 	}
 }
 
+// Create returns the config of a container with the ip in the subnet. An IPv6
+// subnet gives a container with only IPv6, as a container with both is created
+// with its IPv4 subnet, and given its IPv6 addresses after.
 func (c *Creator) Create(log lager.Logger, handle string, subnet *net.IPNet, ip net.IP) (NetworkConfig, error) {
 	id := c.idGenerator.Generate()
-	return NetworkConfig{
+	config := NetworkConfig{
 		ContainerHandle: handle,
 		HostIntf:        fmt.Sprintf("%s%s-0", c.interfacePrefix, id),
 		ContainerIntf:   fmt.Sprintf("%s%s-1", c.interfacePrefix, id),
 
-		BridgeName: fmt.Sprintf("%s%s%s", c.interfacePrefix, "brdg-", hex.EncodeToString(subnet.IP)),
+		BridgeName: fmt.Sprintf("%s%s%s", c.interfacePrefix, "brdg-", bridgeSuffix(subnet)),
 
 		IPTablePrefix:         c.chainPrefix,
 		IPTableInstance:       id,
-		ContainerIP:           ip,
-		BridgeIP:              subnets.GatewayIP(subnet),
-		ExternalIP:            c.externalIP,
-		Subnet:                subnet,
 		Mtu:                   c.mtu,
 		OperatorNameservers:   c.operatorNameservers,
 		AdditionalNameservers: c.additionalNameservers,
-	}, nil
+	}
+
+	if subnet.IP.To4() == nil {
+		config.ContainerIPv6 = ip
+		config.BridgeIPv6 = subnets.GatewayIP(subnet)
+		config.SubnetV6 = subnet
+		return config, nil
+	}
+
+	config.ContainerIP = ip
+	config.BridgeIP = subnets.GatewayIP(subnet)
+	config.ExternalIP = c.externalIP
+	config.Subnet = subnet
+	return config, nil
+}
+
+// bridgeSuffix names the bridge of a subnet in 8 characters, so that bridge
+// names fit in the 15 characters allowed. IPv6 subnets are too long to spell
+// out, so are hashed instead.
+func bridgeSuffix(subnet *net.IPNet) string {
+	if ip := subnet.IP.To4(); ip != nil {
+		return hex.EncodeToString(ip)
+	}
+
+	hash := fnv.New32a()
+	hash.Write(subnet.IP)
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
 func min(a, b int) int {
@@ -105,6 +105,49 @@ var _ = Describe("ConfigCreator", func() {
 		})
 	})
 
+	Context("when the subnet is IPv6", func() {
+		BeforeEach(func() {
+			var err error
+
+			ip, subnet, err = net.ParseCIDR("fd00::1:2/126")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("gives the container only IPv6 addresses", func() {
+			config, err := creator.Create(logger, "banana", subnet, ip)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(config.ContainerIPv6.String()).To(Equal("fd00::1:2"))
+			Expect(config.SubnetV6.String()).To(Equal("fd00::1:0/126"))
+			Expect(config.BridgeIPv6.String()).To(Equal("fd00::1:1"))
+
+			Expect(config.ContainerIP).To(BeNil())
+			Expect(config.Subnet).To(BeNil())
+			Expect(config.BridgeIP).To(BeNil())
+			Expect(config.ExternalIP).To(BeNil())
+		})
+
+		It("assigns a bridge name no longer than 15 chars, starting with the interface prefix then 'brdg-'", func() {
+			config, err := creator.Create(logger, "banana", subnet, ip)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(len(config.BridgeName)).To(BeNumerically("<=", 15))
+			Expect(config.BridgeName).To(HavePrefix("w1brdg-"))
+		})
+
+		It("assigns different bridge names to different subnets", func() {
+			config1, err := creator.Create(logger, "banana", subnet, ip)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherIP, otherSubnet, err := net.ParseCIDR("fd00::1:6/126")
+			Expect(err).NotTo(HaveOccurred())
+			config2, err := creator.Create(logger, "banana", otherSubnet, otherIP)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(config1.BridgeName).NotTo(Equal(config2.BridgeName))
+		})
+	})
+
 	It("assigns the interface names based on the ID from the ID generator", func() {
 		idGenerator.GenerateReturns("cocacola")
 
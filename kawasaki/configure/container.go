@@ -24,6 +24,7 @@ import (
 func init() {
 	reexec.Register("configure-container-netns", func() {
 		var netNsPath, containerIntf, containerIPStr, bridgeIPStr, subnetStr string
+		var containerIPv6Str, bridgeIPv6Str, subnetV6Str string
 		var mtu int
 
 		flag.StringVar(&netNsPath, "netNsPath", "", "netNsPath")
@@ -31,6 +32,9 @@ func init() {
 		flag.StringVar(&containerIPStr, "containerIP", "", "containerIP")
 		flag.StringVar(&bridgeIPStr, "bridgeIP", "", "bridgeIP")
 		flag.StringVar(&subnetStr, "subnet", "", "subnet")
+		flag.StringVar(&containerIPv6Str, "containerIPv6", "", "containerIPv6")
+		flag.StringVar(&bridgeIPv6Str, "bridgeIPv6", "", "bridgeIPv6")
+		flag.StringVar(&subnetV6Str, "subnetV6", "", "subnetV6")
 		flag.IntVar(&mtu, "mtu", 0, "mtu")
 		flag.Parse()
 
@@ -44,13 +48,6 @@ func init() {
 		netNsExecer := &netns.Execer{}
 
 		if err = netNsExecer.Exec(fd, func() error {
-			containerIP := net.ParseIP(containerIPStr)
-			bridgeIP := net.ParseIP(bridgeIPStr)
-			_, subnetIPNet, err := net.ParseCIDR(subnetStr)
-			if err != nil {
-				panic(err)
-			}
-
 			link := devices.Link{}
 
 			intf, found, err := link.InterfaceByName(containerIntf)
@@ -61,16 +58,39 @@ func init() {
 				return fmt.Errorf("interface `%s` was not found", containerIntf)
 			}
 
-			if err := link.AddIP(intf, containerIP, subnetIPNet); err != nil {
+			if err := link.SetUp(intf); err != nil {
 				panic(err)
 			}
 
-			if err := link.SetUp(intf); err != nil {
-				panic(err)
+			// containers with only IPv6 are given no IPv4 address
+			if containerIPStr != "" {
+				_, subnetIPNet, err := net.ParseCIDR(subnetStr)
+				if err != nil {
+					panic(err)
+				}
+
+				if err := link.AddIP(intf, net.ParseIP(containerIPStr), subnetIPNet); err != nil {
+					panic(err)
+				}
+
+				if err := link.AddDefaultGW(intf, net.ParseIP(bridgeIPStr)); err != nil {
+					panic(err)
+				}
 			}
 
-			if err := link.AddDefaultGW(intf, bridgeIP); err != nil {
-				panic(err)
+			if containerIPv6Str != "" {
+				_, subnetV6IPNet, err := net.ParseCIDR(subnetV6Str)
+				if err != nil {
+					panic(err)
+				}
+
+				if err := link.AddIP(intf, net.ParseIP(containerIPv6Str), subnetV6IPNet); err != nil {
+					panic(err)
+				}
+
+				if err := link.AddDefaultGW(intf, net.ParseIP(bridgeIPv6Str)); err != nil {
+					panic(err)
+				}
 			}
 
 			if err := link.SetMTU(intf, mtu); err != nil {
@@ -101,14 +121,29 @@ func (c *Container) Apply(log lager.Logger, cfg kawasaki.NetworkConfig, pid int)
 		"netNsPath":     netns.Name(),
 	})
 
-	cmd := reexec.Command("configure-container-netns",
+	args := []string{
 		"-netNsPath", netns.Name(),
 		"-containerIntf", cfg.ContainerIntf,
-		"-containerIP", cfg.ContainerIP.String(),
-		"-bridgeIP", cfg.BridgeIP.String(),
-		"-subnet", cfg.Subnet.String(),
 		"-mtu", strconv.FormatInt(int64(cfg.Mtu), 10),
-	)
+	}
+
+	if cfg.ContainerIP != nil {
+		args = append(args,
+			"-containerIP", cfg.ContainerIP.String(),
+			"-bridgeIP", cfg.BridgeIP.String(),
+			"-subnet", cfg.Subnet.String(),
+		)
+	}
+
+	if cfg.ContainerIPv6 != nil {
+		args = append(args,
+			"-containerIPv6", cfg.ContainerIPv6.String(),
+			"-bridgeIPv6", cfg.BridgeIPv6.String(),
+			"-subnetV6", cfg.SubnetV6.String(),
+		)
+	}
+
+	cmd := reexec.Command(append([]string{"configure-container-netns"}, args...)...)
 
 	errBuf := bytes.NewBuffer([]byte{})
 	cmd.Stderr = errBuf
@@ -59,6 +59,18 @@ func (err AddToBridgeError) Error() string {
 	return fmtErr("failed to add slave %s to bridge %s: %v", err.Slave.Name, err.Bridge.Name, err.Cause)
 }
 
+// AddIPError is returned if adding an address to an interface fails
+type AddIPError struct {
+	Cause  error
+	Intf   *net.Interface
+	IP     net.IP
+	Subnet *net.IPNet
+}
+
+func (err AddIPError) Error() string {
+	return fmtErr("failed to add IP '%s' in subnet '%s' to interface %s: %v", err.IP, err.Subnet, err.Intf.Name, err.Cause)
+}
+
 // LinkUpError is returned if brinding an interface up fails
 type LinkUpError struct {
 	Cause error
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
 
 	"code.cloudfoundry.org/guardian/kawasaki"
 	"code.cloudfoundry.org/lager"
@@ -18,6 +19,7 @@ type Host struct {
 		SetUp(intf *net.Interface) error
 		SetMTU(intf *net.Interface, mtu int) error
 		SetNs(intf *net.Interface, fd int) error
+		AddIP(intf *net.Interface, ip net.IP, subnet *net.IPNet) error
 		InterfaceByName(name string) (*net.Interface, bool, error)
 	}
 
@@ -44,6 +46,8 @@ func (c *Host) Apply(logger lager.Logger, config kawasaki.NetworkConfig, pid int
 		"bridgeName":     config.BridgeName,
 		"bridgeIP":       config.BridgeIP,
 		"subnet":         config.Subnet,
+		"bridgeIPv6":     config.BridgeIPv6,
+		"subnetV6":       config.SubnetV6,
 		"containerIface": config.ContainerIntf,
 		"hostIface":      config.HostIntf,
 		"mtu":            config.Mtu,
@@ -52,8 +56,21 @@ func (c *Host) Apply(logger lager.Logger, config kawasaki.NetworkConfig, pid int
 
 	cLog.Debug("configuring")
 
-	if bridge, err = c.configureBridgeIntf(cLog, config.BridgeName, config.BridgeIP, config.Subnet); err != nil {
-		return err
+	// the bridge of a container with only IPv6 is created with the IPv6 address
+	if config.BridgeIP == nil {
+		if bridge, err = c.configureBridgeIntf(cLog, config.BridgeName, config.BridgeIPv6, config.SubnetV6); err != nil {
+			return err
+		}
+	} else {
+		if bridge, err = c.configureBridgeIntf(cLog, config.BridgeName, config.BridgeIP, config.Subnet); err != nil {
+			return err
+		}
+	}
+
+	if config.BridgeIP != nil && config.BridgeIPv6 != nil {
+		if err = c.configureBridgeIPv6(cLog, bridge, config.BridgeIPv6, config.SubnetV6); err != nil {
+			return err
+		}
 	}
 
 	if host, container, err = c.configureVethPair(cLog, config.HostIntf, config.ContainerIntf); err != nil {
@@ -104,6 +121,21 @@ func (c *Host) configureBridgeIntf(log lager.Logger, name string, ip net.IP, sub
 	return bridge, nil
 }
 
+// configureBridgeIPv6 gives the bridge the gateway address of the IPv6 subnet.
+// The bridge is shared by the containers of an IPv4 subnet, so it may already
+// have the address.
+func (c *Host) configureBridgeIPv6(log lager.Logger, bridge *net.Interface, ip net.IP, subnet *net.IPNet) error {
+	log = log.Session("bridge-ipv6", lager.Data{"ip": ip, "subnet": subnet})
+
+	log.Debug("add-ip")
+	if err := c.Link.AddIP(bridge, ip, subnet); err != nil && !strings.HasSuffix(err.Error(), "file exists") {
+		log.Error("add-ip", err)
+		return &AddIPError{err, bridge, ip, subnet}
+	}
+
+	return nil
+}
+
 func (c *Host) configureVethPair(log lager.Logger, hostName, containerName string) (*net.Interface, *net.Interface, error) {
 	log = log.Session("veth")
 
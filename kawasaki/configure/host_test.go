@@ -186,6 +186,22 @@ var _ = Describe("Host", func() {
 						Expect(bridger.AddCalledWith.Bridge).To(Equal(createdBridge))
 					})
 
+					Context("when the container has only an IPv6 address", func() {
+						BeforeEach(func() {
+							config.BridgeName = "banana-bridge"
+							config.BridgeIPv6 = net.ParseIP("fd00::1")
+							_, config.SubnetV6, _ = net.ParseCIDR("fd00::/126")
+						})
+
+						It("creates the bridge with the IPv6 gateway address", func() {
+							Expect(configurer.Apply(logger, config, 42)).To(Succeed())
+							Expect(bridger.CreateCalledWith.Name).To(Equal("banana-bridge"))
+							Expect(bridger.CreateCalledWith.IP).To(Equal(net.ParseIP("fd00::1")))
+							Expect(bridger.CreateCalledWith.Subnet).To(Equal(config.SubnetV6))
+							Expect(linkConfigurer.AddIPCalledWith).To(BeEmpty())
+						})
+					})
+
 					Context("but if creating the bridge fails", func() {
 						It("returns an error", func() {
 							bridger.CreateReturns.Error = errors.New("kawasaki!")
@@ -209,6 +225,44 @@ var _ = Describe("Host", func() {
 						Expect(linkConfigurer.SetUpCalledWith).To(ContainElement(vethCreator.CreateReturns.Host))
 					})
 
+					Context("when the container has an IPv6 address", func() {
+						BeforeEach(func() {
+							config.BridgeName = "bridge"
+							config.BridgeIP = net.ParseIP("10.0.0.1")
+							_, config.Subnet, _ = net.ParseCIDR("10.0.0.0/30")
+							config.BridgeIPv6 = net.ParseIP("fd00::1")
+							_, config.SubnetV6, _ = net.ParseCIDR("fd00::/126")
+						})
+
+						It("adds the IPv6 gateway address to the bridge", func() {
+							Expect(configurer.Apply(logger, config, 42)).To(Succeed())
+							Expect(linkConfigurer.AddIPCalledWith).To(ConsistOf(fakedevices.InterfaceIPAndSubnet{
+								Interface: existingBridge,
+								IP:        net.ParseIP("fd00::1"),
+								Subnet:    config.SubnetV6,
+							}))
+						})
+
+						It("tolerates the bridge already having the address", func() {
+							linkConfigurer.AddIPReturns["bridge"] = errors.New("devices: file exists")
+							Expect(configurer.Apply(logger, config, 42)).To(Succeed())
+						})
+
+						Context("when adding the address fails", func() {
+							It("returns a wrapped error", func() {
+								cause := errors.New("no v6 here")
+								linkConfigurer.AddIPReturns["bridge"] = cause
+
+								Expect(configurer.Apply(logger, config, 42)).To(MatchError(&configure.AddIPError{
+									Cause:  cause,
+									Intf:   existingBridge,
+									IP:     net.ParseIP("fd00::1"),
+									Subnet: config.SubnetV6,
+								}))
+							})
+						})
+					})
+
 					Context("when bringing the host interface up fails", func() {
 						It("returns a wrapped error", func() {
 							cause := errors.New("there's jam in this sandwich and it's not ok")
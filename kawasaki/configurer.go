@@ -1,6 +1,7 @@
 package kawasaki
 
 import (
+	"context"
 	"net"
 	"os"
 
@@ -14,11 +15,12 @@ type NetnsExecer interface {
 }
 
 type configurer struct {
-	dnsResolvConfigurer  DnsResolvConfigurer
-	hostConfigurer       HostConfigurer
-	containerConfigurer  ContainerConfigurer
-	instanceChainCreator InstanceChainCreator
-	fileOpener           netns.Opener
+	dnsResolvConfigurer    DnsResolvConfigurer
+	hostConfigurer         HostConfigurer
+	containerConfigurer    ContainerConfigurer
+	instanceChainCreator   InstanceChainCreator
+	instanceChainCreatorV6 InstanceChainCreator
+	fileOpener             netns.Opener
 }
 
 //go:generate counterfeiter . HostConfigurer
@@ -29,7 +31,7 @@ type HostConfigurer interface {
 
 //go:generate counterfeiter . InstanceChainCreator
 type InstanceChainCreator interface {
-	Create(logger lager.Logger, handle, instanceChain, bridgeName string, ip net.IP, network *net.IPNet) error
+	Create(ctx context.Context, logger lager.Logger, handle, instanceChain, bridgeName string, ip net.IP, network *net.IPNet) error
 	Destroy(logger lager.Logger, instanceChain string) error
 }
 
@@ -44,24 +46,51 @@ type DnsResolvConfigurer interface {
 }
 
 func NewConfigurer(resolvConfigurer DnsResolvConfigurer, hostConfigurer HostConfigurer, containerConfigurer ContainerConfigurer, instanceChainCreator InstanceChainCreator) *configurer {
+	return NewDualStackConfigurer(resolvConfigurer, hostConfigurer, containerConfigurer, instanceChainCreator, nil)
+}
+
+// NewDualStackConfigurer returns a configurer which also creates the IPv6
+// instance chains of containers which have an IPv6 address. Containers with
+// only IPv6 have no IPv4 instance chain.
+func NewDualStackConfigurer(resolvConfigurer DnsResolvConfigurer, hostConfigurer HostConfigurer, containerConfigurer ContainerConfigurer, instanceChainCreator, instanceChainCreatorV6 InstanceChainCreator) *configurer {
 	return &configurer{
-		dnsResolvConfigurer:  resolvConfigurer,
-		hostConfigurer:       hostConfigurer,
-		containerConfigurer:  containerConfigurer,
-		instanceChainCreator: instanceChainCreator,
+		dnsResolvConfigurer:    resolvConfigurer,
+		hostConfigurer:         hostConfigurer,
+		containerConfigurer:    containerConfigurer,
+		instanceChainCreator:   instanceChainCreator,
+		instanceChainCreatorV6: instanceChainCreatorV6,
 	}
 }
 
-func (c *configurer) Apply(log lager.Logger, cfg NetworkConfig, pid int) error {
+// Apply sets up the container's networking. Only the iptables commands are
+// bound to ctx; configuring DNS, the host and the container's namespace cannot
+// be interrupted, but none of them are started once ctx is done.
+func (c *configurer) Apply(ctx context.Context, log lager.Logger, cfg NetworkConfig, pid int) error {
 	if err := c.dnsResolvConfigurer.Configure(log, cfg, pid); err != nil {
 		return err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if err := c.hostConfigurer.Apply(log, cfg, pid); err != nil {
 		return err
 	}
 
-	if err := c.instanceChainCreator.Create(log, cfg.ContainerHandle, cfg.IPTableInstance, cfg.BridgeName, cfg.ContainerIP, cfg.Subnet); err != nil {
+	if hasIPv4(cfg) {
+		if err := c.instanceChainCreator.Create(ctx, log, cfg.ContainerHandle, cfg.IPTableInstance, cfg.BridgeName, cfg.ContainerIP, cfg.Subnet); err != nil {
+			return err
+		}
+	}
+
+	if c.hasIPv6(cfg) {
+		if err := c.instanceChainCreatorV6.Create(ctx, log, cfg.ContainerHandle, cfg.IPTableInstance, cfg.BridgeName, cfg.ContainerIPv6, cfg.SubnetV6); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
@@ -73,5 +102,23 @@ func (c *configurer) DestroyBridge(log lager.Logger, cfg NetworkConfig) error {
 }
 
 func (c *configurer) DestroyIPTablesRules(log lager.Logger, cfg NetworkConfig) error {
-	return c.instanceChainCreator.Destroy(log, cfg.IPTableInstance)
+	if hasIPv4(cfg) {
+		if err := c.instanceChainCreator.Destroy(log, cfg.IPTableInstance); err != nil {
+			return err
+		}
+	}
+
+	if !c.hasIPv6(cfg) {
+		return nil
+	}
+
+	return c.instanceChainCreatorV6.Destroy(log, cfg.IPTableInstance)
+}
+
+func hasIPv4(cfg NetworkConfig) bool {
+	return cfg.ContainerIP != nil || cfg.ContainerIPv6 == nil
+}
+
+func (c *configurer) hasIPv6(cfg NetworkConfig) bool {
+	return cfg.ContainerIPv6 != nil && c.instanceChainCreatorV6 != nil
 }
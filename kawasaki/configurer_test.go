@@ -1,6 +1,7 @@
 package kawasaki_test
 
 import (
+	"context"
 	"errors"
 	"io/ioutil"
 	"net"
@@ -58,7 +59,7 @@ var _ = Describe("Configurer", func() {
 
 	Describe("Apply", func() {
 		It("configures dns", func() {
-			Expect(configurer.Apply(logger, kawasaki.NetworkConfig{ContainerHandle: "h"}, 42)).To(Succeed())
+			Expect(configurer.Apply(context.Background(), logger, kawasaki.NetworkConfig{ContainerHandle: "h"}, 42)).To(Succeed())
 			Expect(fakeDnsResolvConfigurer.ConfigureCallCount()).To(Equal(1))
 			_, cfg, pid := fakeDnsResolvConfigurer.ConfigureArgsForCall(0)
 			Expect(cfg).To(Equal(kawasaki.NetworkConfig{ContainerHandle: "h"}))
@@ -68,7 +69,7 @@ var _ = Describe("Configurer", func() {
 		Context("when resolv configuration fails", func() {
 			It("returns the error", func() {
 				fakeDnsResolvConfigurer.ConfigureReturns(errors.New("baboom"))
-				Expect(configurer.Apply(logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("baboom"))
+				Expect(configurer.Apply(context.Background(), logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("baboom"))
 			})
 		})
 
@@ -77,7 +78,7 @@ var _ = Describe("Configurer", func() {
 				ContainerIntf: "banana",
 			}
 
-			Expect(configurer.Apply(logger, cfg, 42)).To(Succeed())
+			Expect(configurer.Apply(context.Background(), logger, cfg, 42)).To(Succeed())
 
 			Expect(fakeHostConfigurer.ApplyCallCount()).To(Equal(1))
 			_, appliedCfg, pid := fakeHostConfigurer.ApplyArgsForCall(0)
@@ -91,16 +92,16 @@ var _ = Describe("Configurer", func() {
 			})
 
 			It("returns the error", func() {
-				Expect(configurer.Apply(logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("boom"))
+				Expect(configurer.Apply(context.Background(), logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("boom"))
 			})
 
 			It("does not configure the container", func() {
-				Expect(configurer.Apply(logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("boom"))
+				Expect(configurer.Apply(context.Background(), logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("boom"))
 				Expect(fakeContainerConfigurer.ApplyCallCount()).To(Equal(0))
 			})
 
 			It("does not configure IPTables", func() {
-				Expect(configurer.Apply(logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("boom"))
+				Expect(configurer.Apply(context.Background(), logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("boom"))
 				Expect(fakeInstanceChainCreator.CreateCallCount()).To(Equal(0))
 			})
 		})
@@ -116,9 +117,9 @@ var _ = Describe("Configurer", func() {
 				Subnet:          subnet,
 			}
 
-			Expect(configurer.Apply(logger, cfg, 42)).To(Succeed())
+			Expect(configurer.Apply(context.Background(), logger, cfg, 42)).To(Succeed())
 			Expect(fakeInstanceChainCreator.CreateCallCount()).To(Equal(1))
-			_, handle, instanceChain, bridgeName, ip, subnet := fakeInstanceChainCreator.CreateArgsForCall(0)
+			_, _, handle, instanceChain, bridgeName, ip, subnet := fakeInstanceChainCreator.CreateArgsForCall(0)
 			Expect(handle).To(Equal("some-handle"))
 			Expect(instanceChain).To(Equal("instance"))
 			Expect(bridgeName).To(Equal("the-bridge-name"))
@@ -129,7 +130,7 @@ var _ = Describe("Configurer", func() {
 		Context("when applying IPTables configuration fails", func() {
 			It("returns the error", func() {
 				fakeInstanceChainCreator.CreateReturns(errors.New("oh no"))
-				Expect(configurer.Apply(logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("oh no"))
+				Expect(configurer.Apply(context.Background(), logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("oh no"))
 			})
 		})
 
@@ -138,7 +139,7 @@ var _ = Describe("Configurer", func() {
 				ContainerIntf: "banana",
 			}
 
-			Expect(configurer.Apply(logger, cfg, 42)).To(Succeed())
+			Expect(configurer.Apply(context.Background(), logger, cfg, 42)).To(Succeed())
 
 			Expect(fakeContainerConfigurer.ApplyCallCount()).To(Equal(1))
 			_, cfgArg, pid := fakeContainerConfigurer.ApplyArgsForCall(0)
@@ -152,7 +153,7 @@ var _ = Describe("Configurer", func() {
 			})
 
 			It("returns the error", func() {
-				Expect(configurer.Apply(logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("banana"))
+				Expect(configurer.Apply(context.Background(), logger, kawasaki.NetworkConfig{}, 42)).To(MatchError("banana"))
 			})
 		})
 	})
@@ -202,4 +203,70 @@ var _ = Describe("Configurer", func() {
 			})
 		})
 	})
+	Context("when the configurer is dual-stack", func() {
+		var (
+			fakeInstanceChainCreatorV6 *fakes.FakeInstanceChainCreator
+			cfg                        kawasaki.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fakeInstanceChainCreatorV6 = new(fakes.FakeInstanceChainCreator)
+			configurer = kawasaki.NewDualStackConfigurer(fakeDnsResolvConfigurer, fakeHostConfigurer, fakeContainerConfigurer, fakeInstanceChainCreator, fakeInstanceChainCreatorV6)
+
+			_, subnetV6, _ := net.ParseCIDR("fd00::/126")
+			cfg = kawasaki.NetworkConfig{
+				IPTableInstance: "instance",
+				BridgeName:      "the-bridge-name",
+				ContainerHandle: "some-handle",
+				ContainerIP:     net.ParseIP("10.0.0.2"),
+				ContainerIPv6:   net.ParseIP("fd00::2"),
+				SubnetV6:        subnetV6,
+			}
+		})
+
+		It("applies the IPv6 iptable configuration", func() {
+			Expect(configurer.Apply(context.Background(), logger, cfg, 42)).To(Succeed())
+
+			Expect(fakeInstanceChainCreatorV6.CreateCallCount()).To(Equal(1))
+			_, _, handle, instanceChain, bridgeName, ip, subnet := fakeInstanceChainCreatorV6.CreateArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(instanceChain).To(Equal("instance"))
+			Expect(bridgeName).To(Equal("the-bridge-name"))
+			Expect(ip).To(Equal(net.ParseIP("fd00::2")))
+			Expect(subnet).To(Equal(cfg.SubnetV6))
+		})
+
+		It("tears down the IPv6 chains", func() {
+			Expect(configurer.DestroyIPTablesRules(logger, cfg)).To(Succeed())
+
+			Expect(fakeInstanceChainCreator.DestroyCallCount()).To(Equal(1))
+			Expect(fakeInstanceChainCreatorV6.DestroyCallCount()).To(Equal(1))
+			_, instance := fakeInstanceChainCreatorV6.DestroyArgsForCall(0)
+			Expect(instance).To(Equal("instance"))
+		})
+
+		Context("when the container has only an IPv6 address", func() {
+			It("leaves iptables alone", func() {
+				cfg.ContainerIP = nil
+				Expect(configurer.Apply(context.Background(), logger, cfg, 42)).To(Succeed())
+				Expect(configurer.DestroyIPTablesRules(logger, cfg)).To(Succeed())
+
+				Expect(fakeInstanceChainCreator.CreateCallCount()).To(Equal(0))
+				Expect(fakeInstanceChainCreator.DestroyCallCount()).To(Equal(0))
+				Expect(fakeInstanceChainCreatorV6.CreateCallCount()).To(Equal(1))
+				Expect(fakeInstanceChainCreatorV6.DestroyCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the container has no IPv6 address", func() {
+			It("leaves ip6tables alone", func() {
+				cfg.ContainerIPv6 = nil
+				Expect(configurer.Apply(context.Background(), logger, cfg, 42)).To(Succeed())
+				Expect(configurer.DestroyIPTablesRules(logger, cfg)).To(Succeed())
+
+				Expect(fakeInstanceChainCreatorV6.CreateCallCount()).To(Equal(0))
+				Expect(fakeInstanceChainCreatorV6.DestroyCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
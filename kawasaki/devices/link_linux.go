@@ -116,6 +116,24 @@ func (Link) List() (names []string, err error) {
 	return names, nil
 }
 
+// Delete removes the interface, doing nothing if it does not exist. Deleting
+// either end of a veth pair removes both ends.
+func (Link) Delete(name string) error {
+	netlinkMu.Lock()
+	defer netlinkMu.Unlock()
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil
+		}
+
+		return errF(err)
+	}
+
+	return errF(netlink.LinkDel(link))
+}
+
 func (l Link) Statistics(name string) (stats garden.ContainerNetworkStat, err error) {
 	var RxBytes, TxBytes uint64
 
@@ -190,6 +190,22 @@ var _ = Describe("Link Management", func() {
 		})
 	})
 
+	Describe("Delete", func() {
+		It("deletes the interface", func() {
+			Expect(l.Delete(name)).To(Succeed())
+
+			_, found, err := l.InterfaceByName(name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the interface does not exist", func() {
+			It("does not return an error", func() {
+				Expect(l.Delete("sandwich")).To(Succeed())
+			})
+		})
+	})
+
 	Describe("Statistics", func() {
 
 		Context("When the interface exist", func() {
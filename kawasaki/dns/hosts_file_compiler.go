@@ -10,10 +10,22 @@ import (
 type HostsFileCompiler struct {
 }
 
-func (h *HostsFileCompiler) Compile(log lager.Logger, ip net.IP, handle string) ([]byte, error) {
+func (h *HostsFileCompiler) Compile(log lager.Logger, ips []net.IP, handle string) ([]byte, error) {
 	if len(handle) > 49 {
 		handle = handle[len(handle)-49:]
 	}
-	contents := fmt.Sprintf("127.0.0.1 localhost\n%s %s\n", ip, handle)
+
+	contents := "127.0.0.1 localhost\n"
+	for _, ip := range ips {
+		if ip.To4() == nil {
+			contents += "::1 localhost ip6-localhost ip6-loopback\n"
+			break
+		}
+	}
+
+	for _, ip := range ips {
+		contents += fmt.Sprintf("%s %s\n", ip, handle)
+	}
+
 	return []byte(contents), nil
 }
@@ -27,20 +27,34 @@ var _ = Describe("HostsFileCompiler", func() {
 
 	Describe("Compile", func() {
 		It("should configure the localhost mapping", func() {
-			contents, err := compiler.Compile(log, ip, "myhandle")
+			contents, err := compiler.Compile(log, []net.IP{ip}, "myhandle")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(contents)).To(ContainSubstring("127.0.0.1 localhost"))
 		})
 
 		It("should configure the hostname mapping", func() {
-			contents, err := compiler.Compile(log, ip, "my-handle")
+			contents, err := compiler.Compile(log, []net.IP{ip}, "my-handle")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(contents)).To(ContainSubstring("123.124.126.128 my-handle"))
 		})
 
+		It("should not configure the IPv6 localhost mapping", func() {
+			contents, err := compiler.Compile(log, []net.IP{ip}, "my-handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).NotTo(ContainSubstring("::1"))
+		})
+
+		Context("when the container has an IPv6 address", func() {
+			It("should configure the IPv6 localhost and hostname mappings", func() {
+				contents, err := compiler.Compile(log, []net.IP{ip, net.ParseIP("fd00::2")}, "my-handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("127.0.0.1 localhost\n::1 localhost ip6-localhost ip6-loopback\n123.124.126.128 my-handle\nfd00::2 my-handle\n"))
+			})
+		})
+
 		Context("when handle is longer than 49 characters", func() {
 			It("should use the last 49 characters of it", func() {
-				contents, err := compiler.Compile(log, ip, "too-looooong-haaaaaaaaaaaaaannnnnndddle-1234456787889")
+				contents, err := compiler.Compile(log, []net.IP{ip}, "too-looooong-haaaaaaaaaaaaaannnnnndddle-1234456787889")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(contents)).To(ContainSubstring("123.124.126.128 looooong-haaaaaaaaaaaaaannnnnndddle-1234456787889"))
 			})
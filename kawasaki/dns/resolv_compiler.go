@@ -23,7 +23,7 @@ func (n *ResolvCompiler) Determine(resolvContents string, hostIP net.IP, pluginN
 }
 
 func parseResolvContents(resolvContents string, hostIP net.IP) []string {
-	loopbackNameserver := regexp.MustCompile(`^\s*nameserver\s+127\.0\.0\.\d+\s*$`)
+	loopbackNameserver := regexp.MustCompile(`^\s*nameserver\s+(127\.0\.0\.\d+|::1)\s*$`)
 	if loopbackNameserver.MatchString(resolvContents) {
 		return nameserverEntries([]net.IP{hostIP})
 	}
@@ -41,7 +41,7 @@ func parseResolvContents(resolvContents string, hostIP net.IP) []string {
 
 		if !strings.Contains(resolvEntry, "127.0.0.") {
 			nameserverFields := strings.Fields(resolvEntry)
-			if len(nameserverFields) != 2 {
+			if len(nameserverFields) != 2 || nameserverFields[1] == "::1" {
 				continue
 			}
 			entries = append(entries, nameserverEntry(nameserverFields[1]))
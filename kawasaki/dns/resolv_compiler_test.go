@@ -45,6 +45,14 @@ var _ = Describe("compiling the resolv.conf file", func() {
 			"nameserver 1.2.3.4\nnameserver 127.0.0.19\n", nil, nil, ips(),
 			nameservers("1.2.3.4"),
 		),
+		Entry("when the host nameservers contain IPv6 entries, it returns all but the IPv6 loopback",
+			"nameserver 1.2.3.4\nnameserver ::1\nnameserver fd00::53\n", nil, nil, ips(),
+			nameservers("1.2.3.4", "fd00::53"),
+		),
+		Entry("when the host nameservers consist of exactly the IPv6 loopback entry, it returns the host IP",
+			"nameserver ::1\n", nil, nil, ips(),
+			nameservers(hostIP.String()),
+		),
 		Entry("when the host nameservers consist of exactly one loopback entry, it returns the host IP",
 			"nameserver 127.0.0.19\n", nil, nil, ips(),
 			nameservers(hostIP.String()),
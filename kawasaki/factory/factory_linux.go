@@ -11,7 +11,9 @@ import (
 	"code.cloudfoundry.org/guardian/kawasaki/netns"
 )
 
-func NewDefaultConfigurer(ipt *iptables.IPTablesController, depotDir string) kawasaki.Configurer {
+// NewDefaultConfigurer returns a configurer for containers. The IPv6
+// controller is nil unless containers are given IPv6 addresses.
+func NewDefaultConfigurer(ipt, ipt6 *iptables.IPTablesController, depotDir string) kawasaki.Configurer {
 	resolvConfigurer := &kawasaki.ResolvConfigurer{
 		HostsFileCompiler: &dns.HostsFileCompiler{},
 		ResolvCompiler:    &dns.ResolvCompiler{},
@@ -30,10 +32,24 @@ func NewDefaultConfigurer(ipt *iptables.IPTablesController, depotDir string) kaw
 		FileOpener: netns.Opener(os.Open),
 	}
 
-	return kawasaki.NewConfigurer(
+	var instanceChainCreatorV6 kawasaki.InstanceChainCreator
+	if ipt6 != nil {
+		instanceChainCreatorV6 = iptables.NewInstanceChainCreator(ipt6)
+	}
+
+	return kawasaki.NewDualStackConfigurer(
 		resolvConfigurer,
 		hostConfigurer,
 		containerConfigurer,
 		iptables.NewInstanceChainCreator(ipt),
+		instanceChainCreatorV6,
 	)
 }
+
+func NewOrphanedBridges(interfacePrefix string, configStore kawasaki.ConfigStore) *kawasaki.OrphanedBridges {
+	return kawasaki.NewOrphanedBridges(&devices.Link{}, &devices.Bridge{}, interfacePrefix, configStore)
+}
+
+func NewOrphanedVeths(interfacePrefix string, configStore kawasaki.ConfigStore) *kawasaki.OrphanedVeths {
+	return kawasaki.NewOrphanedVeths(&devices.Link{}, &devices.Link{}, interfacePrefix, configStore)
+}
@@ -7,6 +7,14 @@ import (
 	"code.cloudfoundry.org/guardian/kawasaki/iptables"
 )
 
-func NewDefaultConfigurer(ipt *iptables.IPTablesController, depotDir string) kawasaki.Configurer {
+func NewDefaultConfigurer(ipt, ipt6 *iptables.IPTablesController, depotDir string) kawasaki.Configurer {
+	panic("not supported on this platform")
+}
+
+func NewOrphanedBridges(interfacePrefix string, configStore kawasaki.ConfigStore) *kawasaki.OrphanedBridges {
+	panic("not supported on this platform")
+}
+
+func NewOrphanedVeths(interfacePrefix string, configStore kawasaki.ConfigStore) *kawasaki.OrphanedVeths {
 	panic("not supported on this platform")
 }
@@ -1,6 +1,8 @@
 package iptables
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 )
@@ -22,7 +24,7 @@ func NewFirewallOpener(ruleTranslator RuleTranslator, iptables IPTables) *Firewa
 	}
 }
 
-func (f *FirewallOpener) Open(logger lager.Logger, instance, handle string, rule garden.NetOutRule) error {
+func (f *FirewallOpener) Open(ctx context.Context, logger lager.Logger, instance, handle string, rule garden.NetOutRule) error {
 	chain := f.iptables.InstanceChain(instance)
 	logger = logger.Session("prepend-filter-rule", lager.Data{
 		"rule":     rule,
@@ -38,7 +40,7 @@ func (f *FirewallOpener) Open(logger lager.Logger, instance, handle string, rule
 	}
 
 	for _, iptableRules := range iptableRules {
-		if err := f.iptables.PrependRule(chain, iptableRules); err != nil {
+		if err := f.iptables.PrependRule(ctx, chain, iptableRules); err != nil {
 			return err
 		}
 	}
@@ -46,7 +48,7 @@ func (f *FirewallOpener) Open(logger lager.Logger, instance, handle string, rule
 	return nil
 }
 
-func (f *FirewallOpener) BulkOpen(logger lager.Logger, instance, handle string, rules []garden.NetOutRule) error {
+func (f *FirewallOpener) BulkOpen(ctx context.Context, logger lager.Logger, instance, handle string, rules []garden.NetOutRule) error {
 	chain := f.iptables.InstanceChain(instance)
 	logger = logger.Session("prepend-filter-rule", lager.Data{
 		"rules":    rules,
@@ -66,5 +68,5 @@ func (f *FirewallOpener) BulkOpen(logger lager.Logger, instance, handle string,
 		collatedIPTablesRules = append(collatedIPTablesRules, iptablesRules...)
 	}
 
-	return f.iptables.BulkPrependRules(chain, collatedIPTablesRules)
+	return f.iptables.BulkPrependRules(ctx, chain, collatedIPTablesRules)
 }
@@ -1,6 +1,7 @@
 package iptables_test
 
 import (
+	"context"
 	"errors"
 
 	"code.cloudfoundry.org/garden"
@@ -41,7 +42,7 @@ var _ = Describe("FirewallOpenner", func() {
 	Describe("Open", func() {
 		It("builds the correct rules", func() {
 			rule := garden.NetOutRule{Protocol: garden.ProtocolUDP}
-			Expect(opener.Open(logger, "foo-bar-baz", "some-handle", rule)).To(Succeed())
+			Expect(opener.Open(context.Background(), logger, "foo-bar-baz", "some-handle", rule)).To(Succeed())
 			actualHandle, actualRule := fakeRuleTranslator.TranslateRuleArgsForCall(0)
 			Expect(actualHandle).To(Equal("some-handle"))
 			Expect(actualRule).To(Equal(rule))
@@ -53,7 +54,7 @@ var _ = Describe("FirewallOpenner", func() {
 			})
 
 			It("returns the error", func() {
-				Expect(opener.Open(logger, "foo-bar-baz", "some-handle", garden.NetOutRule{})).To(MatchError("failed to build rules"))
+				Expect(opener.Open(context.Background(), logger, "foo-bar-baz", "some-handle", garden.NetOutRule{})).To(MatchError("failed to build rules"))
 			})
 		})
 
@@ -68,20 +69,20 @@ var _ = Describe("FirewallOpenner", func() {
 			}
 			fakeRuleTranslator.TranslateRuleReturns(rules, nil)
 
-			Expect(opener.Open(logger, "foo-bar-baz", "some-handle", garden.NetOutRule{})).To(Succeed())
+			Expect(opener.Open(context.Background(), logger, "foo-bar-baz", "some-handle", garden.NetOutRule{})).To(Succeed())
 
 			Expect(fakeIPTablesController.PrependRuleCallCount()).To(Equal(2))
-			_, ruleA := fakeIPTablesController.PrependRuleArgsForCall(0)
+			_, _, ruleA := fakeIPTablesController.PrependRuleArgsForCall(0)
 			Expect(ruleA).To(Equal(rules[0]))
-			_, ruleB := fakeIPTablesController.PrependRuleArgsForCall(1)
+			_, _, ruleB := fakeIPTablesController.PrependRuleArgsForCall(1)
 			Expect(ruleB).To(Equal(rules[1]))
 		})
 
 		It("uses the correct chain name", func() {
-			Expect(opener.Open(logger, "foo-bar-baz", "some-handle", garden.NetOutRule{})).To(Succeed())
+			Expect(opener.Open(context.Background(), logger, "foo-bar-baz", "some-handle", garden.NetOutRule{})).To(Succeed())
 
 			Expect(fakeIPTablesController.PrependRuleCallCount()).To(Equal(1))
-			chainName, _ := fakeIPTablesController.PrependRuleArgsForCall(0)
+			_, chainName, _ := fakeIPTablesController.PrependRuleArgsForCall(0)
 			Expect(chainName).To(Equal("prefix-foo-bar-baz"))
 		})
 
@@ -91,7 +92,7 @@ var _ = Describe("FirewallOpenner", func() {
 			})
 
 			It("returns the error", func() {
-				Expect(opener.Open(logger, "foo-bar-baz", "some-handle", garden.NetOutRule{})).To(MatchError("i-lost-my-banana"))
+				Expect(opener.Open(context.Background(), logger, "foo-bar-baz", "some-handle", garden.NetOutRule{})).To(MatchError("i-lost-my-banana"))
 			})
 		})
 	})
@@ -107,7 +108,7 @@ var _ = Describe("FirewallOpenner", func() {
 		})
 
 		It("translates the rules", func() {
-			Expect(opener.BulkOpen(logger, "foo-bar-baz", "some-handle", rules)).To(Succeed())
+			Expect(opener.BulkOpen(context.Background(), logger, "foo-bar-baz", "some-handle", rules)).To(Succeed())
 			allRules := []garden.NetOutRule{}
 			for i := 0; i < fakeRuleTranslator.TranslateRuleCallCount(); i++ {
 				handle, rule := fakeRuleTranslator.TranslateRuleArgsForCall(i)
@@ -123,7 +124,7 @@ var _ = Describe("FirewallOpenner", func() {
 			})
 
 			It("returns the error", func() {
-				Expect(opener.BulkOpen(logger, "foo-bar-baz", "some-handle", rules)).To(MatchError("failed to build rules"))
+				Expect(opener.BulkOpen(context.Background(), logger, "foo-bar-baz", "some-handle", rules)).To(MatchError("failed to build rules"))
 			})
 		})
 
@@ -153,10 +154,10 @@ var _ = Describe("FirewallOpenner", func() {
 				return iptablesRules[i], nil
 			}
 
-			Expect(opener.BulkOpen(logger, "foo-bar-baz", "some-handle", rules)).To(Succeed())
+			Expect(opener.BulkOpen(context.Background(), logger, "foo-bar-baz", "some-handle", rules)).To(Succeed())
 
 			Expect(fakeIPTablesController.BulkPrependRulesCallCount()).To(Equal(1))
-			_, appendedIPTablesRules := fakeIPTablesController.BulkPrependRulesArgsForCall(0)
+			_, _, appendedIPTablesRules := fakeIPTablesController.BulkPrependRulesArgsForCall(0)
 			Expect(appendedIPTablesRules).To(HaveLen(4))
 			Expect(appendedIPTablesRules[0]).To(Equal(iptablesRules[0][0]))
 			Expect(appendedIPTablesRules[1]).To(Equal(iptablesRules[0][1]))
@@ -164,10 +165,19 @@ var _ = Describe("FirewallOpenner", func() {
 			Expect(appendedIPTablesRules[3]).To(Equal(iptablesRules[1][1]))
 		})
 
+		It("passes the context on to iptables", func() {
+			ctx := context.WithValue(context.Background(), "some-key", "some-value")
+			Expect(opener.BulkOpen(ctx, logger, "foo-bar-baz", "some-handle", rules)).To(Succeed())
+
+			Expect(fakeIPTablesController.BulkPrependRulesCallCount()).To(Equal(1))
+			actualCtx, _, _ := fakeIPTablesController.BulkPrependRulesArgsForCall(0)
+			Expect(actualCtx).To(Equal(ctx))
+		})
+
 		It("prepends to the correct chain name", func() {
-			Expect(opener.BulkOpen(logger, "foo-bar-baz", "some-handle", rules)).To(Succeed())
+			Expect(opener.BulkOpen(context.Background(), logger, "foo-bar-baz", "some-handle", rules)).To(Succeed())
 			Expect(fakeIPTablesController.BulkPrependRulesCallCount()).To(Equal(1))
-			chainName, _ := fakeIPTablesController.BulkPrependRulesArgsForCall(0)
+			_, chainName, _ := fakeIPTablesController.BulkPrependRulesArgsForCall(0)
 			Expect(chainName).To(Equal("prefix-foo-bar-baz"))
 		})
 
@@ -177,7 +187,7 @@ var _ = Describe("FirewallOpenner", func() {
 			})
 
 			It("returns the error", func() {
-				Expect(opener.BulkOpen(logger, "foo-bar-baz", "some-handle", rules)).To(MatchError("i-lost-my-banana"))
+				Expect(opener.BulkOpen(context.Background(), logger, "foo-bar-baz", "some-handle", rules)).To(MatchError("i-lost-my-banana"))
 			})
 		})
 	})
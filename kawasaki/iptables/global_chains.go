@@ -1,6 +1,7 @@
 package iptables
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -22,6 +23,17 @@ const SetupScript = `
 	nat_postrouting_chain="${GARDEN_IPTABLES_NAT_POSTROUTING_CHAIN}"
 	nat_instance_prefix="${GARDEN_IPTABLES_NAT_INSTANCE_PREFIX}"
 	iptables_bin="${GARDEN_IPTABLES_BIN}"
+	ip_version="${GARDEN_IP_VERSION:-4}"
+
+	if [ "${ip_version}" = "6" ]; then
+	ip_route="ip -6 route"
+	reject_with="icmp6-adm-prohibited"
+	ip_forward="/proc/sys/net/ipv6/conf/all/forwarding"
+	else
+	ip_route="ip route"
+	reject_with="icmp-host-prohibited"
+	ip_forward="/proc/sys/net/ipv4/ip_forward"
+	fi
 
 	function teardown_deprecated_rules() {
 		# Remove jump to garden-dispatch from INPUT
@@ -102,7 +114,7 @@ const SetupScript = `
 		teardown_filter
 
 		# Determine interface device to the outside
-		default_interface=$(ip route show | grep default | cut -d' ' -f5 | head -1)
+		default_interface=$(${ip_route} show | grep default | cut -d' ' -f5 | head -1)
 
 		# Create, or empty existing, filter input chain
 		${iptables_bin} -w -N ${filter_input_chain} 2> /dev/null || ${iptables_bin} -w -F ${filter_input_chain}
@@ -115,7 +127,7 @@ const SetupScript = `
 		${iptables_bin} -w -A ${filter_input_chain} -m conntrack --ctstate ESTABLISHED,RELATED --jump ACCEPT
 
 		if [ "${GARDEN_IPTABLES_ALLOW_HOST_ACCESS}" != "true" ]; then
-		${iptables_bin} -w -A ${filter_input_chain} --jump REJECT --reject-with icmp-host-prohibited
+		${iptables_bin} -w -A ${filter_input_chain} --jump REJECT --reject-with ${reject_with}
 		else
 		${iptables_bin} -w -A ${filter_input_chain} --jump ACCEPT
 		fi
@@ -204,7 +216,7 @@ const SetupScript = `
 	setup_nat
 
 	# Enable forwarding
-	echo 1 > /proc/sys/net/ipv4/ip_forward
+	echo 1 > ${ip_forward}
 	;;
 	teardown)
 	teardown_filter
@@ -256,6 +268,9 @@ func (s Starter) Start() error {
 			fmt.Sprintf("GARDEN_NETWORK_INTERFACE_PREFIX=%s", s.nicPrefix),
 			fmt.Sprintf("GARDEN_IPTABLES_ALLOW_HOST_ACCESS=%t", s.allowHostAccess),
 		}
+		if s.iptables.ipv6 {
+			cmd.Env = append(cmd.Env, "GARDEN_IP_VERSION=6")
+		}
 
 		if err := s.iptables.run("setup-global-chains", cmd); err != nil {
 			return fmt.Errorf("setting up default chains: %s", err)
@@ -269,7 +284,7 @@ func (s Starter) Start() error {
 	}
 
 	for _, n := range s.denyNetworks {
-		if err := s.iptables.appendRule(s.iptables.defaultChain, rejectRule(n)); err != nil {
+		if err := s.iptables.appendRule(context.Background(), s.iptables.defaultChain, rejectRule(n)); err != nil {
 			return err
 		}
 	}
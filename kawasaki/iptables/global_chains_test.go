@@ -170,6 +170,44 @@ var _ = Describe("Setup", func() {
 			})
 		})
 
+		Context("when the chains are for IPv6", func() {
+			JustBeforeEach(func() {
+				starter = iptables.NewStarter(
+					iptables.NewIPv6("/sbin/ip6tables", "/sbin/ip6tables-restore", fakeRunner, NewFakeLocksmith(), "prefix-"),
+					true,
+					"the-nic-prefix",
+					denyNetworks,
+					true,
+					lagertest.NewTestLogger("global_chains_test"),
+				)
+			})
+
+			It("runs the setup script with ip6tables, telling it the IP version", func() {
+				Expect(starter.Start()).To(Succeed())
+
+				Expect(fakeRunner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+					Path: "bash",
+					Args: []string{"-c", iptables.SetupScript},
+					Env: []string{
+						fmt.Sprintf("PATH=%s", os.Getenv("PATH")),
+						"ACTION=setup",
+
+						"GARDEN_IPTABLES_BIN=/sbin/ip6tables",
+						"GARDEN_IPTABLES_FILTER_INPUT_CHAIN=prefix-input",
+						"GARDEN_IPTABLES_FILTER_FORWARD_CHAIN=prefix-forward",
+						"GARDEN_IPTABLES_FILTER_DEFAULT_CHAIN=prefix-default",
+						"GARDEN_IPTABLES_FILTER_INSTANCE_PREFIX=prefix-instance-",
+						"GARDEN_IPTABLES_NAT_PREROUTING_CHAIN=prefix-prerouting",
+						"GARDEN_IPTABLES_NAT_POSTROUTING_CHAIN=prefix-postrouting",
+						"GARDEN_IPTABLES_NAT_INSTANCE_PREFIX=prefix-instance-",
+						"GARDEN_NETWORK_INTERFACE_PREFIX=the-nic-prefix",
+						"GARDEN_IPTABLES_ALLOW_HOST_ACCESS=true",
+						"GARDEN_IP_VERSION=6",
+					},
+				}))
+			})
+		})
+
 		Context("when the input chain exists", func() {
 			BeforeEach(func() {
 				fakeRunner.WhenRunning(fake_command_runner.CommandSpec{
@@ -1,6 +1,7 @@
 package iptables
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os/exec"
@@ -18,61 +19,61 @@ func NewInstanceChainCreator(iptables *IPTablesController) *InstanceChainCreator
 	}
 }
 
-func (cc *InstanceChainCreator) Create(logger lager.Logger, handle, instanceId, bridgeName string, ip net.IP, network *net.IPNet) error {
+func (cc *InstanceChainCreator) Create(ctx context.Context, logger lager.Logger, handle, instanceId, bridgeName string, ip net.IP, network *net.IPNet) error {
 	instanceChain := cc.iptables.InstanceChain(instanceId)
 
-	if err := cc.iptables.CreateChain("nat", instanceChain); err != nil {
+	if err := cc.iptables.createChain(ctx, "nat", instanceChain); err != nil {
 		return err
 	}
 
 	// Bind nat instance chain to nat prerouting chain
-	cmd := exec.Command(cc.iptables.iptablesBinPath, "--wait", "--table", "nat", "-A", cc.iptables.preroutingChain, "--jump", instanceChain, "-m", "comment", "--comment", handle)
-	if err := cc.iptables.run("create-instance-chains", cmd); err != nil {
+	cmd := exec.CommandContext(ctx, cc.iptables.iptablesBinPath, "--wait", "--table", "nat", "-A", cc.iptables.preroutingChain, "--jump", instanceChain, "-m", "comment", "--comment", handle)
+	if err := cc.iptables.runContext(ctx, "create-instance-chains", cmd); err != nil {
 		return err
 	}
 
 	// Enable NAT for traffic coming from containers
-	cmd = exec.Command("sh", "-c", fmt.Sprintf(
+	cmd = exec.CommandContext(ctx, "sh", "-c", fmt.Sprintf(
 		`(%s --wait --table nat -S %s | grep "\-j MASQUERADE\b" | grep -q -F -- "-s %s") || %s --wait --table nat -A %s --source %s ! --destination %s --jump MASQUERADE -m comment --comment %s`,
 		cc.iptables.iptablesBinPath, cc.iptables.postroutingChain, network.String(), cc.iptables.iptablesBinPath, cc.iptables.postroutingChain,
 		network.String(), network.String(), handle,
 	))
-	if err := cc.iptables.run("create-instance-chains", cmd); err != nil {
+	if err := cc.iptables.runContext(ctx, "create-instance-chains", cmd); err != nil {
 		return err
 	}
 
 	// Create filter instance chain
-	if err := cc.iptables.CreateChain("filter", instanceChain); err != nil {
+	if err := cc.iptables.createChain(ctx, "filter", instanceChain); err != nil {
 		return err
 	}
 
 	// Allow intra-subnet traffic (Linux ethernet bridging goes through ip stack)
-	cmd = exec.Command(cc.iptables.iptablesBinPath, "--wait", "-A", instanceChain, "-s", network.String(), "-d", network.String(), "-j", "ACCEPT", "-m", "comment", "--comment", handle)
-	if err := cc.iptables.run("create-instance-chains", cmd); err != nil {
+	cmd = exec.CommandContext(ctx, cc.iptables.iptablesBinPath, "--wait", "-A", instanceChain, "-s", network.String(), "-d", network.String(), "-j", "ACCEPT", "-m", "comment", "--comment", handle)
+	if err := cc.iptables.runContext(ctx, "create-instance-chains", cmd); err != nil {
 		return err
 	}
 
 	// Otherwise, use the default filter chain
-	cmd = exec.Command(cc.iptables.iptablesBinPath, "--wait", "-A", instanceChain, "--goto", cc.iptables.defaultChain, "-m", "comment", "--comment", handle)
-	if err := cc.iptables.run("create-instance-chains", cmd); err != nil {
+	cmd = exec.CommandContext(ctx, cc.iptables.iptablesBinPath, "--wait", "-A", instanceChain, "--goto", cc.iptables.defaultChain, "-m", "comment", "--comment", handle)
+	if err := cc.iptables.runContext(ctx, "create-instance-chains", cmd); err != nil {
 		return err
 	}
 
 	// Bind filter instance chain to filter forward chain
-	cmd = exec.Command(cc.iptables.iptablesBinPath, "--wait", "-I", cc.iptables.forwardChain, "2", "--in-interface", bridgeName, "--source", ip.String(), "--goto", instanceChain, "-m", "comment", "--comment", handle)
-	if err := cc.iptables.run("create-instance-chains", cmd); err != nil {
+	cmd = exec.CommandContext(ctx, cc.iptables.iptablesBinPath, "--wait", "-I", cc.iptables.forwardChain, "2", "--in-interface", bridgeName, "--source", ip.String(), "--goto", instanceChain, "-m", "comment", "--comment", handle)
+	if err := cc.iptables.runContext(ctx, "create-instance-chains", cmd); err != nil {
 		return err
 	}
 
 	// Create Logging Chain
-	return cc.createLoggingChain(logger, handle, instanceId)
+	return cc.createLoggingChain(ctx, logger, handle, instanceId)
 }
 
-func (cc *InstanceChainCreator) createLoggingChain(logger lager.Logger, handle, instanceId string) error {
+func (cc *InstanceChainCreator) createLoggingChain(ctx context.Context, logger lager.Logger, handle, instanceId string) error {
 	instanceChain := cc.iptables.InstanceChain(instanceId)
 	loggingChain := fmt.Sprintf("%s-log", instanceChain)
 
-	if err := cc.iptables.CreateChain("filter", loggingChain); err != nil {
+	if err := cc.iptables.createChain(ctx, "filter", loggingChain); err != nil {
 		return err
 	}
 
@@ -82,13 +83,13 @@ func (cc *InstanceChainCreator) createLoggingChain(logger lager.Logger, handle,
 	}
 	logPrefix = logPrefix + " "
 
-	cmd := exec.Command(cc.iptables.iptablesBinPath, "--wait", "-A", loggingChain, "-m", "conntrack", "--ctstate", "NEW,UNTRACKED,INVALID", "--protocol", "all", "--jump", "LOG", "--log-prefix", logPrefix, "-m", "comment", "--comment", handle)
-	if err := cc.iptables.run("create-instance-chains", cmd); err != nil {
+	cmd := exec.CommandContext(ctx, cc.iptables.iptablesBinPath, "--wait", "-A", loggingChain, "-m", "conntrack", "--ctstate", "NEW,UNTRACKED,INVALID", "--protocol", "all", "--jump", "LOG", "--log-prefix", logPrefix, "-m", "comment", "--comment", handle)
+	if err := cc.iptables.runContext(ctx, "create-instance-chains", cmd); err != nil {
 		return err
 	}
 
-	cmd = exec.Command(cc.iptables.iptablesBinPath, "--wait", "-A", loggingChain, "--jump", "RETURN", "-m", "comment", "--comment", handle)
-	if err := cc.iptables.run("create-instance-chains", cmd); err != nil {
+	cmd = exec.CommandContext(ctx, cc.iptables.iptablesBinPath, "--wait", "-A", loggingChain, "--jump", "RETURN", "-m", "comment", "--comment", handle)
+	if err := cc.iptables.runContext(ctx, "create-instance-chains", cmd); err != nil {
 		return err
 	}
 
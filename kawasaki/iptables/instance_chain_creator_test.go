@@ -1,10 +1,12 @@
 package iptables_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
 	"os/exec"
+	"time"
 
 	"code.cloudfoundry.org/commandrunner/fake_command_runner"
 	"code.cloudfoundry.org/guardian/kawasaki/iptables"
@@ -19,13 +21,14 @@ import (
 
 var _ = Describe("Create", func() {
 	var (
-		fakeRunner *fake_command_runner.FakeCommandRunner
-		creator    *iptables.InstanceChainCreator
-		bridgeName string
-		ip         net.IP
-		network    *net.IPNet
-		logger     lager.Logger
-		handle     string
+		fakeRunner    *fake_command_runner.FakeCommandRunner
+		fakeLocksmith *FakeLocksmith
+		creator       *iptables.InstanceChainCreator
+		bridgeName    string
+		ip            net.IP
+		network       *net.IPNet
+		logger        lager.Logger
+		handle        string
 	)
 
 	BeforeEach(func() {
@@ -39,7 +42,7 @@ var _ = Describe("Create", func() {
 		ip, network, err = net.ParseCIDR("1.2.3.4/28")
 		Expect(err).NotTo(HaveOccurred())
 
-		fakeLocksmith := NewFakeLocksmith()
+		fakeLocksmith = NewFakeLocksmith()
 		creator = iptables.NewInstanceChainCreator(
 			iptables.New("/sbin/iptables", "/sbin/iptables-restore", fakeRunner, fakeLocksmith, "prefix-"),
 		)
@@ -114,10 +117,23 @@ var _ = Describe("Create", func() {
 		})
 
 		It("should set up the chain", func() {
-			Expect(creator.Create(logger, handle, "some-id", bridgeName, ip, network)).To(Succeed())
+			Expect(creator.Create(context.Background(), logger, handle, "some-id", bridgeName, ip, network)).To(Succeed())
 			Expect(fakeRunner).To(HaveExecutedSerially(specs...))
 		})
 
+		Context("when the iptables lock is held until the context is done", func() {
+			It("gives up waiting for it", func() {
+				_, err := fakeLocksmith.Lock(iptables.LockKey)
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+
+				Expect(creator.Create(ctx, logger, handle, "some-id", bridgeName, ip, network)).To(MatchError(context.DeadlineExceeded))
+				Expect(fakeRunner.ExecutedCommands()).To(BeEmpty())
+			})
+		})
+
 		DescribeTable("iptables failures",
 			func(specIndex int, errorString string) {
 				fakeRunner.WhenRunning(specs[specIndex], func(cmd *exec.Cmd) error {
@@ -125,7 +141,7 @@ var _ = Describe("Create", func() {
 					return errors.New("Exit status blah")
 				})
 
-				Expect(creator.Create(logger, handle, "some-id", bridgeName, ip, network)).To(MatchError(errorString))
+				Expect(creator.Create(context.Background(), logger, handle, "some-id", bridgeName, ip, network)).To(MatchError(errorString))
 			},
 			Entry("create nat instance chain", 0, "iptables: create-instance-chains: iptables failed"),
 			Entry("bind nat instance chain to nat prerouting chain", 1, "iptables: create-instance-chains: iptables failed"),
@@ -2,6 +2,7 @@ package iptables
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -28,9 +29,10 @@ type IPTables interface {
 	DeleteChain(table, chain string) error
 	FlushChain(table, chain string) error
 	DeleteChainReferences(table, targetChain, referencedChain string) error
-	PrependRule(chain string, rule Rule) error
-	BulkPrependRules(chain string, rules []Rule) error
+	PrependRule(ctx context.Context, chain string, rule Rule) error
+	BulkPrependRules(ctx context.Context, chain string, rules []Rule) error
 	InstanceChain(instanceId string) string
+	InstanceChains() ([]string, error)
 }
 
 type IPTablesController struct {
@@ -39,6 +41,7 @@ type IPTablesController struct {
 	iptablesBinPath                                                                                string
 	iptablesRestoreBinPath                                                                         string
 	preroutingChain, postroutingChain, inputChain, forwardChain, defaultChain, instanceChainPrefix string
+	ipv6                                                                                           bool
 }
 
 type Chains struct {
@@ -61,8 +64,20 @@ func New(iptablesBinPath, iptablesRestoreBinPath string, runner commandrunner.Co
 	}
 }
 
+// NewIPv6 returns a controller for the IPv6 rules of containers, which are
+// kept by ip6tables in chains with the same names as the IPv4 ones
+func NewIPv6(ip6tablesBinPath, ip6tablesRestoreBinPath string, runner commandrunner.CommandRunner, locksmith Locksmith, chainPrefix string) *IPTablesController {
+	iptables := New(ip6tablesBinPath, ip6tablesRestoreBinPath, runner, locksmith, chainPrefix)
+	iptables.ipv6 = true
+	return iptables
+}
+
 func (iptables *IPTablesController) CreateChain(table, chain string) error {
-	return iptables.run("create-instance-chains", exec.Command(iptables.iptablesBinPath, "--wait", "--table", table, "-N", chain))
+	return iptables.createChain(context.Background(), table, chain)
+}
+
+func (iptables *IPTablesController) createChain(ctx context.Context, table, chain string) error {
+	return iptables.runContext(ctx, "create-instance-chains", exec.CommandContext(ctx, iptables.iptablesBinPath, "--wait", "--table", table, "-N", chain))
 }
 
 func (iptables *IPTablesController) DeleteChain(table, chain string) error {
@@ -89,11 +104,11 @@ func (iptables *IPTablesController) DeleteChainReferences(table, targetChain, re
 	return iptables.run("delete-referenced-chains", exec.Command("sh", "-c", shellCmd))
 }
 
-func (iptables *IPTablesController) PrependRule(chain string, rule Rule) error {
-	return iptables.run("prepend-rule", exec.Command(iptables.iptablesBinPath, append([]string{"-w", "-I", chain, "1"}, rule.Flags(chain)...)...))
+func (iptables *IPTablesController) PrependRule(ctx context.Context, chain string, rule Rule) error {
+	return iptables.runContext(ctx, "prepend-rule", exec.CommandContext(ctx, iptables.iptablesBinPath, append([]string{"-w", "-I", chain, "1"}, rule.Flags(chain)...)...))
 }
 
-func (iptables *IPTablesController) BulkPrependRules(chain string, rules []Rule) error {
+func (iptables *IPTablesController) BulkPrependRules(ctx context.Context, chain string, rules []Rule) error {
 	if len(rules) == 0 {
 		return nil
 	}
@@ -107,22 +122,63 @@ func (iptables *IPTablesController) BulkPrependRules(chain string, rules []Rule)
 	}
 	in.WriteString("COMMIT\n")
 
-	cmd := exec.Command(iptables.iptablesRestoreBinPath, "--noflush")
+	cmd := exec.CommandContext(ctx, iptables.iptablesRestoreBinPath, "--noflush")
 	cmd.Stdin = in
 
-	return iptables.run("bulk-prepend-rules", cmd)
+	return iptables.runContext(ctx, "bulk-prepend-rules", cmd)
 }
 
 func (iptables *IPTablesController) InstanceChain(instanceId string) string {
 	return iptables.instanceChainPrefix + instanceId
 }
 
-func (iptables *IPTablesController) run(action string, cmd *exec.Cmd) (err error) {
+// InstanceChains returns the ids of the instances which have a filter chain,
+// whether or not they still belong to a container
+func (iptables *IPTablesController) InstanceChains() ([]string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(iptables.iptablesBinPath, "--wait", "--table", "filter", "-S")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := iptables.runLocked(context.Background(), "list-instance-chains", cmd, &stderr); err != nil {
+		return nil, err
+	}
+
+	instances := []string{}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "-N" || !strings.HasPrefix(fields[1], iptables.instanceChainPrefix) {
+			continue
+		}
+
+		if strings.HasSuffix(fields[1], "-log") {
+			continue
+		}
+
+		instances = append(instances, strings.TrimPrefix(fields[1], iptables.instanceChainPrefix))
+	}
+
+	return instances, nil
+}
+
+func (iptables *IPTablesController) run(action string, cmd *exec.Cmd) error {
+	return iptables.runContext(context.Background(), action, cmd)
+}
+
+// runContext is like run, but gives up waiting for the iptables lock once ctx
+// is done. The command should be bound to the same context.
+func (iptables *IPTablesController) runContext(ctx context.Context, action string, cmd *exec.Cmd) error {
 	var buff bytes.Buffer
 	cmd.Stdout = &buff
 	cmd.Stderr = &buff
 
-	u, err := iptables.locksmith.Lock(LockKey)
+	return iptables.runLocked(ctx, action, cmd, &buff)
+}
+
+// runLocked runs the command while holding the iptables lock, including the
+// contents of output in the error if it fails
+func (iptables *IPTablesController) runLocked(ctx context.Context, action string, cmd *exec.Cmd, output *bytes.Buffer) (err error) {
+	u, err := iptables.lock(ctx)
 	if err != nil {
 		return err
 	}
@@ -138,12 +194,48 @@ func (iptables *IPTablesController) run(action string, cmd *exec.Cmd) (err error
 	}()
 
 	if err := iptables.runner.Run(cmd); err != nil {
-		return fmt.Errorf("iptables: %s: %s", action, buff.String())
+		if ctx.Err() != nil {
+			return fmt.Errorf("iptables: %s: %s", action, ctx.Err())
+		}
+
+		return fmt.Errorf("iptables: %s: %s", action, output.String())
 	}
 
 	return nil
 }
 
-func (iptables *IPTablesController) appendRule(chain string, rule Rule) error {
-	return iptables.run("append-rule", exec.Command(iptables.iptablesBinPath, append([]string{"-w", "-A", chain}, rule.Flags(chain)...)...))
+// lock takes the iptables lock, giving up once ctx is done. A lock which is
+// only taken after that is released straight away.
+func (iptables *IPTablesController) lock(ctx context.Context) (locksmith.Unlocker, error) {
+	if ctx.Done() == nil {
+		return iptables.locksmith.Lock(LockKey)
+	}
+
+	type locked struct {
+		unlocker locksmith.Unlocker
+		err      error
+	}
+
+	result := make(chan locked, 1)
+	go func() {
+		u, err := iptables.locksmith.Lock(LockKey)
+		result <- locked{unlocker: u, err: err}
+	}()
+
+	select {
+	case l := <-result:
+		return l.unlocker, l.err
+	case <-ctx.Done():
+		go func() {
+			if l := <-result; l.err == nil {
+				l.unlocker.Unlock()
+			}
+		}()
+
+		return nil, ctx.Err()
+	}
+}
+
+func (iptables *IPTablesController) appendRule(ctx context.Context, chain string, rule Rule) error {
+	return iptables.runContext(ctx, "append-rule", exec.CommandContext(ctx, iptables.iptablesBinPath, append([]string{"-w", "-A", chain}, rule.Flags(chain)...)...))
 }
@@ -1,6 +1,7 @@
 package iptables_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os/exec"
@@ -89,8 +90,8 @@ var _ = Describe("IPTables controller", func() {
 
 			Expect(iptablesController.CreateChain("filter", "test-chain")).To(Succeed())
 
-			Expect(iptablesController.PrependRule("test-chain", fakeTCPRule)).To(Succeed())
-			Expect(iptablesController.PrependRule("test-chain", fakeUDPRule)).To(Succeed())
+			Expect(iptablesController.PrependRule(context.Background(), "test-chain", fakeTCPRule)).To(Succeed())
+			Expect(iptablesController.PrependRule(context.Background(), "test-chain", fakeUDPRule)).To(Succeed())
 
 			buff := gbytes.NewBuffer()
 			sess, err := gexec.Start(wrapCmdInNs(netnsName, exec.Command("iptables", "-S", "test-chain")), buff, GinkgoWriter)
@@ -103,7 +104,7 @@ var _ = Describe("IPTables controller", func() {
 			fakeRule := new(fakes.FakeRule)
 			fakeRule.FlagsReturns([]string{})
 
-			Expect(iptablesController.PrependRule("test-chain", fakeRule)).NotTo(Succeed())
+			Expect(iptablesController.PrependRule(context.Background(), "test-chain", fakeRule)).NotTo(Succeed())
 		})
 	})
 
@@ -115,7 +116,7 @@ var _ = Describe("IPTables controller", func() {
 			fakeUDPRule.FlagsReturns([]string{"--protocol", "udp"})
 
 			Expect(iptablesController.CreateChain("filter", "test-chain")).To(Succeed())
-			Expect(iptablesController.BulkPrependRules("test-chain", []iptables.Rule{
+			Expect(iptablesController.BulkPrependRules(context.Background(), "test-chain", []iptables.Rule{
 				fakeTCPRule,
 				fakeUDPRule,
 			})).To(Succeed())
@@ -131,12 +132,12 @@ var _ = Describe("IPTables controller", func() {
 			fakeRule := new(fakes.FakeRule)
 			fakeRule.FlagsReturns([]string{"--protocol", "tcp"})
 
-			Expect(iptablesController.BulkPrependRules("test-chain", []iptables.Rule{fakeRule})).NotTo(Succeed())
+			Expect(iptablesController.BulkPrependRules(context.Background(), "test-chain", []iptables.Rule{fakeRule})).NotTo(Succeed())
 		})
 
 		Context("when there are no rules passed", func() {
 			It("does nothing", func() {
-				Expect(iptablesController.BulkPrependRules("test-chain", []iptables.Rule{})).To(Succeed())
+				Expect(iptablesController.BulkPrependRules(context.Background(), "test-chain", []iptables.Rule{})).To(Succeed())
 				Expect(fakeRunner.ExecutedCommands()).To(BeZero())
 			})
 		})
@@ -176,6 +177,19 @@ var _ = Describe("IPTables controller", func() {
 		})
 	})
 
+	Describe("InstanceChains", func() {
+		BeforeEach(func() {
+			Expect(iptablesController.CreateChain("filter", iptablesController.InstanceChain("instance1"))).To(Succeed())
+			Expect(iptablesController.CreateChain("filter", iptablesController.InstanceChain("instance1")+"-log")).To(Succeed())
+			Expect(iptablesController.CreateChain("filter", iptablesController.InstanceChain("instance2"))).To(Succeed())
+			Expect(iptablesController.CreateChain("filter", "test-chain")).To(Succeed())
+		})
+
+		It("returns the ids of the instances with a filter chain", func() {
+			Expect(iptablesController.InstanceChains()).To(ConsistOf("instance1", "instance2"))
+		})
+	})
+
 	Describe("FlushChain", func() {
 		var table string
 
@@ -302,7 +316,7 @@ var _ = Describe("IPTables controller", func() {
 		Context("when running an iptables command fails", func() {
 			It("still unlocks", func(done Done) {
 				// this is going to fail, because the chain does not exist
-				Expect(iptablesController.PrependRule("non-existent-chain", iptables.SingleFilterRule{})).NotTo(Succeed())
+				Expect(iptablesController.PrependRule(context.Background(), "non-existent-chain", iptables.SingleFilterRule{})).NotTo(Succeed())
 				Expect(iptablesController.CreateChain("filter", "test-chain-2")).To(Succeed())
 				close(done)
 			}, 2.0)
@@ -310,7 +324,7 @@ var _ = Describe("IPTables controller", func() {
 
 		Context("when running an iptables command panics", func() {
 			It("still unlocks", func(done Done) {
-				Expect(func() { iptablesController.PrependRule("panic", iptables.SingleFilterRule{}) }).To(Panic())
+				Expect(func() { iptablesController.PrependRule(context.Background(), "panic", iptables.SingleFilterRule{}) }).To(Panic())
 				Expect(iptablesController.CreateChain("filter", "test-chain-2")).To(Succeed())
 				close(done)
 			}, 2.0)
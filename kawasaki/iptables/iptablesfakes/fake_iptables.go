@@ -2,6 +2,7 @@
 package iptablesfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/guardian/kawasaki/iptables"
@@ -57,9 +58,10 @@ type FakeIPTables struct {
 	deleteChainReferencesReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PrependRuleStub        func(chain string, rule iptables.Rule) error
+	PrependRuleStub        func(ctx context.Context, chain string, rule iptables.Rule) error
 	prependRuleMutex       sync.RWMutex
 	prependRuleArgsForCall []struct {
+		ctx   context.Context
 		chain string
 		rule  iptables.Rule
 	}
@@ -69,9 +71,10 @@ type FakeIPTables struct {
 	prependRuleReturnsOnCall map[int]struct {
 		result1 error
 	}
-	BulkPrependRulesStub        func(chain string, rules []iptables.Rule) error
+	BulkPrependRulesStub        func(ctx context.Context, chain string, rules []iptables.Rule) error
 	bulkPrependRulesMutex       sync.RWMutex
 	bulkPrependRulesArgsForCall []struct {
+		ctx   context.Context
 		chain string
 		rules []iptables.Rule
 	}
@@ -92,6 +95,17 @@ type FakeIPTables struct {
 	instanceChainReturnsOnCall map[int]struct {
 		result1 string
 	}
+	InstanceChainsStub        func() ([]string, error)
+	instanceChainsMutex       sync.RWMutex
+	instanceChainsArgsForCall []struct{}
+	instanceChainsReturns     struct {
+		result1 []string
+		result2 error
+	}
+	instanceChainsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -293,17 +307,18 @@ func (fake *FakeIPTables) DeleteChainReferencesReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
-func (fake *FakeIPTables) PrependRule(chain string, rule iptables.Rule) error {
+func (fake *FakeIPTables) PrependRule(ctx context.Context, chain string, rule iptables.Rule) error {
 	fake.prependRuleMutex.Lock()
 	ret, specificReturn := fake.prependRuleReturnsOnCall[len(fake.prependRuleArgsForCall)]
 	fake.prependRuleArgsForCall = append(fake.prependRuleArgsForCall, struct {
+		ctx   context.Context
 		chain string
 		rule  iptables.Rule
-	}{chain, rule})
-	fake.recordInvocation("PrependRule", []interface{}{chain, rule})
+	}{ctx, chain, rule})
+	fake.recordInvocation("PrependRule", []interface{}{ctx, chain, rule})
 	fake.prependRuleMutex.Unlock()
 	if fake.PrependRuleStub != nil {
-		return fake.PrependRuleStub(chain, rule)
+		return fake.PrependRuleStub(ctx, chain, rule)
 	}
 	if specificReturn {
 		return ret.result1
@@ -317,10 +332,10 @@ func (fake *FakeIPTables) PrependRuleCallCount() int {
 	return len(fake.prependRuleArgsForCall)
 }
 
-func (fake *FakeIPTables) PrependRuleArgsForCall(i int) (string, iptables.Rule) {
+func (fake *FakeIPTables) PrependRuleArgsForCall(i int) (context.Context, string, iptables.Rule) {
 	fake.prependRuleMutex.RLock()
 	defer fake.prependRuleMutex.RUnlock()
-	return fake.prependRuleArgsForCall[i].chain, fake.prependRuleArgsForCall[i].rule
+	return fake.prependRuleArgsForCall[i].ctx, fake.prependRuleArgsForCall[i].chain, fake.prependRuleArgsForCall[i].rule
 }
 
 func (fake *FakeIPTables) PrependRuleReturns(result1 error) {
@@ -342,7 +357,7 @@ func (fake *FakeIPTables) PrependRuleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeIPTables) BulkPrependRules(chain string, rules []iptables.Rule) error {
+func (fake *FakeIPTables) BulkPrependRules(ctx context.Context, chain string, rules []iptables.Rule) error {
 	var rulesCopy []iptables.Rule
 	if rules != nil {
 		rulesCopy = make([]iptables.Rule, len(rules))
@@ -351,13 +366,14 @@ func (fake *FakeIPTables) BulkPrependRules(chain string, rules []iptables.Rule)
 	fake.bulkPrependRulesMutex.Lock()
 	ret, specificReturn := fake.bulkPrependRulesReturnsOnCall[len(fake.bulkPrependRulesArgsForCall)]
 	fake.bulkPrependRulesArgsForCall = append(fake.bulkPrependRulesArgsForCall, struct {
+		ctx   context.Context
 		chain string
 		rules []iptables.Rule
-	}{chain, rulesCopy})
-	fake.recordInvocation("BulkPrependRules", []interface{}{chain, rulesCopy})
+	}{ctx, chain, rulesCopy})
+	fake.recordInvocation("BulkPrependRules", []interface{}{ctx, chain, rulesCopy})
 	fake.bulkPrependRulesMutex.Unlock()
 	if fake.BulkPrependRulesStub != nil {
-		return fake.BulkPrependRulesStub(chain, rules)
+		return fake.BulkPrependRulesStub(ctx, chain, rules)
 	}
 	if specificReturn {
 		return ret.result1
@@ -371,10 +387,10 @@ func (fake *FakeIPTables) BulkPrependRulesCallCount() int {
 	return len(fake.bulkPrependRulesArgsForCall)
 }
 
-func (fake *FakeIPTables) BulkPrependRulesArgsForCall(i int) (string, []iptables.Rule) {
+func (fake *FakeIPTables) BulkPrependRulesArgsForCall(i int) (context.Context, string, []iptables.Rule) {
 	fake.bulkPrependRulesMutex.RLock()
 	defer fake.bulkPrependRulesMutex.RUnlock()
-	return fake.bulkPrependRulesArgsForCall[i].chain, fake.bulkPrependRulesArgsForCall[i].rules
+	return fake.bulkPrependRulesArgsForCall[i].ctx, fake.bulkPrependRulesArgsForCall[i].chain, fake.bulkPrependRulesArgsForCall[i].rules
 }
 
 func (fake *FakeIPTables) BulkPrependRulesReturns(result1 error) {
@@ -444,6 +460,49 @@ func (fake *FakeIPTables) InstanceChainReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeIPTables) InstanceChains() ([]string, error) {
+	fake.instanceChainsMutex.Lock()
+	ret, specificReturn := fake.instanceChainsReturnsOnCall[len(fake.instanceChainsArgsForCall)]
+	fake.instanceChainsArgsForCall = append(fake.instanceChainsArgsForCall, struct{}{})
+	fake.recordInvocation("InstanceChains", []interface{}{})
+	fake.instanceChainsMutex.Unlock()
+	if fake.InstanceChainsStub != nil {
+		return fake.InstanceChainsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.instanceChainsReturns.result1, fake.instanceChainsReturns.result2
+}
+
+func (fake *FakeIPTables) InstanceChainsCallCount() int {
+	fake.instanceChainsMutex.RLock()
+	defer fake.instanceChainsMutex.RUnlock()
+	return len(fake.instanceChainsArgsForCall)
+}
+
+func (fake *FakeIPTables) InstanceChainsReturns(result1 []string, result2 error) {
+	fake.InstanceChainsStub = nil
+	fake.instanceChainsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIPTables) InstanceChainsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.InstanceChainsStub = nil
+	if fake.instanceChainsReturnsOnCall == nil {
+		fake.instanceChainsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.instanceChainsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeIPTables) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -461,6 +520,8 @@ func (fake *FakeIPTables) Invocations() map[string][][]interface{} {
 	defer fake.bulkPrependRulesMutex.RUnlock()
 	fake.instanceChainMutex.RLock()
 	defer fake.instanceChainMutex.RUnlock()
+	fake.instanceChainsMutex.RLock()
+	defer fake.instanceChainsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -1,6 +1,10 @@
 package iptables
 
-import "code.cloudfoundry.org/guardian/kawasaki"
+import (
+	"context"
+
+	"code.cloudfoundry.org/guardian/kawasaki"
+)
 
 type PortForwarder struct {
 	iptables *IPTablesController
@@ -12,8 +16,9 @@ func NewPortForwarder(iptables *IPTablesController) *PortForwarder {
 	}
 }
 
-func (p *PortForwarder) Forward(spec kawasaki.PortForwarderSpec) error {
+func (p *PortForwarder) Forward(ctx context.Context, spec kawasaki.PortForwarderSpec) error {
 	return p.iptables.appendRule(
+		ctx,
 		p.iptables.InstanceChain(spec.InstanceID),
 		natRule(
 			spec.ExternalIP.String(),
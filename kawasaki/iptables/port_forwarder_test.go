@@ -1,6 +1,7 @@
 package iptables_test
 
 import (
+	"context"
 	"net"
 
 	"code.cloudfoundry.org/commandrunner/fake_command_runner"
@@ -27,7 +28,7 @@ var _ = Describe("PortForwarder", func() {
 	})
 
 	It("adds a NAT rule to forward the port", func() {
-		Expect(forwarder.Forward(kawasaki.PortForwarderSpec{
+		Expect(forwarder.Forward(context.Background(), kawasaki.PortForwarderSpec{
 			InstanceID:  "some-instance",
 			Handle:      "some-handle",
 			ExternalIP:  net.ParseIP("5.6.7.8"),
@@ -56,4 +57,43 @@ var _ = Describe("PortForwarder", func() {
 			},
 		))
 	})
+
+	Context("when the addresses are IPv6", func() {
+		BeforeEach(func() {
+			forwarder = iptables.NewPortForwarder(
+				iptables.NewIPv6("/sbin/ip6tables", "/sbin/ip6tables-restore", fakeRunner, NewFakeLocksmith(), "prefix-"),
+			)
+		})
+
+		It("adds a NAT rule with ip6tables, bracketing the container address", func() {
+			Expect(forwarder.Forward(context.Background(), kawasaki.PortForwarderSpec{
+				InstanceID:  "some-instance",
+				Handle:      "some-handle",
+				ExternalIP:  net.ParseIP("2001:db8::8"),
+				ContainerIP: net.ParseIP("fd00::2"),
+				FromPort:    22,
+				ToPort:      33,
+			})).To(Succeed())
+
+			Expect(fakeRunner).To(HaveExecutedSerially(
+				fake_command_runner.CommandSpec{
+					Path: "/sbin/ip6tables",
+					Args: []string{
+						"-w",
+						"-A", "prefix-instance-some-instance",
+						"--table", "nat",
+						"--protocol", "tcp",
+						"--destination", "2001:db8::8",
+						"--destination-port", "22",
+						"--jump", "DNAT",
+						"--to-destination", "[fd00::2]:33",
+						"-m",
+						"comment",
+						"--comment",
+						"some-handle",
+					},
+				},
+			))
+		})
+	})
 })
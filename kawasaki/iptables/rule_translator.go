@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/kawasaki"
 )
 
 var protocols = map[garden.Protocol]string{
@@ -15,12 +16,19 @@ var protocols = map[garden.Protocol]string{
 }
 
 type ruleTranslator struct {
+	ipv6 bool
 }
 
 func NewRuleTranslator() RuleTranslator {
 	return &ruleTranslator{}
 }
 
+// NewIPv6RuleTranslator returns a translator for ip6tables, which leaves out
+// the IPv4 networks of rules
+func NewIPv6RuleTranslator() RuleTranslator {
+	return &ruleTranslator{ipv6: true}
+}
+
 func (t *ruleTranslator) TranslateRule(handle string, gardenRule garden.NetOutRule) ([]Rule, error) {
 	if len(gardenRule.Ports) > 0 && !allowsPort(gardenRule.Protocol) {
 		return nil, fmt.Errorf("Ports cannot be specified for Protocol %s", strings.ToUpper(protocols[gardenRule.Protocol]))
@@ -30,11 +38,23 @@ func (t *ruleTranslator) TranslateRule(handle string, gardenRule garden.NetOutRu
 		return nil, fmt.Errorf("invalid protocol: %d", gardenRule.Protocol)
 	}
 
+	networks, err := t.networks(gardenRule.Networks)
+	if err != nil {
+		return nil, err
+	}
+
+	// a rule only for networks of the other IP version has nothing to open here
+	if len(gardenRule.Networks) > 0 && len(networks) == 0 {
+		return []Rule{}, nil
+	}
+	gardenRule.Networks = networks
+
 	iptablesRule := SingleFilterRule{
 		Protocol: gardenRule.Protocol,
 		ICMPs:    gardenRule.ICMPs,
 		Log:      gardenRule.Log,
 		Handle:   handle,
+		IPv6:     t.ipv6,
 	}
 
 	iptablesRules := []Rule{}
@@ -58,6 +78,23 @@ func (t *ruleTranslator) TranslateRule(handle string, gardenRule garden.NetOutRu
 	return iptablesRules, nil
 }
 
+// networks returns the networks of the IP version of the translator
+func (t *ruleTranslator) networks(networks []garden.IPRange) ([]garden.IPRange, error) {
+	matching := []garden.IPRange{}
+	for _, network := range networks {
+		ipv6, err := kawasaki.IsIPv6Range(network)
+		if err != nil {
+			return nil, err
+		}
+
+		if ipv6 == t.ipv6 {
+			matching = append(matching, network)
+		}
+	}
+
+	return matching, nil
+}
+
 func allowsPort(p garden.Protocol) bool {
 	return p == garden.ProtocolTCP || p == garden.ProtocolUDP
 }
@@ -90,6 +90,63 @@ var _ = Describe("RuleBuilder", func() {
 		})
 	})
 
+	Describe("IP versions", func() {
+		v4Network := garden.IPRange{Start: net.ParseIP("1.2.3.4"), End: net.ParseIP("1.2.3.9")}
+		v6Network := garden.IPRange{Start: net.ParseIP("2001:db8::1"), End: net.ParseIP("2001:db8::9")}
+
+		It("leaves out the IPv6 networks of the rule", func() {
+			iptablesRules, err := translator.TranslateRule("some-handle", garden.NetOutRule{
+				Networks: []garden.IPRange{v4Network, v6Network},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(iptablesRules).To(Equal([]iptables.Rule{
+				iptables.SingleFilterRule{Handle: "some-handle", Networks: &v4Network},
+			}))
+		})
+
+		It("translates nothing for a rule only for IPv6 networks", func() {
+			iptablesRules, err := translator.TranslateRule("some-handle", garden.NetOutRule{
+				Networks: []garden.IPRange{v6Network},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(iptablesRules).To(BeEmpty())
+		})
+
+		It("returns an error for a range of mixed IP versions", func() {
+			_, err := translator.TranslateRule("some-handle", garden.NetOutRule{
+				Networks: []garden.IPRange{{Start: net.ParseIP("1.2.3.4"), End: net.ParseIP("2001:db8::9")}},
+			})
+			Expect(err).To(MatchError("network range 1.2.3.4-2001:db8::9 mixes IPv4 and IPv6 addresses"))
+		})
+
+		Context("when translating for IPv6", func() {
+			BeforeEach(func() {
+				translator = iptables.NewIPv6RuleTranslator()
+			})
+
+			It("keeps only the IPv6 networks of the rule", func() {
+				iptablesRules, err := translator.TranslateRule("some-handle", garden.NetOutRule{
+					Networks: []garden.IPRange{v4Network, v6Network},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(iptablesRules).To(Equal([]iptables.Rule{
+					iptables.SingleFilterRule{Handle: "some-handle", Networks: &v6Network, IPv6: true},
+				}))
+			})
+
+			It("applies a rule without networks to every IPv6 destination", func() {
+				iptablesRules, err := translator.TranslateRule("some-handle", garden.NetOutRule{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(iptablesRules).To(Equal([]iptables.Rule{
+					iptables.SingleFilterRule{Handle: "some-handle", IPv6: true},
+				}))
+			})
+		})
+	})
+
 	DescribeTable("networks and ports",
 		func(netOutRule garden.NetOutRule, expectedIptablesRules []iptables.SingleFilterRule) {
 			iptablesRules, err := translator.TranslateRule("some-handle", netOutRule)
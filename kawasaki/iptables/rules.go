@@ -2,6 +2,7 @@ package iptables
 
 import (
 	"fmt"
+	"net"
 
 	"code.cloudfoundry.org/garden"
 )
@@ -19,7 +20,7 @@ func natRule(destination string, destinationPort uint32, containerIP string, con
 		"--destination", destination,
 		"--destination-port", fmt.Sprintf("%d", destinationPort),
 		"--jump", "DNAT",
-		"--to-destination", fmt.Sprintf("%s:%d", containerIP, containerPort),
+		"--to-destination", net.JoinHostPort(containerIP, fmt.Sprintf("%d", containerPort)),
 		"-m", "comment", "--comment", comment,
 	})
 }
@@ -38,10 +39,15 @@ type SingleFilterRule struct {
 	ICMPs    *garden.ICMPControl
 	Log      bool
 	Handle   string
+	IPv6     bool
 }
 
 func (r SingleFilterRule) Flags(chain string) (params []string) {
-	params = append(params, "--protocol", protocols[r.Protocol])
+	protocol := protocols[r.Protocol]
+	if r.IPv6 && r.Protocol == garden.ProtocolICMP {
+		protocol = "icmpv6"
+	}
+	params = append(params, "--protocol", protocol)
 
 	network := r.Networks
 	if network != nil {
@@ -69,7 +75,11 @@ func (r SingleFilterRule) Flags(chain string) (params []string) {
 			icmpType = fmt.Sprintf("%d/%d", r.ICMPs.Type, *r.ICMPs.Code)
 		}
 
-		params = append(params, "--icmp-type", icmpType)
+		if r.IPv6 {
+			params = append(params, "--icmpv6-type", icmpType)
+		} else {
+			params = append(params, "--icmp-type", icmpType)
+		}
 	}
 
 	if r.Log {
@@ -144,6 +144,27 @@ var _ = Describe("Rules", func() {
 			})
 		})
 
+		Context("when the rule is for IPv6", func() {
+			It("uses the icmpv6 protocol and type", func() {
+				code := garden.ICMPCode(1)
+				rule := iptables.SingleFilterRule{
+					Protocol: garden.ProtocolICMP,
+					ICMPs: &garden.ICMPControl{
+						Type: 1,
+						Code: &code,
+					},
+					IPv6: true,
+				}
+
+				Expect(rule.Flags("banana-chain")).To(Equal([]string{
+					"--protocol", "icmpv6",
+					"--icmpv6-type", "1/1",
+					"--jump", "RETURN",
+					"-m", "comment", "--comment", "",
+				}))
+			})
+		})
+
 		It("goes to the log chain when logging is enabled", func() {
 			rule := iptables.SingleFilterRule{
 				Protocol: garden.ProtocolTCP,
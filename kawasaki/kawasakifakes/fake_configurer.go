@@ -2,6 +2,7 @@
 package kawasakifakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/guardian/kawasaki"
@@ -9,9 +10,10 @@ import (
 )
 
 type FakeConfigurer struct {
-	ApplyStub        func(log lager.Logger, cfg kawasaki.NetworkConfig, pid int) error
+	ApplyStub        func(ctx context.Context, log lager.Logger, cfg kawasaki.NetworkConfig, pid int) error
 	applyMutex       sync.RWMutex
 	applyArgsForCall []struct {
+		ctx context.Context
 		log lager.Logger
 		cfg kawasaki.NetworkConfig
 		pid int
@@ -50,18 +52,19 @@ type FakeConfigurer struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeConfigurer) Apply(log lager.Logger, cfg kawasaki.NetworkConfig, pid int) error {
+func (fake *FakeConfigurer) Apply(ctx context.Context, log lager.Logger, cfg kawasaki.NetworkConfig, pid int) error {
 	fake.applyMutex.Lock()
 	ret, specificReturn := fake.applyReturnsOnCall[len(fake.applyArgsForCall)]
 	fake.applyArgsForCall = append(fake.applyArgsForCall, struct {
+		ctx context.Context
 		log lager.Logger
 		cfg kawasaki.NetworkConfig
 		pid int
-	}{log, cfg, pid})
-	fake.recordInvocation("Apply", []interface{}{log, cfg, pid})
+	}{ctx, log, cfg, pid})
+	fake.recordInvocation("Apply", []interface{}{ctx, log, cfg, pid})
 	fake.applyMutex.Unlock()
 	if fake.ApplyStub != nil {
-		return fake.ApplyStub(log, cfg, pid)
+		return fake.ApplyStub(ctx, log, cfg, pid)
 	}
 	if specificReturn {
 		return ret.result1
@@ -75,10 +78,10 @@ func (fake *FakeConfigurer) ApplyCallCount() int {
 	return len(fake.applyArgsForCall)
 }
 
-func (fake *FakeConfigurer) ApplyArgsForCall(i int) (lager.Logger, kawasaki.NetworkConfig, int) {
+func (fake *FakeConfigurer) ApplyArgsForCall(i int) (context.Context, lager.Logger, kawasaki.NetworkConfig, int) {
 	fake.applyMutex.RLock()
 	defer fake.applyMutex.RUnlock()
-	return fake.applyArgsForCall[i].log, fake.applyArgsForCall[i].cfg, fake.applyArgsForCall[i].pid
+	return fake.applyArgsForCall[i].ctx, fake.applyArgsForCall[i].log, fake.applyArgsForCall[i].cfg, fake.applyArgsForCall[i].pid
 }
 
 func (fake *FakeConfigurer) ApplyReturns(result1 error) {
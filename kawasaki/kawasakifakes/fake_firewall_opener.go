@@ -2,6 +2,7 @@
 package kawasakifakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/garden"
@@ -10,9 +11,10 @@ import (
 )
 
 type FakeFirewallOpener struct {
-	OpenStub        func(log lager.Logger, instance, handle string, rule garden.NetOutRule) error
+	OpenStub        func(ctx context.Context, log lager.Logger, instance, handle string, rule garden.NetOutRule) error
 	openMutex       sync.RWMutex
 	openArgsForCall []struct {
+		ctx      context.Context
 		log      lager.Logger
 		instance string
 		handle   string
@@ -24,9 +26,10 @@ type FakeFirewallOpener struct {
 	openReturnsOnCall map[int]struct {
 		result1 error
 	}
-	BulkOpenStub        func(log lager.Logger, instance, handle string, rule []garden.NetOutRule) error
+	BulkOpenStub        func(ctx context.Context, log lager.Logger, instance, handle string, rule []garden.NetOutRule) error
 	bulkOpenMutex       sync.RWMutex
 	bulkOpenArgsForCall []struct {
+		ctx      context.Context
 		log      lager.Logger
 		instance string
 		handle   string
@@ -42,19 +45,20 @@ type FakeFirewallOpener struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFirewallOpener) Open(log lager.Logger, instance string, handle string, rule garden.NetOutRule) error {
+func (fake *FakeFirewallOpener) Open(ctx context.Context, log lager.Logger, instance string, handle string, rule garden.NetOutRule) error {
 	fake.openMutex.Lock()
 	ret, specificReturn := fake.openReturnsOnCall[len(fake.openArgsForCall)]
 	fake.openArgsForCall = append(fake.openArgsForCall, struct {
+		ctx      context.Context
 		log      lager.Logger
 		instance string
 		handle   string
 		rule     garden.NetOutRule
-	}{log, instance, handle, rule})
-	fake.recordInvocation("Open", []interface{}{log, instance, handle, rule})
+	}{ctx, log, instance, handle, rule})
+	fake.recordInvocation("Open", []interface{}{ctx, log, instance, handle, rule})
 	fake.openMutex.Unlock()
 	if fake.OpenStub != nil {
-		return fake.OpenStub(log, instance, handle, rule)
+		return fake.OpenStub(ctx, log, instance, handle, rule)
 	}
 	if specificReturn {
 		return ret.result1
@@ -68,10 +72,10 @@ func (fake *FakeFirewallOpener) OpenCallCount() int {
 	return len(fake.openArgsForCall)
 }
 
-func (fake *FakeFirewallOpener) OpenArgsForCall(i int) (lager.Logger, string, string, garden.NetOutRule) {
+func (fake *FakeFirewallOpener) OpenArgsForCall(i int) (context.Context, lager.Logger, string, string, garden.NetOutRule) {
 	fake.openMutex.RLock()
 	defer fake.openMutex.RUnlock()
-	return fake.openArgsForCall[i].log, fake.openArgsForCall[i].instance, fake.openArgsForCall[i].handle, fake.openArgsForCall[i].rule
+	return fake.openArgsForCall[i].ctx, fake.openArgsForCall[i].log, fake.openArgsForCall[i].instance, fake.openArgsForCall[i].handle, fake.openArgsForCall[i].rule
 }
 
 func (fake *FakeFirewallOpener) OpenReturns(result1 error) {
@@ -93,7 +97,7 @@ func (fake *FakeFirewallOpener) OpenReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeFirewallOpener) BulkOpen(log lager.Logger, instance string, handle string, rule []garden.NetOutRule) error {
+func (fake *FakeFirewallOpener) BulkOpen(ctx context.Context, log lager.Logger, instance string, handle string, rule []garden.NetOutRule) error {
 	var ruleCopy []garden.NetOutRule
 	if rule != nil {
 		ruleCopy = make([]garden.NetOutRule, len(rule))
@@ -102,15 +106,16 @@ func (fake *FakeFirewallOpener) BulkOpen(log lager.Logger, instance string, hand
 	fake.bulkOpenMutex.Lock()
 	ret, specificReturn := fake.bulkOpenReturnsOnCall[len(fake.bulkOpenArgsForCall)]
 	fake.bulkOpenArgsForCall = append(fake.bulkOpenArgsForCall, struct {
+		ctx      context.Context
 		log      lager.Logger
 		instance string
 		handle   string
 		rule     []garden.NetOutRule
-	}{log, instance, handle, ruleCopy})
-	fake.recordInvocation("BulkOpen", []interface{}{log, instance, handle, ruleCopy})
+	}{ctx, log, instance, handle, ruleCopy})
+	fake.recordInvocation("BulkOpen", []interface{}{ctx, log, instance, handle, ruleCopy})
 	fake.bulkOpenMutex.Unlock()
 	if fake.BulkOpenStub != nil {
-		return fake.BulkOpenStub(log, instance, handle, rule)
+		return fake.BulkOpenStub(ctx, log, instance, handle, rule)
 	}
 	if specificReturn {
 		return ret.result1
@@ -124,10 +129,10 @@ func (fake *FakeFirewallOpener) BulkOpenCallCount() int {
 	return len(fake.bulkOpenArgsForCall)
 }
 
-func (fake *FakeFirewallOpener) BulkOpenArgsForCall(i int) (lager.Logger, string, string, []garden.NetOutRule) {
+func (fake *FakeFirewallOpener) BulkOpenArgsForCall(i int) (context.Context, lager.Logger, string, string, []garden.NetOutRule) {
 	fake.bulkOpenMutex.RLock()
 	defer fake.bulkOpenMutex.RUnlock()
-	return fake.bulkOpenArgsForCall[i].log, fake.bulkOpenArgsForCall[i].instance, fake.bulkOpenArgsForCall[i].handle, fake.bulkOpenArgsForCall[i].rule
+	return fake.bulkOpenArgsForCall[i].ctx, fake.bulkOpenArgsForCall[i].log, fake.bulkOpenArgsForCall[i].instance, fake.bulkOpenArgsForCall[i].handle, fake.bulkOpenArgsForCall[i].rule
 }
 
 func (fake *FakeFirewallOpener) BulkOpenReturns(result1 error) {
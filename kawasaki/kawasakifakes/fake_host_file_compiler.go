@@ -10,12 +10,12 @@ import (
 )
 
 type FakeHostFileCompiler struct {
-	CompileStub        func(log lager.Logger, containerIp net.IP, handle string) ([]byte, error)
+	CompileStub        func(log lager.Logger, containerIps []net.IP, handle string) ([]byte, error)
 	compileMutex       sync.RWMutex
 	compileArgsForCall []struct {
-		log         lager.Logger
-		containerIp net.IP
-		handle      string
+		log          lager.Logger
+		containerIps []net.IP
+		handle       string
 	}
 	compileReturns struct {
 		result1 []byte
@@ -29,18 +29,23 @@ type FakeHostFileCompiler struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeHostFileCompiler) Compile(log lager.Logger, containerIp net.IP, handle string) ([]byte, error) {
+func (fake *FakeHostFileCompiler) Compile(log lager.Logger, containerIps []net.IP, handle string) ([]byte, error) {
+	var containerIpsCopy []net.IP
+	if containerIps != nil {
+		containerIpsCopy = make([]net.IP, len(containerIps))
+		copy(containerIpsCopy, containerIps)
+	}
 	fake.compileMutex.Lock()
 	ret, specificReturn := fake.compileReturnsOnCall[len(fake.compileArgsForCall)]
 	fake.compileArgsForCall = append(fake.compileArgsForCall, struct {
-		log         lager.Logger
-		containerIp net.IP
-		handle      string
-	}{log, containerIp, handle})
-	fake.recordInvocation("Compile", []interface{}{log, containerIp, handle})
+		log          lager.Logger
+		containerIps []net.IP
+		handle       string
+	}{log, containerIpsCopy, handle})
+	fake.recordInvocation("Compile", []interface{}{log, containerIpsCopy, handle})
 	fake.compileMutex.Unlock()
 	if fake.CompileStub != nil {
-		return fake.CompileStub(log, containerIp, handle)
+		return fake.CompileStub(log, containerIps, handle)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -54,10 +59,10 @@ func (fake *FakeHostFileCompiler) CompileCallCount() int {
 	return len(fake.compileArgsForCall)
 }
 
-func (fake *FakeHostFileCompiler) CompileArgsForCall(i int) (lager.Logger, net.IP, string) {
+func (fake *FakeHostFileCompiler) CompileArgsForCall(i int) (lager.Logger, []net.IP, string) {
 	fake.compileMutex.RLock()
 	defer fake.compileMutex.RUnlock()
-	return fake.compileArgsForCall[i].log, fake.compileArgsForCall[i].containerIp, fake.compileArgsForCall[i].handle
+	return fake.compileArgsForCall[i].log, fake.compileArgsForCall[i].containerIps, fake.compileArgsForCall[i].handle
 }
 
 func (fake *FakeHostFileCompiler) CompileReturns(result1 []byte, result2 error) {
@@ -2,6 +2,7 @@
 package kawasakifakes
 
 import (
+	"context"
 	"net"
 	"sync"
 
@@ -10,9 +11,10 @@ import (
 )
 
 type FakeInstanceChainCreator struct {
-	CreateStub        func(logger lager.Logger, handle, instanceChain, bridgeName string, ip net.IP, network *net.IPNet) error
+	CreateStub        func(ctx context.Context, logger lager.Logger, handle, instanceChain, bridgeName string, ip net.IP, network *net.IPNet) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
+		ctx           context.Context
 		logger        lager.Logger
 		handle        string
 		instanceChain string
@@ -42,21 +44,22 @@ type FakeInstanceChainCreator struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeInstanceChainCreator) Create(logger lager.Logger, handle string, instanceChain string, bridgeName string, ip net.IP, network *net.IPNet) error {
+func (fake *FakeInstanceChainCreator) Create(ctx context.Context, logger lager.Logger, handle string, instanceChain string, bridgeName string, ip net.IP, network *net.IPNet) error {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		ctx           context.Context
 		logger        lager.Logger
 		handle        string
 		instanceChain string
 		bridgeName    string
 		ip            net.IP
 		network       *net.IPNet
-	}{logger, handle, instanceChain, bridgeName, ip, network})
-	fake.recordInvocation("Create", []interface{}{logger, handle, instanceChain, bridgeName, ip, network})
+	}{ctx, logger, handle, instanceChain, bridgeName, ip, network})
+	fake.recordInvocation("Create", []interface{}{ctx, logger, handle, instanceChain, bridgeName, ip, network})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(logger, handle, instanceChain, bridgeName, ip, network)
+		return fake.CreateStub(ctx, logger, handle, instanceChain, bridgeName, ip, network)
 	}
 	if specificReturn {
 		return ret.result1
@@ -70,10 +73,10 @@ func (fake *FakeInstanceChainCreator) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeInstanceChainCreator) CreateArgsForCall(i int) (lager.Logger, string, string, string, net.IP, *net.IPNet) {
+func (fake *FakeInstanceChainCreator) CreateArgsForCall(i int) (context.Context, lager.Logger, string, string, string, net.IP, *net.IPNet) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].logger, fake.createArgsForCall[i].handle, fake.createArgsForCall[i].instanceChain, fake.createArgsForCall[i].bridgeName, fake.createArgsForCall[i].ip, fake.createArgsForCall[i].network
+	return fake.createArgsForCall[i].ctx, fake.createArgsForCall[i].logger, fake.createArgsForCall[i].handle, fake.createArgsForCall[i].instanceChain, fake.createArgsForCall[i].bridgeName, fake.createArgsForCall[i].ip, fake.createArgsForCall[i].network
 }
 
 func (fake *FakeInstanceChainCreator) CreateReturns(result1 error) {
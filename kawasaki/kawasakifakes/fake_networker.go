@@ -2,6 +2,7 @@
 package kawasakifakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/garden"
@@ -19,9 +20,10 @@ type FakeNetworker struct {
 	capacityReturnsOnCall map[int]struct {
 		result1 uint64
 	}
-	NetworkStub        func(log lager.Logger, spec garden.ContainerSpec, pid int) error
+	NetworkStub        func(ctx context.Context, log lager.Logger, spec garden.ContainerSpec, pid int) error
 	networkMutex       sync.RWMutex
 	networkArgsForCall []struct {
+		ctx  context.Context
 		log  lager.Logger
 		spec garden.ContainerSpec
 		pid  int
@@ -88,6 +90,19 @@ type FakeNetworker struct {
 	bulkNetOutReturnsOnCall map[int]struct {
 		result1 error
 	}
+	LimitBandwidthStub        func(log lager.Logger, handle string, limits garden.BandwidthLimits) error
+	limitBandwidthMutex       sync.RWMutex
+	limitBandwidthArgsForCall []struct {
+		log    lager.Logger
+		handle string
+		limits garden.BandwidthLimits
+	}
+	limitBandwidthReturns struct {
+		result1 error
+	}
+	limitBandwidthReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RestoreStub        func(log lager.Logger, handle string) error
 	restoreMutex       sync.RWMutex
 	restoreArgsForCall []struct {
@@ -144,18 +159,19 @@ func (fake *FakeNetworker) CapacityReturnsOnCall(i int, result1 uint64) {
 	}{result1}
 }
 
-func (fake *FakeNetworker) Network(log lager.Logger, spec garden.ContainerSpec, pid int) error {
+func (fake *FakeNetworker) Network(ctx context.Context, log lager.Logger, spec garden.ContainerSpec, pid int) error {
 	fake.networkMutex.Lock()
 	ret, specificReturn := fake.networkReturnsOnCall[len(fake.networkArgsForCall)]
 	fake.networkArgsForCall = append(fake.networkArgsForCall, struct {
+		ctx  context.Context
 		log  lager.Logger
 		spec garden.ContainerSpec
 		pid  int
-	}{log, spec, pid})
-	fake.recordInvocation("Network", []interface{}{log, spec, pid})
+	}{ctx, log, spec, pid})
+	fake.recordInvocation("Network", []interface{}{ctx, log, spec, pid})
 	fake.networkMutex.Unlock()
 	if fake.NetworkStub != nil {
-		return fake.NetworkStub(log, spec, pid)
+		return fake.NetworkStub(ctx, log, spec, pid)
 	}
 	if specificReturn {
 		return ret.result1
@@ -169,10 +185,10 @@ func (fake *FakeNetworker) NetworkCallCount() int {
 	return len(fake.networkArgsForCall)
 }
 
-func (fake *FakeNetworker) NetworkArgsForCall(i int) (lager.Logger, garden.ContainerSpec, int) {
+func (fake *FakeNetworker) NetworkArgsForCall(i int) (context.Context, lager.Logger, garden.ContainerSpec, int) {
 	fake.networkMutex.RLock()
 	defer fake.networkMutex.RUnlock()
-	return fake.networkArgsForCall[i].log, fake.networkArgsForCall[i].spec, fake.networkArgsForCall[i].pid
+	return fake.networkArgsForCall[i].ctx, fake.networkArgsForCall[i].log, fake.networkArgsForCall[i].spec, fake.networkArgsForCall[i].pid
 }
 
 func (fake *FakeNetworker) NetworkReturns(result1 error) {
@@ -405,6 +421,56 @@ func (fake *FakeNetworker) BulkNetOutReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeNetworker) LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error {
+	fake.limitBandwidthMutex.Lock()
+	ret, specificReturn := fake.limitBandwidthReturnsOnCall[len(fake.limitBandwidthArgsForCall)]
+	fake.limitBandwidthArgsForCall = append(fake.limitBandwidthArgsForCall, struct {
+		log    lager.Logger
+		handle string
+		limits garden.BandwidthLimits
+	}{log, handle, limits})
+	fake.recordInvocation("LimitBandwidth", []interface{}{log, handle, limits})
+	fake.limitBandwidthMutex.Unlock()
+	if fake.LimitBandwidthStub != nil {
+		return fake.LimitBandwidthStub(log, handle, limits)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.limitBandwidthReturns.result1
+}
+
+func (fake *FakeNetworker) LimitBandwidthCallCount() int {
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
+	return len(fake.limitBandwidthArgsForCall)
+}
+
+func (fake *FakeNetworker) LimitBandwidthArgsForCall(i int) (lager.Logger, string, garden.BandwidthLimits) {
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
+	return fake.limitBandwidthArgsForCall[i].log, fake.limitBandwidthArgsForCall[i].handle, fake.limitBandwidthArgsForCall[i].limits
+}
+
+func (fake *FakeNetworker) LimitBandwidthReturns(result1 error) {
+	fake.LimitBandwidthStub = nil
+	fake.limitBandwidthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetworker) LimitBandwidthReturnsOnCall(i int, result1 error) {
+	fake.LimitBandwidthStub = nil
+	if fake.limitBandwidthReturnsOnCall == nil {
+		fake.limitBandwidthReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.limitBandwidthReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNetworker) Restore(log lager.Logger, handle string) error {
 	fake.restoreMutex.Lock()
 	ret, specificReturn := fake.restoreReturnsOnCall[len(fake.restoreArgsForCall)]
@@ -469,6 +535,8 @@ func (fake *FakeNetworker) Invocations() map[string][][]interface{} {
 	defer fake.netOutMutex.RUnlock()
 	fake.bulkNetOutMutex.RLock()
 	defer fake.bulkNetOutMutex.RUnlock()
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
 	fake.restoreMutex.RLock()
 	defer fake.restoreMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -2,15 +2,17 @@
 package kawasakifakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/guardian/kawasaki"
 )
 
 type FakePortForwarder struct {
-	ForwardStub        func(spec kawasaki.PortForwarderSpec) error
+	ForwardStub        func(ctx context.Context, spec kawasaki.PortForwarderSpec) error
 	forwardMutex       sync.RWMutex
 	forwardArgsForCall []struct {
+		ctx  context.Context
 		spec kawasaki.PortForwarderSpec
 	}
 	forwardReturns struct {
@@ -23,16 +25,17 @@ type FakePortForwarder struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePortForwarder) Forward(spec kawasaki.PortForwarderSpec) error {
+func (fake *FakePortForwarder) Forward(ctx context.Context, spec kawasaki.PortForwarderSpec) error {
 	fake.forwardMutex.Lock()
 	ret, specificReturn := fake.forwardReturnsOnCall[len(fake.forwardArgsForCall)]
 	fake.forwardArgsForCall = append(fake.forwardArgsForCall, struct {
+		ctx  context.Context
 		spec kawasaki.PortForwarderSpec
-	}{spec})
-	fake.recordInvocation("Forward", []interface{}{spec})
+	}{ctx, spec})
+	fake.recordInvocation("Forward", []interface{}{ctx, spec})
 	fake.forwardMutex.Unlock()
 	if fake.ForwardStub != nil {
-		return fake.ForwardStub(spec)
+		return fake.ForwardStub(ctx, spec)
 	}
 	if specificReturn {
 		return ret.result1
@@ -46,10 +49,10 @@ func (fake *FakePortForwarder) ForwardCallCount() int {
 	return len(fake.forwardArgsForCall)
 }
 
-func (fake *FakePortForwarder) ForwardArgsForCall(i int) kawasaki.PortForwarderSpec {
+func (fake *FakePortForwarder) ForwardArgsForCall(i int) (context.Context, kawasaki.PortForwarderSpec) {
 	fake.forwardMutex.RLock()
 	defer fake.forwardMutex.RUnlock()
-	return fake.forwardArgsForCall[i].spec
+	return fake.forwardArgsForCall[i].ctx, fake.forwardArgsForCall[i].spec
 }
 
 func (fake *FakePortForwarder) ForwardReturns(result1 error) {
@@ -1,7 +1,9 @@
 package kawasaki
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
@@ -17,12 +19,17 @@ import (
 const containerIpKey = gardener.ContainerIPKey
 const bridgeIpKey = gardener.BridgeIPKey
 const externalIpKey = gardener.ExternalIPKey
+const containerIpv6Key = gardener.ContainerIPv6Key
+const bridgeIpv6Key = gardener.BridgeIPv6Key
+const externalIpv6Key = gardener.ExternalIPv6Key
+const bandwidthLimitsKey = gardener.BandwidthLimitsKey
 
 // kawasaki-specific state properties
 const hostIntfKey = "kawasaki.host-interface"
 const containerIntfKey = "kawasaki.container-interface"
 const bridgeIntfKey = "kawasaki.bridge-interface"
 const subnetKey = "kawasaki.subnet"
+const subnetV6Key = "kawasaki.subnet-v6"
 const iptablePrefixKey = "kawasaki.iptable-prefix"
 const iptableInstanceKey = "kawasaki.iptable-inst"
 const mtuKey = "kawasaki.mtu"
@@ -43,7 +50,7 @@ type ConfigCreator interface {
 //go:generate counterfeiter . Configurer
 
 type Configurer interface {
-	Apply(log lager.Logger, cfg NetworkConfig, pid int) error
+	Apply(ctx context.Context, log lager.Logger, cfg NetworkConfig, pid int) error
 	DestroyBridge(log lager.Logger, cfg NetworkConfig) error
 	DestroyIPTablesRules(log lager.Logger, cfg NetworkConfig) error
 }
@@ -66,7 +73,7 @@ type PortPool interface {
 //go:generate counterfeiter . PortForwarder
 
 type PortForwarder interface {
-	Forward(spec PortForwarderSpec) error
+	Forward(ctx context.Context, spec PortForwarderSpec) error
 }
 
 type PortForwarderSpec struct {
@@ -81,22 +88,45 @@ type PortForwarderSpec struct {
 //go:generate counterfeiter . FirewallOpener
 
 type FirewallOpener interface {
-	Open(log lager.Logger, instance, handle string, rule garden.NetOutRule) error
-	BulkOpen(log lager.Logger, instance, handle string, rule []garden.NetOutRule) error
+	Open(ctx context.Context, log lager.Logger, instance, handle string, rule garden.NetOutRule) error
+	BulkOpen(ctx context.Context, log lager.Logger, instance, handle string, rule []garden.NetOutRule) error
+}
+
+//go:generate counterfeiter . BandwidthShaper
+
+type BandwidthShaper interface {
+	Shape(ctx context.Context, log lager.Logger, intf string, mtu int, limits garden.BandwidthLimits) error
 }
 
 //go:generate counterfeiter . Networker
 
 type Networker interface {
 	Capacity() uint64
-	Network(log lager.Logger, spec garden.ContainerSpec, pid int) error
+	Network(ctx context.Context, log lager.Logger, spec garden.ContainerSpec, pid int) error
 	Destroy(log lager.Logger, handle string) error
 	NetIn(log lager.Logger, handle string, externalPort, containerPort uint32) (uint32, uint32, error)
 	NetOut(log lager.Logger, handle string, rule garden.NetOutRule) error
 	BulkNetOut(log lager.Logger, handle string, rules []garden.NetOutRule) error
+	LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error
 	Restore(log lager.Logger, handle string) error
 }
 
+// IPv6 configures the IPv6 networking of containers. When its subnet pool is
+// set, each container is given an IPv6 address alongside its IPv4 one, and the
+// port forwarder and firewall opener are used for the IPv6 side of NetIn and
+// NetOut. A container whose network spec names only an IPv6 network is given
+// only an IPv6 address.
+type IPv6 struct {
+	SubnetPool     subnets.Pool
+	ExternalIP     net.IP
+	PortForwarder  PortForwarder
+	FirewallOpener FirewallOpener
+
+	// Only gives every container only an IPv6 address, refusing network specs
+	// which name an IPv4 network
+	Only bool
+}
+
 type networker struct {
 	specParser     SpecParser
 	subnetPool     subnets.Pool
@@ -106,6 +136,8 @@ type networker struct {
 	portPool       PortPool
 	firewallOpener FirewallOpener
 	configurer     Configurer
+	shaper         BandwidthShaper
+	ipv6           IPv6
 }
 
 func New(
@@ -117,6 +149,22 @@ func New(
 	portPool PortPool,
 	portForwarder PortForwarder,
 	firewallOpener FirewallOpener,
+	shaper BandwidthShaper,
+) *networker {
+	return NewDualStack(specParser, subnetPool, configCreator, configStore, configurer, portPool, portForwarder, firewallOpener, shaper, IPv6{})
+}
+
+func NewDualStack(
+	specParser SpecParser,
+	subnetPool subnets.Pool,
+	configCreator ConfigCreator,
+	configStore ConfigStore,
+	configurer Configurer,
+	portPool PortPool,
+	portForwarder PortForwarder,
+	firewallOpener FirewallOpener,
+	shaper BandwidthShaper,
+	ipv6 IPv6,
 ) *networker {
 	return &networker{
 		specParser:    specParser,
@@ -129,10 +177,13 @@ func New(
 		portPool:      portPool,
 
 		firewallOpener: firewallOpener,
+		shaper:         shaper,
+
+		ipv6: ipv6,
 	}
 }
 
-func (n *networker) Network(log lager.Logger, containerSpec garden.ContainerSpec, pid int) error {
+func (n *networker) Network(ctx context.Context, log lager.Logger, containerSpec garden.ContainerSpec, pid int) error {
 	log = log.Session("network", lager.Data{
 		"handle": containerSpec.Handle,
 		"spec":   containerSpec.Network,
@@ -141,57 +192,193 @@ func (n *networker) Network(log lager.Logger, containerSpec garden.ContainerSpec
 	log.Info("started")
 	defer log.Info("finished")
 
-	subnetReq, ipReq, err := n.specParser.Parse(log, containerSpec.Network)
+	spec, specV6, err := splitNetworkSpec(containerSpec.Network)
 	if err != nil {
-		log.Error("parse-failed", err)
+		log.Error("split-spec-failed", err)
 		return err
 	}
 
-	subnet, ip, err := n.subnetPool.Acquire(log, subnetReq, ipReq)
-	if err != nil {
-		log.Error("acquire-failed", err)
-		return err
+	if (specV6 != "" || n.ipv6.Only) && n.ipv6.SubnetPool == nil {
+		return errors.New("a network with IPv6 was requested, but IPv6 networking is not enabled")
+	}
+
+	if spec != "" && n.ipv6.Only {
+		return fmt.Errorf("network spec '%s' names an IPv4 network, but containers are only given IPv6 addresses", containerSpec.Network)
 	}
 
-	config, err := n.configCreator.Create(log, containerSpec.Handle, subnet, ip)
+	ipv6Only := spec == "" && (specV6 != "" || n.ipv6.Only)
+
+	var subnet *net.IPNet
+	var ip net.IP
+	if !ipv6Only {
+		subnetReq, ipReq, err := n.specParser.Parse(log, spec)
+		if err != nil {
+			log.Error("parse-failed", err)
+			return err
+		}
+
+		subnet, ip, err = n.subnetPool.Acquire(log, subnetReq, ipReq)
+		if err != nil {
+			log.Error("acquire-failed", err)
+			return err
+		}
+	}
+
+	var subnetV6 *net.IPNet
+	var ipV6 net.IP
+	if n.ipv6.SubnetPool != nil {
+		subnetV6, ipV6, err = n.acquireIPv6(log, specV6)
+		if err != nil {
+			if subnet == nil {
+				return err
+			}
+
+			if releaseErr := n.subnetPool.Release(subnet, ip); releaseErr != nil {
+				log.Error("release-failed", releaseErr)
+			}
+			return err
+		}
+	}
+
+	// the config of a container with both is created from its IPv4 subnet
+	var config NetworkConfig
+	if ipv6Only {
+		config, err = n.configCreator.Create(log, containerSpec.Handle, subnetV6, ipV6)
+	} else {
+		config, err = n.configCreator.Create(log, containerSpec.Handle, subnet, ip)
+	}
 	if err != nil {
 		log.Error("create-config-failed", err)
 		return fmt.Errorf("create network config: %s", err)
 	}
+
+	if subnetV6 != nil {
+		config.SubnetV6 = subnetV6
+		config.ContainerIPv6 = ipV6
+		config.BridgeIPv6 = subnets.GatewayIP(subnetV6)
+		config.ExternalIPv6 = n.ipv6.ExternalIP
+	}
 	log.Info("config-create", lager.Data{"config": config})
 
 	if err := save(n.configStore, containerSpec.Handle, config); err != nil {
 		return err
 	}
 
-	if err := n.configurer.Apply(log, config, pid); err != nil {
+	if err := n.configurer.Apply(ctx, log, config, pid); err != nil {
+		return err
+	}
+
+	// save acquiring ports for a container which is going to be cleaned up
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
 	for _, netIn := range containerSpec.NetIn {
-		if _, _, err := n.NetIn(log, containerSpec.Handle, netIn.HostPort, netIn.ContainerPort); err != nil {
+		if _, _, err := n.netIn(ctx, log, containerSpec.Handle, netIn.HostPort, netIn.ContainerPort); err != nil {
 			return err
 		}
 	}
 
-	if err := n.BulkNetOut(log, containerSpec.Handle, containerSpec.NetOut); err != nil {
+	if err := n.bulkNetOut(ctx, log, containerSpec.Handle, containerSpec.NetOut); err != nil {
 		return err
 	}
 
+	if containerSpec.Limits.Bandwidth != (garden.BandwidthLimits{}) {
+		if err := n.limitBandwidth(ctx, log, containerSpec.Handle, containerSpec.Limits.Bandwidth); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// Capacity returns the number of subnets this network can host
+func (n *networker) acquireIPv6(log lager.Logger, spec string) (*net.IPNet, net.IP, error) {
+	log = log.Session("ipv6")
+
+	subnetReq, ipReq, err := n.specParser.Parse(log, spec)
+	if err != nil {
+		log.Error("parse-failed", err)
+		return nil, nil, err
+	}
+
+	subnet, ip, err := n.ipv6.SubnetPool.Acquire(log, subnetReq, ipReq)
+	if err != nil {
+		log.Error("acquire-failed", err)
+		return nil, nil, err
+	}
+
+	return subnet, ip, nil
+}
+
+// splitNetworkSpec separates the IPv4 and IPv6 parts of a network spec, such
+// as "10.0.0.4/30,fd00::4/126". Either part may be left out.
+func splitNetworkSpec(spec string) (string, string, error) {
+	var specV4, specV6 string
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if !strings.Contains(part, ":") {
+			if specV4 != "" {
+				return "", "", fmt.Errorf("network spec '%s' has more than one IPv4 network", spec)
+			}
+			specV4 = part
+			continue
+		}
+
+		if specV6 != "" {
+			return "", "", fmt.Errorf("network spec '%s' has more than one IPv6 network", spec)
+		}
+		specV6 = part
+	}
+
+	return specV4, specV6, nil
+}
+
+// IsIPv6Range reports whether a range is of IPv6 addresses. A range with one
+// IPv4 and one IPv6 end is an error.
+func IsIPv6Range(network garden.IPRange) (bool, error) {
+	startIPv6 := network.Start != nil && network.Start.To4() == nil
+	endIPv6 := network.End != nil && network.End.To4() == nil
+	if network.Start != nil && network.End != nil && startIPv6 != endIPv6 {
+		return false, fmt.Errorf("network range %s-%s mixes IPv4 and IPv6 addresses", network.Start, network.End)
+	}
+
+	return startIPv6 || endIPv6, nil
+}
+
+// Capacity returns the number of subnets this network can host. With IPv6
+// enabled, each container takes a subnet from both pools, unless it is only
+// given IPv6.
 func (n *networker) Capacity() uint64 {
-	return uint64(n.subnetPool.Capacity())
+	if n.ipv6.Only {
+		return uint64(n.ipv6.SubnetPool.Capacity())
+	}
+
+	capacity := n.subnetPool.Capacity()
+	if n.ipv6.SubnetPool != nil && n.ipv6.SubnetPool.Capacity() < capacity {
+		capacity = n.ipv6.SubnetPool.Capacity()
+	}
+
+	return uint64(capacity)
 }
 
 func (n *networker) NetIn(log lager.Logger, handle string, externalPort, containerPort uint32) (uint32, uint32, error) {
+	return n.netIn(context.Background(), log, handle, externalPort, containerPort)
+}
+
+func (n *networker) netIn(ctx context.Context, log lager.Logger, handle string, externalPort, containerPort uint32) (uint32, uint32, error) {
 	cfg, err := load(n.configStore, handle)
 	if err != nil {
 		return 0, 0, err
 	}
 
+	if cfg.ContainerIP == nil && (cfg.ExternalIPv6 == nil || n.ipv6.PortForwarder == nil) {
+		return 0, 0, errors.New("cannot map ports to a container with only IPv6 without an external IPv6 address")
+	}
+
 	if externalPort == 0 {
 		externalPort, err = n.portPool.Acquire()
 		if err != nil {
@@ -203,17 +390,34 @@ func (n *networker) NetIn(log lager.Logger, handle string, externalPort, contain
 		containerPort = externalPort
 	}
 
-	err = n.portForwarder.Forward(PortForwarderSpec{
-		InstanceID:  cfg.IPTableInstance,
-		Handle:      handle,
-		FromPort:    externalPort,
-		ToPort:      containerPort,
-		ContainerIP: cfg.ContainerIP,
-		ExternalIP:  cfg.ExternalIP,
-	})
+	if cfg.ContainerIP != nil {
+		err = n.portForwarder.Forward(ctx, PortForwarderSpec{
+			InstanceID:  cfg.IPTableInstance,
+			Handle:      handle,
+			FromPort:    externalPort,
+			ToPort:      containerPort,
+			ContainerIP: cfg.ContainerIP,
+			ExternalIP:  cfg.ExternalIP,
+		})
 
-	if err != nil {
-		return 0, 0, err
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if cfg.ContainerIPv6 != nil && cfg.ExternalIPv6 != nil && n.ipv6.PortForwarder != nil {
+		err = n.ipv6.PortForwarder.Forward(ctx, PortForwarderSpec{
+			InstanceID:  cfg.IPTableInstance,
+			Handle:      handle,
+			FromPort:    externalPort,
+			ToPort:      containerPort,
+			ContainerIP: cfg.ContainerIPv6,
+			ExternalIP:  cfg.ExternalIPv6,
+		})
+
+		if err != nil {
+			return 0, 0, err
+		}
 	}
 
 	if err := AddPortMapping(log, n.configStore, handle, garden.PortMapping{
@@ -232,16 +436,111 @@ func (n *networker) NetOut(log lager.Logger, handle string, rule garden.NetOutRu
 		return err
 	}
 
-	return n.firewallOpener.Open(log, cfg.IPTableInstance, handle, rule)
+	if err := n.checkRuleIPVersions(cfg, rule); err != nil {
+		return err
+	}
+
+	if cfg.ContainerIP != nil {
+		if err := n.firewallOpener.Open(context.Background(), log, cfg.IPTableInstance, handle, rule); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ContainerIPv6 == nil || n.ipv6.FirewallOpener == nil {
+		return nil
+	}
+
+	return n.ipv6.FirewallOpener.Open(context.Background(), log, cfg.IPTableInstance, handle, rule)
 }
 
 func (n *networker) BulkNetOut(log lager.Logger, handle string, rules []garden.NetOutRule) error {
+	return n.bulkNetOut(context.Background(), log, handle, rules)
+}
+
+func (n *networker) bulkNetOut(ctx context.Context, log lager.Logger, handle string, rules []garden.NetOutRule) error {
 	cfg, err := load(n.configStore, handle)
 	if err != nil {
 		return err
 	}
 
-	return n.firewallOpener.BulkOpen(log, cfg.IPTableInstance, handle, rules)
+	if err := n.checkRuleIPVersions(cfg, rules...); err != nil {
+		return err
+	}
+
+	if cfg.ContainerIP != nil {
+		if err := n.firewallOpener.BulkOpen(ctx, log, cfg.IPTableInstance, handle, rules); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ContainerIPv6 == nil || n.ipv6.FirewallOpener == nil {
+		return nil
+	}
+
+	return n.ipv6.FirewallOpener.BulkOpen(ctx, log, cfg.IPTableInstance, handle, rules)
+}
+
+// checkRuleIPVersions refuses rules to networks of an IP version which the
+// container has no address of, which would otherwise be silently left out
+func (n *networker) checkRuleIPVersions(cfg NetworkConfig, rules ...garden.NetOutRule) error {
+	if cfg.ContainerIP != nil && cfg.ContainerIPv6 != nil {
+		return nil
+	}
+
+	for _, rule := range rules {
+		for _, network := range rule.Networks {
+			ipv6, err := IsIPv6Range(network)
+			if err != nil {
+				return err
+			}
+
+			if ipv6 && cfg.ContainerIPv6 == nil {
+				return fmt.Errorf("cannot open IPv6 network %s-%s: container has no IPv6 address", network.Start, network.End)
+			}
+
+			if !ipv6 && cfg.ContainerIP == nil {
+				return fmt.Errorf("cannot open IPv4 network %s-%s: container has no IPv4 address", network.Start, network.End)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LimitBandwidth shapes the traffic flowing to and from the container on its
+// host-side interface and records the limits so that they survive a restart
+func (n *networker) LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error {
+	return n.limitBandwidth(context.Background(), log, handle, limits)
+}
+
+func (n *networker) limitBandwidth(ctx context.Context, log lager.Logger, handle string, limits garden.BandwidthLimits) error {
+	log = log.Session("limit-bandwidth", lager.Data{"handle": handle, "limits": limits})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	if limits.RateInBytesPerSecond == 0 || limits.BurstRateInBytesPerSecond == 0 {
+		return errors.New("limit bandwidth: rate and burst must be greater than zero")
+	}
+
+	cfg, err := load(n.configStore, handle)
+	if err != nil {
+		log.Error("load-config-failed", err)
+		return err
+	}
+
+	if err := n.shaper.Shape(ctx, log, cfg.HostIntf, cfg.Mtu, limits); err != nil {
+		log.Error("shape-failed", err)
+		return err
+	}
+
+	limitsJson, err := json.Marshal(limits)
+	if err != nil {
+		return err
+	}
+	n.configStore.Set(handle, bandwidthLimitsKey, string(limitsJson))
+
+	return nil
 }
 
 func (n *networker) Destroy(log lager.Logger, handle string) error {
@@ -255,9 +554,18 @@ func (n *networker) Destroy(log lager.Logger, handle string) error {
 		return err
 	}
 
-	if err := n.subnetPool.Release(cfg.Subnet, cfg.ContainerIP); err != nil && err != subnets.ErrReleasedUnallocatedSubnet {
-		log.Error("release-failed", err)
-		return err
+	if cfg.Subnet != nil {
+		if err := n.subnetPool.Release(cfg.Subnet, cfg.ContainerIP); err != nil && err != subnets.ErrReleasedUnallocatedSubnet {
+			log.Error("release-failed", err)
+			return err
+		}
+	}
+
+	if cfg.SubnetV6 != nil && n.ipv6.SubnetPool != nil {
+		if err := n.ipv6.SubnetPool.Release(cfg.SubnetV6, cfg.ContainerIPv6); err != nil && err != subnets.ErrReleasedUnallocatedSubnet {
+			log.Error("release-ipv6-failed", err)
+			return err
+		}
 	}
 
 	if ports, ok := n.configStore.Get(handle, gardener.MappedPortsKey); ok {
@@ -271,11 +579,20 @@ func (n *networker) Destroy(log lager.Logger, handle string) error {
 		}
 	}
 
-	err = n.subnetPool.RunIfFree(cfg.Subnet, func() error {
+	// the bridge is shared by the containers of the subnet it is named after,
+	// which is the IPv6 one for containers with only IPv6
+	pool, subnet := n.subnetPool, cfg.Subnet
+	if subnet == nil {
+		pool, subnet = n.ipv6.SubnetPool, cfg.SubnetV6
+	}
+
+	if pool == nil {
+		return nil
+	}
+
+	return pool.RunIfFree(subnet, func() error {
 		return n.configurer.DestroyBridge(log, cfg)
 	})
-
-	return err
 }
 
 func (n *networker) Restore(log lager.Logger, handle string) error {
@@ -284,9 +601,28 @@ func (n *networker) Restore(log lager.Logger, handle string) error {
 		return fmt.Errorf("loading %s: %v", handle, err)
 	}
 
-	err = n.subnetPool.Remove(networkConfig.Subnet, networkConfig.ContainerIP)
-	if err != nil {
-		return fmt.Errorf("subnet pool removing %s: %v", handle, err)
+	if networkConfig.Subnet != nil {
+		err = n.subnetPool.Remove(networkConfig.Subnet, networkConfig.ContainerIP)
+		if err != nil {
+			return fmt.Errorf("subnet pool removing %s: %v", handle, err)
+		}
+	}
+
+	if networkConfig.SubnetV6 != nil && n.ipv6.SubnetPool != nil {
+		if err := n.ipv6.SubnetPool.Remove(networkConfig.SubnetV6, networkConfig.ContainerIPv6); err != nil {
+			return fmt.Errorf("IPv6 subnet pool removing %s: %v", handle, err)
+		}
+	}
+
+	if limitsJson, ok := n.configStore.Get(handle, bandwidthLimitsKey); ok {
+		var limits garden.BandwidthLimits
+		if err := json.Unmarshal([]byte(limitsJson), &limits); err != nil {
+			return fmt.Errorf("unmarshaling bandwidth limits %s: %v", handle, err)
+		}
+
+		if err := n.shaper.Shape(context.Background(), log, networkConfig.HostIntf, networkConfig.Mtu, limits); err != nil {
+			return fmt.Errorf("reapplying bandwidth limits %s: %v", handle, err)
+		}
 	}
 
 	currentMappingsJson, ok := n.configStore.Get(handle, gardener.MappedPortsKey)
@@ -340,13 +676,25 @@ func save(config ConfigStore, handle string, netConfig NetworkConfig) error {
 	config.Set(handle, hostIntfKey, netConfig.HostIntf)
 	config.Set(handle, containerIntfKey, netConfig.ContainerIntf)
 	config.Set(handle, bridgeIntfKey, netConfig.BridgeName)
-	config.Set(handle, bridgeIpKey, netConfig.BridgeIP.String())
-	config.Set(handle, containerIpKey, netConfig.ContainerIP.String())
-	config.Set(handle, subnetKey, netConfig.Subnet.String())
 	config.Set(handle, iptablePrefixKey, netConfig.IPTablePrefix)
 	config.Set(handle, iptableInstanceKey, netConfig.IPTableInstance)
 	config.Set(handle, mtuKey, strconv.Itoa(netConfig.Mtu))
-	config.Set(handle, externalIpKey, netConfig.ExternalIP.String())
+
+	if netConfig.ContainerIP != nil {
+		config.Set(handle, bridgeIpKey, netConfig.BridgeIP.String())
+		config.Set(handle, containerIpKey, netConfig.ContainerIP.String())
+		config.Set(handle, subnetKey, netConfig.Subnet.String())
+		config.Set(handle, externalIpKey, netConfig.ExternalIP.String())
+	}
+
+	if netConfig.ContainerIPv6 != nil {
+		config.Set(handle, containerIpv6Key, netConfig.ContainerIPv6.String())
+		config.Set(handle, bridgeIpv6Key, netConfig.BridgeIPv6.String())
+		config.Set(handle, subnetV6Key, netConfig.SubnetV6.String())
+		if netConfig.ExternalIPv6 != nil {
+			config.Set(handle, externalIpv6Key, netConfig.ExternalIPv6.String())
+		}
+	}
 
 	var dnsServers []string
 	for _, dnsServer := range netConfig.OperatorNameservers {
@@ -366,24 +714,19 @@ func appendIfNotNil(errors []error, err error) []error {
 }
 
 func load(config ConfigStore, handle string) (NetworkConfig, error) {
-	vals, err := getAll(config, handle, hostIntfKey, containerIntfKey, bridgeIntfKey, bridgeIpKey, containerIpKey, subnetKey, iptablePrefixKey, iptableInstanceKey, mtuKey, externalIpKey, dnsServerKey)
+	vals, err := getAll(config, handle, hostIntfKey, containerIntfKey, bridgeIntfKey, iptablePrefixKey, iptableInstanceKey, mtuKey, dnsServerKey)
 
 	if err != nil {
 		return NetworkConfig{}, err
 	}
 
-	_, ipnet, err := net.ParseCIDR(vals[5])
-	if err != nil {
-		return NetworkConfig{}, err
-	}
-
-	mtu, err := strconv.Atoi(vals[8])
+	mtu, err := strconv.Atoi(vals[5])
 	if err != nil {
 		return NetworkConfig{}, err
 	}
 
 	var dnsServers []net.IP
-	for _, dnsServerName := range strings.Split(vals[10], ",") {
+	for _, dnsServerName := range strings.Split(vals[6], ",") {
 		dnsServerName = strings.TrimSpace(dnsServerName)
 		if dnsServerName == "" {
 			continue
@@ -395,19 +738,58 @@ func load(config ConfigStore, handle string) (NetworkConfig, error) {
 		dnsServers = append(dnsServers, ip)
 	}
 
-	return NetworkConfig{
+	netConfig := NetworkConfig{
 		HostIntf:            vals[0],
 		ContainerIntf:       vals[1],
 		BridgeName:          vals[2],
-		BridgeIP:            net.ParseIP(vals[3]),
-		ContainerIP:         net.ParseIP(vals[4]),
-		ExternalIP:          net.ParseIP(vals[9]),
-		Subnet:              ipnet,
-		IPTablePrefix:       vals[6],
-		IPTableInstance:     vals[7],
+		IPTablePrefix:       vals[3],
+		IPTableInstance:     vals[4],
 		Mtu:                 mtu,
 		OperatorNameservers: dnsServers,
-	}, nil
+	}
+
+	_, hasIPv4 := config.Get(handle, containerIpKey)
+	containerIPv6, hasIPv6 := config.Get(handle, containerIpv6Key)
+
+	// containers created with only IPv6 have none of the IPv4 properties
+	if hasIPv4 || !hasIPv6 {
+		vals, err := getAll(config, handle, bridgeIpKey, containerIpKey, subnetKey, externalIpKey)
+		if err != nil {
+			return NetworkConfig{}, err
+		}
+
+		_, ipnet, err := net.ParseCIDR(vals[2])
+		if err != nil {
+			return NetworkConfig{}, err
+		}
+
+		netConfig.BridgeIP = net.ParseIP(vals[0])
+		netConfig.ContainerIP = net.ParseIP(vals[1])
+		netConfig.Subnet = ipnet
+		netConfig.ExternalIP = net.ParseIP(vals[3])
+	}
+
+	// containers created without IPv6 have none of its properties
+	if hasIPv6 {
+		vals, err := getAll(config, handle, bridgeIpv6Key, subnetV6Key)
+		if err != nil {
+			return NetworkConfig{}, err
+		}
+
+		_, subnetV6, err := net.ParseCIDR(vals[1])
+		if err != nil {
+			return NetworkConfig{}, err
+		}
+
+		netConfig.ContainerIPv6 = net.ParseIP(containerIPv6)
+		netConfig.BridgeIPv6 = net.ParseIP(vals[0])
+		netConfig.SubnetV6 = subnetV6
+		if externalIPv6, ok := config.Get(handle, externalIpv6Key); ok {
+			netConfig.ExternalIPv6 = net.ParseIP(externalIPv6)
+		}
+	}
+
+	return netConfig, nil
 }
 
 type portMappingList []garden.PortMapping
@@ -1,6 +1,7 @@
 package kawasaki_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +30,7 @@ var _ = Describe("Networker", func() {
 		fakePortPool       *fakes.FakePortPool
 		fakeFirewallOpener *fakes.FakeFirewallOpener
 		fakeConfigurer     *fakes.FakeConfigurer
+		fakeShaper         *fakes.FakeBandwidthShaper
 		containerSpec      garden.ContainerSpec
 		networker          kawasaki.Networker
 		logger             lager.Logger
@@ -45,6 +47,7 @@ var _ = Describe("Networker", func() {
 		fakePortPool = new(fakes.FakePortPool)
 		fakeFirewallOpener = new(fakes.FakeFirewallOpener)
 		fakeConfigurer = new(fakes.FakeConfigurer)
+		fakeShaper = new(fakes.FakeBandwidthShaper)
 
 		containerSpec = garden.ContainerSpec{
 			Handle:  "some-handle",
@@ -83,6 +86,7 @@ var _ = Describe("Networker", func() {
 			fakePortPool,
 			fakePortForwarder,
 			fakeFirewallOpener,
+			fakeShaper,
 		)
 
 		ip, subnet, err := net.ParseCIDR("123.123.123.12/24")
@@ -141,7 +145,7 @@ var _ = Describe("Networker", func() {
 
 	Describe("Network", func() {
 		It("parses the spec", func() {
-			networker.Network(logger, containerSpec, 42)
+			networker.Network(context.Background(), logger, containerSpec, 42)
 			Expect(fakeSpecParser.ParseCallCount()).To(Equal(1))
 			_, spec := fakeSpecParser.ParseArgsForCall(0)
 			Expect(spec).To(Equal("1.2.3.4/30"))
@@ -149,7 +153,7 @@ var _ = Describe("Networker", func() {
 
 		It("returns an error if the spec can't be parsed", func() {
 			fakeSpecParser.ParseReturns(nil, nil, errors.New("no parsey"))
-			err := networker.Network(logger, containerSpec, 42)
+			err := networker.Network(context.Background(), logger, containerSpec, 42)
 			Expect(err).To(MatchError("no parsey"))
 		})
 
@@ -158,7 +162,7 @@ var _ = Describe("Networker", func() {
 			someIpRequest := subnets.DynamicIPSelector
 			fakeSpecParser.ParseReturns(someSubnetRequest, someIpRequest, nil)
 
-			networker.Network(logger, containerSpec, 42)
+			networker.Network(context.Background(), logger, containerSpec, 42)
 			Expect(fakeSubnetPool.AcquireCallCount()).To(Equal(1))
 			_, sr, ir := fakeSubnetPool.AcquireArgsForCall(0)
 			Expect(sr).To(Equal(someSubnetRequest))
@@ -169,7 +173,7 @@ var _ = Describe("Networker", func() {
 			someIp, someSubnet, err := net.ParseCIDR("1.2.3.4/5")
 			fakeSubnetPool.AcquireReturns(someSubnet, someIp, err)
 
-			networker.Network(logger, containerSpec, 42)
+			networker.Network(context.Background(), logger, containerSpec, 42)
 			Expect(fakeConfigCreator.CreateCallCount()).To(Equal(1))
 			_, handle, subnet, ip := fakeConfigCreator.CreateArgsForCall(0)
 			Expect(handle).To(Equal("some-handle"))
@@ -184,7 +188,7 @@ var _ = Describe("Networker", func() {
 				config[name] = value
 			}
 
-			err := networker.Network(logger, containerSpec, 42)
+			err := networker.Network(context.Background(), logger, containerSpec, 42)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(config["kawasaki.host-interface"]).To(Equal(networkConfig.HostIntf))
@@ -201,9 +205,9 @@ var _ = Describe("Networker", func() {
 		})
 
 		It("applies the right configuration", func() {
-			Expect(networker.Network(logger, containerSpec, 42)).To(Succeed())
+			Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
 			Expect(fakeConfigurer.ApplyCallCount()).To(Equal(1))
-			_, actualNetConfig, pid := fakeConfigurer.ApplyArgsForCall(0)
+			_, _, actualNetConfig, pid := fakeConfigurer.ApplyArgsForCall(0)
 			Expect(actualNetConfig).To(Equal(networkConfig))
 			Expect(pid).To(Equal(42))
 		})
@@ -211,15 +215,48 @@ var _ = Describe("Networker", func() {
 		Context("when the configurer fails to apply the config", func() {
 			It("errors", func() {
 				fakeConfigurer.ApplyReturns(errors.New("wont-apply"))
-				Expect(networker.Network(logger, containerSpec, 42)).To(MatchError("wont-apply"))
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(MatchError("wont-apply"))
 			})
 		})
 
+		It("passes the context on to the configurer", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			Expect(networker.Network(ctx, logger, containerSpec, 42)).To(Succeed())
+			actualCtx, _, _, _ := fakeConfigurer.ApplyArgsForCall(0)
+			Expect(actualCtx).To(Equal(ctx))
+		})
+
+		Context("when the context is done once the config is applied", func() {
+			It("does not go on to forward ports or open the firewall", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				fakeConfigurer.ApplyStub = func(context.Context, lager.Logger, kawasaki.NetworkConfig, int) error {
+					cancel()
+					return nil
+				}
+
+				Expect(networker.Network(ctx, logger, containerSpec, 42)).To(MatchError(context.Canceled))
+				Expect(fakePortForwarder.ForwardCallCount()).To(Equal(0))
+				Expect(fakeFirewallOpener.BulkOpenCallCount()).To(Equal(0))
+			})
+		})
+
+		It("passes the context on to the port forwarder and firewall opener", func() {
+			ctx := context.WithValue(context.Background(), "some-key", "some-value")
+			Expect(networker.Network(ctx, logger, containerSpec, 42)).To(Succeed())
+
+			forwardCtx, _ := fakePortForwarder.ForwardArgsForCall(0)
+			Expect(forwardCtx).To(Equal(ctx))
+			openCtx, _, _, _, _ := fakeFirewallOpener.BulkOpenArgsForCall(0)
+			Expect(openCtx).To(Equal(ctx))
+		})
+
 		It("forwards any NetIn configuration via the port forwarder", func() {
-			Expect(networker.Network(logger, containerSpec, 42)).To(Succeed())
+			Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
 
 			for i, netIn := range containerSpec.NetIn {
-				actualPortForwarderSpec := fakePortForwarder.ForwardArgsForCall(i)
+				_, actualPortForwarderSpec := fakePortForwarder.ForwardArgsForCall(i)
 				Expect(actualPortForwarderSpec.FromPort).To(BeEquivalentTo(netIn.HostPort))
 				Expect(actualPortForwarderSpec.ToPort).To(BeEquivalentTo(netIn.ContainerPort))
 			}
@@ -231,14 +268,14 @@ var _ = Describe("Networker", func() {
 			})
 
 			It("returns a sensible error", func() {
-				err := networker.Network(logger, containerSpec, 42)
+				err := networker.Network(context.Background(), logger, containerSpec, 42)
 				Expect(err).To(MatchError("some error"))
 			})
 		})
 
 		It("opens any NetOut rules provided on the firewall", func() {
-			Expect(networker.Network(logger, containerSpec, 42)).To(Succeed())
-			_, _, _, appliedRules := fakeFirewallOpener.BulkOpenArgsForCall(0)
+			Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+			_, _, _, _, appliedRules := fakeFirewallOpener.BulkOpenArgsForCall(0)
 			Expect(appliedRules).To(Equal(containerSpec.NetOut))
 		})
 
@@ -248,10 +285,51 @@ var _ = Describe("Networker", func() {
 			})
 
 			It("returns a sensible error", func() {
-				err := networker.Network(logger, containerSpec, 42)
+				err := networker.Network(context.Background(), logger, containerSpec, 42)
 				Expect(err).To(MatchError("some error"))
 			})
 		})
+
+		It("does not shape traffic when no bandwidth limits are provided", func() {
+			Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+			Expect(fakeShaper.ShapeCallCount()).To(Equal(0))
+		})
+
+		Context("when bandwidth limits are provided", func() {
+			BeforeEach(func() {
+				containerSpec.Limits.Bandwidth = garden.BandwidthLimits{
+					RateInBytesPerSecond:      1000,
+					BurstRateInBytesPerSecond: 200,
+				}
+			})
+
+			It("shapes traffic on the host interface", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+				Expect(fakeShaper.ShapeCallCount()).To(Equal(1))
+				_, _, intf, mtu, limits := fakeShaper.ShapeArgsForCall(0)
+				Expect(intf).To(Equal("banana-iface"))
+				Expect(mtu).To(Equal(1200))
+				Expect(limits).To(Equal(containerSpec.Limits.Bandwidth))
+			})
+
+			It("passes the context on to the shaper", func() {
+				ctx := context.WithValue(context.Background(), "some-key", "some-value")
+				Expect(networker.Network(ctx, logger, containerSpec, 42)).To(Succeed())
+
+				shapeCtx, _, _, _, _ := fakeShaper.ShapeArgsForCall(0)
+				Expect(shapeCtx).To(Equal(ctx))
+			})
+
+			Context("when shaping fails", func() {
+				BeforeEach(func() {
+					fakeShaper.ShapeReturns(errors.New("shape-failed"))
+				})
+
+				It("returns the error", func() {
+					Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(MatchError("shape-failed"))
+				})
+			})
+		})
 	})
 
 	Describe("Capacity", func() {
@@ -392,7 +470,7 @@ var _ = Describe("Networker", func() {
 			fakeFirewallOpener.OpenReturns(errors.New("potato"))
 			Expect(networker.NetOut(lagertest.NewTestLogger(""), "some-handle", rule)).To(MatchError("potato"))
 
-			_, chainArg, handleArg, ruleArg := fakeFirewallOpener.OpenArgsForCall(0)
+			_, _, chainArg, handleArg, ruleArg := fakeFirewallOpener.OpenArgsForCall(0)
 			Expect(chainArg).To(Equal(networkConfig.IPTableInstance))
 			Expect(handleArg).To(Equal("some-handle"))
 			Expect(ruleArg).To(Equal(rule))
@@ -409,13 +487,71 @@ var _ = Describe("Networker", func() {
 			fakeFirewallOpener.BulkOpenReturns(errors.New("potato"))
 			Expect(networker.BulkNetOut(lagertest.NewTestLogger(""), "some-handle", rules)).To(MatchError("potato"))
 
-			_, chainArg, handleArg, rulesArg := fakeFirewallOpener.BulkOpenArgsForCall(0)
+			_, _, chainArg, handleArg, rulesArg := fakeFirewallOpener.BulkOpenArgsForCall(0)
 			Expect(chainArg).To(Equal(networkConfig.IPTableInstance))
 			Expect(handleArg).To(Equal("some-handle"))
 			Expect(rulesArg).To(Equal(rules))
 		})
 	})
 
+	Describe("LimitBandwidth", func() {
+		var limits garden.BandwidthLimits
+
+		BeforeEach(func() {
+			limits = garden.BandwidthLimits{
+				RateInBytesPerSecond:      1000,
+				BurstRateInBytesPerSecond: 200,
+			}
+		})
+
+		It("shapes traffic on the host interface", func() {
+			Expect(networker.LimitBandwidth(logger, "some-handle", limits)).To(Succeed())
+			Expect(fakeShaper.ShapeCallCount()).To(Equal(1))
+			_, _, intf, mtu, actualLimits := fakeShaper.ShapeArgsForCall(0)
+			Expect(intf).To(Equal("banana-iface"))
+			Expect(mtu).To(Equal(1200))
+			Expect(actualLimits).To(Equal(limits))
+		})
+
+		It("stores the limits in the ConfigStore", func() {
+			Expect(networker.LimitBandwidth(logger, "some-handle", limits)).To(Succeed())
+			Expect(fakeConfigStore.SetCallCount()).To(Equal(1))
+			handle, key, value := fakeConfigStore.SetArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(key).To(Equal(gardener.BandwidthLimitsKey))
+			Expect(value).To(MatchJSON(`{"rate":1000,"burst":200}`))
+		})
+
+		Context("when the rate or burst is zero", func() {
+			It("returns an error without shaping", func() {
+				Expect(networker.LimitBandwidth(logger, "some-handle", garden.BandwidthLimits{RateInBytesPerSecond: 1})).To(MatchError(ContainSubstring("must be greater than zero")))
+				Expect(networker.LimitBandwidth(logger, "some-handle", garden.BandwidthLimits{BurstRateInBytesPerSecond: 1})).To(MatchError(ContainSubstring("must be greater than zero")))
+				Expect(fakeShaper.ShapeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the config cannot be loaded", func() {
+			BeforeEach(func() {
+				config = map[string]string{}
+			})
+
+			It("returns an error", func() {
+				Expect(networker.LimitBandwidth(logger, "some-handle", limits)).To(MatchError(ContainSubstring("property not found")))
+			})
+		})
+
+		Context("when shaping fails", func() {
+			BeforeEach(func() {
+				fakeShaper.ShapeReturns(errors.New("shape-failed"))
+			})
+
+			It("returns the error and does not store the limits", func() {
+				Expect(networker.LimitBandwidth(logger, "some-handle", limits)).To(MatchError("shape-failed"))
+				Expect(fakeConfigStore.SetCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("NetIn", func() {
 		var (
 			externalPort  uint32
@@ -435,7 +571,7 @@ var _ = Describe("Networker", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakePortForwarder.ForwardCallCount()).To(Equal(1))
 
-			actualSpec := fakePortForwarder.ForwardArgsForCall(0)
+			_, actualSpec := fakePortForwarder.ForwardArgsForCall(0)
 			Expect(actualSpec.InstanceID).To(Equal(networkConfig.IPTableInstance))
 			Expect(actualSpec.ContainerIP).To(Equal(networkConfig.ContainerIP))
 			Expect(actualSpec.ExternalIP).To(Equal(networkConfig.ExternalIP))
@@ -457,7 +593,7 @@ var _ = Describe("Networker", func() {
 
 				Expect(fakePortPool.AcquireCallCount()).To(Equal(1))
 				Expect(fakePortForwarder.ForwardCallCount()).To(Equal(1))
-				spec := fakePortForwarder.ForwardArgsForCall(0)
+				_, spec := fakePortForwarder.ForwardArgsForCall(0)
 
 				Expect(spec.FromPort).To(Equal(externalPort))
 				Expect(spec.ToPort).To(Equal(containerPort))
@@ -574,6 +710,46 @@ var _ = Describe("Networker", func() {
 			})
 		})
 
+		It("does not shape traffic when no bandwidth limits were stored", func() {
+			Expect(networker.Restore(logger, "some-handle")).To(Succeed())
+			Expect(fakeShaper.ShapeCallCount()).To(Equal(0))
+		})
+
+		Context("when bandwidth limits were stored", func() {
+			BeforeEach(func() {
+				config[gardener.BandwidthLimitsKey] = `{"rate":1000,"burst":200}`
+			})
+
+			It("reapplies them to the host interface", func() {
+				Expect(networker.Restore(logger, "some-handle")).To(Succeed())
+				Expect(fakeShaper.ShapeCallCount()).To(Equal(1))
+				_, _, intf, mtu, limits := fakeShaper.ShapeArgsForCall(0)
+				Expect(intf).To(Equal("banana-iface"))
+				Expect(mtu).To(Equal(1200))
+				Expect(limits).To(Equal(garden.BandwidthLimits{RateInBytesPerSecond: 1000, BurstRateInBytesPerSecond: 200}))
+			})
+
+			Context("when shaping fails", func() {
+				BeforeEach(func() {
+					fakeShaper.ShapeReturns(errors.New("shape-failed"))
+				})
+
+				It("returns an appropriate error", func() {
+					Expect(networker.Restore(logger, "some-handle")).To(MatchError("reapplying bandwidth limits some-handle: shape-failed"))
+				})
+			})
+		})
+
+		Context("when the stored bandwidth limits are not valid JSON", func() {
+			BeforeEach(func() {
+				config[gardener.BandwidthLimitsKey] = "not-json"
+			})
+
+			It("returns an appropriate error", func() {
+				Expect(networker.Restore(logger, "some-handle")).To(MatchError(ContainSubstring("unmarshaling bandwidth limits some-handle")))
+			})
+		})
+
 		Context("when removing the IP from the subnet pool errors", func() {
 			BeforeEach(func() {
 				fakeSubnetPool.RemoveReturns(errors.New("failed-to-remove-from-subnet-pool"))
@@ -612,4 +788,348 @@ var _ = Describe("Networker", func() {
 			})
 		})
 	})
+	Describe("IPv6", func() {
+		var (
+			fakeSubnetPoolV6     *fake_subnet_pool.FakePool
+			fakePortForwarderV6  *fakes.FakePortForwarder
+			fakeFirewallOpenerV6 *fakes.FakeFirewallOpener
+			subnetV6             *net.IPNet
+		)
+
+		BeforeEach(func() {
+			fakeSubnetPoolV6 = new(fake_subnet_pool.FakePool)
+			fakePortForwarderV6 = new(fakes.FakePortForwarder)
+			fakeFirewallOpenerV6 = new(fakes.FakeFirewallOpener)
+
+			_, subnetV6, _ = net.ParseCIDR("fd00::/126")
+			fakeSubnetPoolV6.AcquireReturns(subnetV6, net.ParseIP("fd00::2"), nil)
+
+			networker = kawasaki.NewDualStack(
+				fakeSpecParser,
+				fakeSubnetPool,
+				fakeConfigCreator,
+				fakeConfigStore,
+				fakeConfigurer,
+				fakePortPool,
+				fakePortForwarder,
+				fakeFirewallOpener,
+				fakeShaper,
+				kawasaki.IPv6{
+					SubnetPool:     fakeSubnetPoolV6,
+					ExternalIP:     net.ParseIP("2001:db8::8"),
+					PortForwarder:  fakePortForwarderV6,
+					FirewallOpener: fakeFirewallOpenerV6,
+				},
+			)
+
+			// the properties set by Network are loaded by the later calls
+			fakeConfigStore.SetStub = func(handle, name, value string) {
+				config[name] = value
+			}
+		})
+
+		Describe("Network", func() {
+			BeforeEach(func() {
+				containerSpec.Network = "1.2.3.4/30, fd00::4/126"
+			})
+
+			It("parses the IPv4 and IPv6 parts of the spec separately", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				Expect(fakeSpecParser.ParseCallCount()).To(Equal(2))
+				_, spec := fakeSpecParser.ParseArgsForCall(0)
+				Expect(spec).To(Equal("1.2.3.4/30"))
+				_, specV6 := fakeSpecParser.ParseArgsForCall(1)
+				Expect(specV6).To(Equal("fd00::4/126"))
+			})
+
+			It("acquires an IPv6 subnet and IP, and applies them with the rest of the config", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+				Expect(fakeSubnetPoolV6.AcquireCallCount()).To(Equal(1))
+
+				_, _, actualNetConfig, _ := fakeConfigurer.ApplyArgsForCall(0)
+				Expect(actualNetConfig.ContainerIP).To(Equal(networkConfig.ContainerIP))
+				Expect(actualNetConfig.ContainerIPv6).To(Equal(net.ParseIP("fd00::2")))
+				Expect(actualNetConfig.BridgeIPv6).To(Equal(net.ParseIP("fd00::1")))
+				Expect(actualNetConfig.SubnetV6).To(Equal(subnetV6))
+				Expect(actualNetConfig.ExternalIPv6).To(Equal(net.ParseIP("2001:db8::8")))
+			})
+
+			It("stores the IPv6 config", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				Expect(config[gardener.ContainerIPv6Key]).To(Equal("fd00::2"))
+				Expect(config[gardener.BridgeIPv6Key]).To(Equal("fd00::1"))
+				Expect(config[gardener.ExternalIPv6Key]).To(Equal("2001:db8::8"))
+				Expect(config["kawasaki.subnet-v6"]).To(Equal("fd00::/126"))
+			})
+
+			It("forwards the NetIn ports over IPv4 and IPv6", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				Expect(fakePortForwarder.ForwardCallCount()).To(Equal(2))
+				Expect(fakePortForwarderV6.ForwardCallCount()).To(Equal(2))
+				_, specV6 := fakePortForwarderV6.ForwardArgsForCall(0)
+				Expect(specV6).To(Equal(kawasaki.PortForwarderSpec{
+					InstanceID:  networkConfig.IPTableInstance,
+					Handle:      "some-handle",
+					FromPort:    9999,
+					ToPort:      8080,
+					ContainerIP: net.ParseIP("fd00::2"),
+					ExternalIP:  net.ParseIP("2001:db8::8"),
+				}))
+			})
+
+			It("opens the NetOut rules with both firewall openers", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				Expect(fakeFirewallOpener.BulkOpenCallCount()).To(Equal(1))
+				Expect(fakeFirewallOpenerV6.BulkOpenCallCount()).To(Equal(1))
+				_, _, _, _, rules := fakeFirewallOpenerV6.BulkOpenArgsForCall(0)
+				Expect(rules).To(Equal(containerSpec.NetOut))
+			})
+
+			Context("when the spec has two networks of the same IP version", func() {
+				It("returns an error", func() {
+					containerSpec.Network = "1.2.3.4/30,5.6.7.8/30"
+					Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(MatchError(
+						"network spec '1.2.3.4/30,5.6.7.8/30' has more than one IPv4 network",
+					))
+				})
+			})
+
+			Context("when acquiring the IPv6 subnet fails", func() {
+				It("releases the IPv4 subnet and returns the error", func() {
+					ip, subnet, _ := net.ParseCIDR("1.2.3.4/30")
+					fakeSubnetPool.AcquireReturns(subnet, ip, nil)
+					fakeSubnetPoolV6.AcquireReturns(nil, nil, errors.New("no v6 for you"))
+
+					Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(MatchError("no v6 for you"))
+					Expect(fakeSubnetPool.ReleaseCallCount()).To(Equal(1))
+					releasedSubnet, releasedIP := fakeSubnetPool.ReleaseArgsForCall(0)
+					Expect(releasedSubnet).To(Equal(subnet))
+					Expect(releasedIP).To(Equal(ip))
+					Expect(fakeConfigurer.ApplyCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Describe("containers with only IPv6", func() {
+			BeforeEach(func() {
+				containerSpec.Network = "fd00::4/126"
+				containerSpec.NetOut = []garden.NetOutRule{{Protocol: garden.ProtocolTCP}}
+
+				configV6 := networkConfig
+				configV6.ContainerIP = nil
+				configV6.BridgeIP = nil
+				configV6.ExternalIP = nil
+				configV6.Subnet = nil
+				configV6.ContainerIPv6 = net.ParseIP("fd00::2")
+				configV6.BridgeIPv6 = net.ParseIP("fd00::1")
+				configV6.SubnetV6 = subnetV6
+				fakeConfigCreator.CreateReturns(configV6, nil)
+
+				for _, name := range []string{gardener.ContainerIPKey, gardener.BridgeIPKey, gardener.ExternalIPKey, "kawasaki.subnet"} {
+					delete(config, name)
+				}
+			})
+
+			It("acquires only an IPv6 subnet, and creates the config from it", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				Expect(fakeSubnetPool.AcquireCallCount()).To(Equal(0))
+				Expect(fakeSubnetPoolV6.AcquireCallCount()).To(Equal(1))
+
+				_, _, subnet, ip := fakeConfigCreator.CreateArgsForCall(0)
+				Expect(subnet).To(Equal(subnetV6))
+				Expect(ip).To(Equal(net.ParseIP("fd00::2")))
+
+				_, _, actualNetConfig, _ := fakeConfigurer.ApplyArgsForCall(0)
+				Expect(actualNetConfig.ContainerIP).To(BeNil())
+				Expect(actualNetConfig.ExternalIPv6).To(Equal(net.ParseIP("2001:db8::8")))
+			})
+
+			It("stores only the IPv6 config", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				Expect(config[gardener.ContainerIPv6Key]).To(Equal("fd00::2"))
+				Expect(config).NotTo(HaveKey(gardener.ContainerIPKey))
+				Expect(config).NotTo(HaveKey("kawasaki.subnet"))
+			})
+
+			It("forwards the NetIn ports only over IPv6", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				Expect(fakePortForwarder.ForwardCallCount()).To(Equal(0))
+				Expect(fakePortForwarderV6.ForwardCallCount()).To(Equal(2))
+			})
+
+			It("refuses to map ports when there is no external IPv6 address", func() {
+				containerSpec.NetIn = nil
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+				delete(config, gardener.ExternalIPv6Key)
+
+				_, _, err := networker.NetIn(logger, "some-handle", 1234, 5678)
+				Expect(err).To(MatchError("cannot map ports to a container with only IPv6 without an external IPv6 address"))
+				Expect(fakePortPool.AcquireCallCount()).To(Equal(0))
+			})
+
+			It("opens the NetOut rules only with the IPv6 firewall opener", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				Expect(fakeFirewallOpener.BulkOpenCallCount()).To(Equal(0))
+				Expect(fakeFirewallOpenerV6.BulkOpenCallCount()).To(Equal(1))
+			})
+
+			It("refuses IPv4 networks in NetOut rules", func() {
+				containerSpec.NetOut = nil
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				rule := garden.NetOutRule{Networks: []garden.IPRange{garden.IPRangeFromIP(net.ParseIP("8.8.8.8"))}}
+				Expect(networker.NetOut(logger, "some-handle", rule)).To(MatchError(
+					"cannot open IPv4 network 8.8.8.8-8.8.8.8: container has no IPv4 address",
+				))
+				Expect(fakeFirewallOpenerV6.OpenCallCount()).To(Equal(0))
+			})
+
+			It("releases only the IPv6 subnet on Destroy, and destroys the bridge once it is free", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+				Expect(networker.Destroy(logger, "some-handle")).To(Succeed())
+
+				Expect(fakeSubnetPool.ReleaseCallCount()).To(Equal(0))
+				Expect(fakeSubnetPoolV6.ReleaseCallCount()).To(Equal(1))
+
+				Expect(fakeSubnetPool.RunIfFreeCallCount()).To(Equal(0))
+				Expect(fakeSubnetPoolV6.RunIfFreeCallCount()).To(Equal(1))
+				subnet, _ := fakeSubnetPoolV6.RunIfFreeArgsForCall(0)
+				Expect(subnet).To(Equal(subnetV6))
+			})
+
+			It("removes only the IPv6 subnet from its pool on Restore", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+				Expect(networker.Restore(logger, "some-handle")).To(Succeed())
+
+				Expect(fakeSubnetPool.RemoveCallCount()).To(Equal(0))
+				Expect(fakeSubnetPoolV6.RemoveCallCount()).To(Equal(1))
+			})
+
+			Context("when acquiring the IPv6 subnet fails", func() {
+				It("returns the error", func() {
+					fakeSubnetPoolV6.AcquireReturns(nil, nil, errors.New("no v6 for you"))
+
+					Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(MatchError("no v6 for you"))
+					Expect(fakeSubnetPool.ReleaseCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Describe("when every container is only given IPv6", func() {
+			BeforeEach(func() {
+				networker = kawasaki.NewDualStack(
+					fakeSpecParser,
+					fakeSubnetPool,
+					fakeConfigCreator,
+					fakeConfigStore,
+					fakeConfigurer,
+					fakePortPool,
+					fakePortForwarder,
+					fakeFirewallOpener,
+					fakeShaper,
+					kawasaki.IPv6{
+						SubnetPool:     fakeSubnetPoolV6,
+						ExternalIP:     net.ParseIP("2001:db8::8"),
+						PortForwarder:  fakePortForwarderV6,
+						FirewallOpener: fakeFirewallOpenerV6,
+						Only:           true,
+					},
+				)
+			})
+
+			It("gives containers without a network spec only IPv6", func() {
+				containerSpec.Network = ""
+				containerSpec.NetIn = nil
+				containerSpec.NetOut = nil
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+
+				Expect(fakeSubnetPool.AcquireCallCount()).To(Equal(0))
+				Expect(fakeSubnetPoolV6.AcquireCallCount()).To(Equal(1))
+				_, _, subnet, _ := fakeConfigCreator.CreateArgsForCall(0)
+				Expect(subnet).To(Equal(subnetV6))
+			})
+
+			It("refuses network specs which name an IPv4 network", func() {
+				containerSpec.Network = "1.2.3.4/30"
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(MatchError(
+					"network spec '1.2.3.4/30' names an IPv4 network, but containers are only given IPv6 addresses",
+				))
+				Expect(fakeSubnetPool.AcquireCallCount()).To(Equal(0))
+				Expect(fakeSubnetPoolV6.AcquireCallCount()).To(Equal(0))
+			})
+
+			It("has the capacity of the IPv6 pool", func() {
+				fakeSubnetPool.CapacityReturns(16)
+				fakeSubnetPoolV6.CapacityReturns(64)
+				Expect(networker.Capacity()).To(BeEquivalentTo(64))
+			})
+		})
+
+		Describe("Capacity", func() {
+			It("is the capacity of the smaller pool", func() {
+				fakeSubnetPool.CapacityReturns(64)
+				fakeSubnetPoolV6.CapacityReturns(16)
+				Expect(networker.Capacity()).To(BeEquivalentTo(16))
+			})
+		})
+
+		Describe("Destroy", func() {
+			It("releases the IPv6 subnet", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+				Expect(networker.Destroy(logger, "some-handle")).To(Succeed())
+
+				Expect(fakeSubnetPoolV6.ReleaseCallCount()).To(Equal(1))
+				subnet, ip := fakeSubnetPoolV6.ReleaseArgsForCall(0)
+				Expect(subnet).To(Equal(subnetV6))
+				Expect(ip).To(Equal(net.ParseIP("fd00::2")))
+			})
+		})
+
+		Describe("Restore", func() {
+			It("removes the IPv6 subnet from the pool", func() {
+				Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+				Expect(networker.Restore(logger, "some-handle")).To(Succeed())
+
+				Expect(fakeSubnetPoolV6.RemoveCallCount()).To(Equal(1))
+				subnet, ip := fakeSubnetPoolV6.RemoveArgsForCall(0)
+				Expect(subnet).To(Equal(subnetV6))
+				Expect(ip).To(Equal(net.ParseIP("fd00::2")))
+			})
+		})
+
+		Describe("NetOut", func() {
+			It("only opens the IPv4 firewall for containers without IPv6", func() {
+				Expect(networker.NetOut(logger, "some-handle", garden.NetOutRule{})).To(Succeed())
+
+				Expect(fakeFirewallOpener.OpenCallCount()).To(Equal(1))
+				Expect(fakeFirewallOpenerV6.OpenCallCount()).To(Equal(0))
+			})
+
+			It("refuses IPv6 networks for containers without IPv6", func() {
+				rule := garden.NetOutRule{Networks: []garden.IPRange{garden.IPRangeFromIP(net.ParseIP("2001:db8::1"))}}
+				Expect(networker.NetOut(logger, "some-handle", rule)).To(MatchError(
+					"cannot open IPv6 network 2001:db8::1-2001:db8::1: container has no IPv6 address",
+				))
+				Expect(fakeFirewallOpener.OpenCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when IPv6 is not enabled", func() {
+		It("refuses specs with an IPv6 network", func() {
+			containerSpec.Network = "fd00::4/126"
+			Expect(networker.Network(context.Background(), logger, containerSpec, 42)).To(MatchError(
+				"a network with IPv6 was requested, but IPv6 networking is not enabled",
+			))
+			Expect(fakeSubnetPool.AcquireCallCount()).To(Equal(0))
+		})
+	})
 })
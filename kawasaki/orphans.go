@@ -0,0 +1,162 @@
+package kawasaki
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . InstanceChainLister
+
+type InstanceChainLister interface {
+	InstanceChains() ([]string, error)
+}
+
+//go:generate counterfeiter . InterfaceLister
+
+type InterfaceLister interface {
+	List() ([]string, error)
+}
+
+//go:generate counterfeiter . BridgeDestroyer
+
+type BridgeDestroyer interface {
+	Destroy(bridge string) error
+}
+
+//go:generate counterfeiter . LinkDeleter
+
+type LinkDeleter interface {
+	Delete(name string) error
+}
+
+// OrphanedChains finds the iptables instance chains which do not belong to the
+// network of any container
+type OrphanedChains struct {
+	lister      InstanceChainLister
+	destroyer   InstanceChainCreator
+	configStore ConfigStore
+}
+
+func NewOrphanedChains(lister InstanceChainLister, destroyer InstanceChainCreator, configStore ConfigStore) *OrphanedChains {
+	return &OrphanedChains{
+		lister:      lister,
+		destroyer:   destroyer,
+		configStore: configStore,
+	}
+}
+
+func (o *OrphanedChains) Orphans(log lager.Logger, handles []string) ([]string, error) {
+	instances, err := o.lister.InstanceChains()
+	if err != nil {
+		return nil, err
+	}
+
+	return unknown(instances, known(o.configStore, iptableInstanceKey, handles)), nil
+}
+
+func (o *OrphanedChains) RemoveOrphan(log lager.Logger, instance string) error {
+	return o.destroyer.Destroy(log, instance)
+}
+
+// OrphanedBridges finds the bridges created by kawasaki which are not used by
+// the network of any container
+type OrphanedBridges struct {
+	lister      InterfaceLister
+	destroyer   BridgeDestroyer
+	prefix      string
+	configStore ConfigStore
+}
+
+func NewOrphanedBridges(lister InterfaceLister, destroyer BridgeDestroyer, interfacePrefix string, configStore ConfigStore) *OrphanedBridges {
+	return &OrphanedBridges{
+		lister:      lister,
+		destroyer:   destroyer,
+		prefix:      interfacePrefix + "brdg-",
+		configStore: configStore,
+	}
+}
+
+func (o *OrphanedBridges) Orphans(log lager.Logger, handles []string) ([]string, error) {
+	intfs, err := o.lister.List()
+	if err != nil {
+		return nil, err
+	}
+
+	bridges := []string{}
+	for _, intf := range intfs {
+		if strings.HasPrefix(intf, o.prefix) {
+			bridges = append(bridges, intf)
+		}
+	}
+
+	return unknown(bridges, known(o.configStore, bridgeIntfKey, handles)), nil
+}
+
+func (o *OrphanedBridges) RemoveOrphan(log lager.Logger, bridge string) error {
+	return o.destroyer.Destroy(bridge)
+}
+
+// OrphanedVeths finds the host ends of the veth pairs created by kawasaki
+// which do not belong to the network of any container
+type OrphanedVeths struct {
+	lister      InterfaceLister
+	deleter     LinkDeleter
+	pattern     *regexp.Regexp
+	configStore ConfigStore
+}
+
+func NewOrphanedVeths(lister InterfaceLister, deleter LinkDeleter, interfacePrefix string, configStore ConfigStore) *OrphanedVeths {
+	return &OrphanedVeths{
+		lister:  lister,
+		deleter: deleter,
+		// host interfaces are named after the 11 character ids from the
+		// sequential id generator
+		pattern:     regexp.MustCompile(fmt.Sprintf("^%s[0-9a-v]{11}-0$", regexp.QuoteMeta(interfacePrefix))),
+		configStore: configStore,
+	}
+}
+
+func (o *OrphanedVeths) Orphans(log lager.Logger, handles []string) ([]string, error) {
+	intfs, err := o.lister.List()
+	if err != nil {
+		return nil, err
+	}
+
+	veths := []string{}
+	for _, intf := range intfs {
+		if o.pattern.MatchString(intf) {
+			veths = append(veths, intf)
+		}
+	}
+
+	return unknown(veths, known(o.configStore, hostIntfKey, handles)), nil
+}
+
+func (o *OrphanedVeths) RemoveOrphan(log lager.Logger, veth string) error {
+	return o.deleter.Delete(veth)
+}
+
+func known(configStore ConfigStore, key string, handles []string) map[string]bool {
+	values := make(map[string]bool)
+	for _, handle := range handles {
+		if value, ok := configStore.Get(handle, key); ok {
+			values[value] = true
+		}
+	}
+
+	return values
+}
+
+func unknown(ids []string, known map[string]bool) []string {
+	result := []string{}
+	for _, id := range ids {
+		if !known[id] {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
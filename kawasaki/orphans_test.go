@@ -0,0 +1,177 @@
+package kawasaki_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/guardian/kawasaki"
+	fakes "code.cloudfoundry.org/guardian/kawasaki/kawasakifakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Orphans", func() {
+	var (
+		fakeConfigStore *fakes.FakeConfigStore
+		logger          lager.Logger
+	)
+
+	BeforeEach(func() {
+		fakeConfigStore = new(fakes.FakeConfigStore)
+		fakeConfigStore.GetStub = func(handle, name string) (string, bool) {
+			config := map[string]map[string]string{
+				"handle1": {
+					"kawasaki.iptable-inst":     "instance1",
+					"kawasaki.bridge-interface": "w1brdg-0afe0000",
+					"kawasaki.host-interface":   "w1abcdefghijk-0",
+				},
+				"handle2": {
+					"kawasaki.iptable-inst":     "instance2",
+					"kawasaki.bridge-interface": "w1brdg-0afe0004",
+					"kawasaki.host-interface":   "w1lmnopqrstuv-0",
+				},
+			}
+
+			value, ok := config[handle][name]
+			return value, ok
+		}
+
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	Describe("OrphanedChains", func() {
+		var (
+			fakeLister    *fakes.FakeInstanceChainLister
+			fakeDestroyer *fakes.FakeInstanceChainCreator
+			orphans       *kawasaki.OrphanedChains
+		)
+
+		BeforeEach(func() {
+			fakeLister = new(fakes.FakeInstanceChainLister)
+			fakeLister.InstanceChainsReturns([]string{"instance1", "orphan", "instance2"}, nil)
+			fakeDestroyer = new(fakes.FakeInstanceChainCreator)
+
+			orphans = kawasaki.NewOrphanedChains(fakeLister, fakeDestroyer, fakeConfigStore)
+		})
+
+		It("returns the instance chains which do not belong to any of the containers", func() {
+			Expect(orphans.Orphans(logger, []string{"handle1", "handle2", "handle3"})).To(Equal([]string{"orphan"}))
+		})
+
+		It("treats the chains of containers which are not given as orphans", func() {
+			Expect(orphans.Orphans(logger, []string{"handle1"})).To(Equal([]string{"orphan", "instance2"}))
+		})
+
+		Context("when listing the chains fails", func() {
+			BeforeEach(func() {
+				fakeLister.InstanceChainsReturns(nil, errors.New("iptables failed"))
+			})
+
+			It("returns the error", func() {
+				_, err := orphans.Orphans(logger, []string{})
+				Expect(err).To(MatchError("iptables failed"))
+			})
+		})
+
+		Describe("RemoveOrphan", func() {
+			It("destroys the instance chain", func() {
+				Expect(orphans.RemoveOrphan(logger, "orphan")).To(Succeed())
+
+				Expect(fakeDestroyer.DestroyCallCount()).To(Equal(1))
+				_, instance := fakeDestroyer.DestroyArgsForCall(0)
+				Expect(instance).To(Equal("orphan"))
+			})
+
+			It("returns the error when destroying fails", func() {
+				fakeDestroyer.DestroyReturns(errors.New("boom"))
+				Expect(orphans.RemoveOrphan(logger, "orphan")).To(MatchError("boom"))
+			})
+		})
+	})
+
+	Describe("OrphanedBridges", func() {
+		var (
+			fakeLister    *fakes.FakeInterfaceLister
+			fakeDestroyer *fakes.FakeBridgeDestroyer
+			orphans       *kawasaki.OrphanedBridges
+		)
+
+		BeforeEach(func() {
+			fakeLister = new(fakes.FakeInterfaceLister)
+			fakeLister.ListReturns([]string{"lo", "eth0", "w1brdg-0afe0000", "w1brdg-0afe0008", "w2brdg-0afe000c", "w1abc-0"}, nil)
+			fakeDestroyer = new(fakes.FakeBridgeDestroyer)
+
+			orphans = kawasaki.NewOrphanedBridges(fakeLister, fakeDestroyer, "w1", fakeConfigStore)
+		})
+
+		It("returns the bridges with the prefix which are not used by any of the containers", func() {
+			Expect(orphans.Orphans(logger, []string{"handle1", "handle2"})).To(Equal([]string{"w1brdg-0afe0008"}))
+		})
+
+		Context("when listing the interfaces fails", func() {
+			BeforeEach(func() {
+				fakeLister.ListReturns(nil, errors.New("netlink failed"))
+			})
+
+			It("returns the error", func() {
+				_, err := orphans.Orphans(logger, []string{})
+				Expect(err).To(MatchError("netlink failed"))
+			})
+		})
+
+		Describe("RemoveOrphan", func() {
+			It("destroys the bridge", func() {
+				Expect(orphans.RemoveOrphan(logger, "w1brdg-0afe0008")).To(Succeed())
+
+				Expect(fakeDestroyer.DestroyCallCount()).To(Equal(1))
+				Expect(fakeDestroyer.DestroyArgsForCall(0)).To(Equal("w1brdg-0afe0008"))
+			})
+		})
+	})
+
+	Describe("OrphanedVeths", func() {
+		var (
+			fakeLister  *fakes.FakeInterfaceLister
+			fakeDeleter *fakes.FakeLinkDeleter
+			orphans     *kawasaki.OrphanedVeths
+		)
+
+		BeforeEach(func() {
+			fakeLister = new(fakes.FakeInterfaceLister)
+			fakeLister.ListReturns([]string{
+				"lo", "wg-0", "w1brdg-0afe0000",
+				"w1abcdefghijk-0", "w1lmnopqrstuv-0", "w1000000000aa-0",
+				"w1000000000ab-1", "w2000000000ac-0",
+			}, nil)
+			fakeDeleter = new(fakes.FakeLinkDeleter)
+
+			orphans = kawasaki.NewOrphanedVeths(fakeLister, fakeDeleter, "w1", fakeConfigStore)
+		})
+
+		It("returns the host veths with the prefix which do not belong to any of the containers", func() {
+			Expect(orphans.Orphans(logger, []string{"handle1", "handle2"})).To(Equal([]string{"w1000000000aa-0"}))
+		})
+
+		Context("when listing the interfaces fails", func() {
+			BeforeEach(func() {
+				fakeLister.ListReturns(nil, errors.New("netlink failed"))
+			})
+
+			It("returns the error", func() {
+				_, err := orphans.Orphans(logger, []string{})
+				Expect(err).To(MatchError("netlink failed"))
+			})
+		})
+
+		Describe("RemoveOrphan", func() {
+			It("deletes the veth", func() {
+				Expect(orphans.RemoveOrphan(logger, "w1000000000aa-0")).To(Succeed())
+
+				Expect(fakeDeleter.DeleteCallCount()).To(Equal(1))
+				Expect(fakeDeleter.DeleteArgsForCall(0)).To(Equal("w1000000000aa-0"))
+			})
+		})
+	})
+})
@@ -12,7 +12,7 @@ import (
 
 //go:generate counterfeiter . HostFileCompiler
 type HostFileCompiler interface {
-	Compile(log lager.Logger, containerIp net.IP, handle string) ([]byte, error)
+	Compile(log lager.Logger, containerIps []net.IP, handle string) ([]byte, error)
 }
 
 //go:generate counterfeiter . ResolvCompiler
@@ -30,7 +30,14 @@ type ResolvConfigurer struct {
 func (d *ResolvConfigurer) Configure(log lager.Logger, cfg NetworkConfig, pid int) error {
 	log = log.Session("dns-resolve-configure")
 
-	containerHostsContents, err := d.HostsFileCompiler.Compile(log, cfg.ContainerIP, cfg.ContainerHandle)
+	var containerIPs []net.IP
+	for _, ip := range []net.IP{cfg.ContainerIP, cfg.ContainerIPv6} {
+		if ip != nil {
+			containerIPs = append(containerIPs, ip)
+		}
+	}
+
+	containerHostsContents, err := d.HostsFileCompiler.Compile(log, containerIPs, cfg.ContainerHandle)
 	if err != nil {
 		log.Error("compiling-hosts-file", err)
 		return err
@@ -46,7 +53,13 @@ func (d *ResolvConfigurer) Configure(log lager.Logger, cfg NetworkConfig, pid in
 		log.Error("reading-host-resolv-file", err)
 		return err
 	}
-	resolvEntries := d.ResolvCompiler.Determine(string(hostResolvContents), cfg.BridgeIP, cfg.PluginNameservers, cfg.OperatorNameservers, cfg.AdditionalNameservers)
+	// containers with only IPv6 reach the host over the IPv6 bridge address
+	hostIP := cfg.BridgeIP
+	if hostIP == nil {
+		hostIP = cfg.BridgeIPv6
+	}
+
+	resolvEntries := d.ResolvCompiler.Determine(string(hostResolvContents), hostIP, cfg.PluginNameservers, cfg.OperatorNameservers, cfg.AdditionalNameservers)
 
 	containerResolvContents := ""
 	for _, resolvEntry := range resolvEntries {
@@ -68,6 +68,45 @@ var _ = Describe("ResolvConfigurer", func() {
 		Expect(string(hostsFileContents)).To(Equal(compiledHostsFile))
 	})
 
+	It("should compile the hosts file with the container's addresses", func() {
+		cfg := kawasaki.NetworkConfig{
+			ContainerHandle: handle,
+			ContainerIP:     net.ParseIP("10.0.0.2"),
+			ContainerIPv6:   net.ParseIP("fd00::2"),
+		}
+		Expect(dnsResolv.Configure(log, cfg, 42)).To(Succeed())
+
+		_, actualIPs, actualHandle := fakeHostsFileCompiler.CompileArgsForCall(0)
+		Expect(actualIPs).To(Equal([]net.IP{net.ParseIP("10.0.0.2"), net.ParseIP("fd00::2")}))
+		Expect(actualHandle).To(Equal(handle))
+	})
+
+	Context("when the container has only IPv6", func() {
+		var cfg kawasaki.NetworkConfig
+
+		BeforeEach(func() {
+			cfg = kawasaki.NetworkConfig{
+				ContainerHandle: handle,
+				ContainerIPv6:   net.ParseIP("fd00::2"),
+				BridgeIPv6:      net.ParseIP("fd00::1"),
+			}
+		})
+
+		It("should compile the hosts file with only its IPv6 address", func() {
+			Expect(dnsResolv.Configure(log, cfg, 42)).To(Succeed())
+
+			_, actualIPs, _ := fakeHostsFileCompiler.CompileArgsForCall(0)
+			Expect(actualIPs).To(Equal([]net.IP{net.ParseIP("fd00::2")}))
+		})
+
+		It("should determine the nameservers with the IPv6 address of the host", func() {
+			Expect(dnsResolv.Configure(log, cfg, 42)).To(Succeed())
+
+			_, actualHostIP, _, _, _ := fakeResolvCompiler.DetermineArgsForCall(0)
+			Expect(actualHostIP).To(Equal(net.ParseIP("fd00::1")))
+		})
+	})
+
 	Context("when compiling the hosts file fails", func() {
 		It("should return an error", func() {
 			fakeHostsFileCompiler.CompileReturns(nil, errors.New("banana error"))
@@ -34,10 +34,16 @@ func ParseSpec(spec string) (subnets.SubnetSelector, subnets.IPSelector, error)
 	return subnetSelector, ipSelector, nil
 }
 
+// suffixIfNeeded gives a spec with no prefix length the length of the subnets
+// allocated dynamically for its IP version
 func suffixIfNeeded(spec string) string {
-	if !strings.Contains(spec, "/") {
-		spec = spec + "/30"
+	if strings.Contains(spec, "/") {
+		return spec
 	}
 
-	return spec
+	if ip := net.ParseIP(spec); ip != nil && ip.To4() == nil {
+		return spec + "/126"
+	}
+
+	return spec + "/30"
 }
@@ -43,6 +43,17 @@ var _ = Describe("ParseSpec", func() {
 			})
 		})
 
+		Context("when it is an IPv6 address without a prefix length", func() {
+			It("statically allocates the requested Network from Subnets as a /126", func() {
+				subnetReq, ipReq, err := kawasaki.ParseSpec("fd00::6")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, sn, _ := net.ParseCIDR("fd00::4/126")
+				Expect(subnetReq).To(Equal(subnets.StaticSubnetSelector{IPNet: sn}))
+				Expect(ipReq).To(Equal(subnets.StaticIPSelector{IP: net.ParseIP("fd00::6")}))
+			})
+		})
+
 		Context("when the network parameter has non-zero host bits", func() {
 			It("statically allocates an IP address based on the network parameter", func() {
 				subnetReq, ipReq, err := kawasaki.ParseSpec("1.2.3.1/20")
@@ -2,6 +2,21 @@ package subnets
 
 import "net"
 
+// SubnetMask returns the mask of the subnets allocated to containers from a
+// range with the given IP: a /30 for IPv4, and a /126, which also has four
+// addresses, for IPv6
+func SubnetMask(ip net.IP) net.IPMask {
+	if isIPv4(ip) {
+		return net.CIDRMask(30, 32)
+	}
+
+	return net.CIDRMask(126, 128)
+}
+
+func isIPv4(ip net.IP) bool {
+	return ip.To4() != nil
+}
+
 func equals(a *net.IPNet, b *net.IPNet) bool {
 	aOnes, aBits := a.Mask.Size()
 	bOnes, bBits := b.Mask.Size()
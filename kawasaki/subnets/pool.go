@@ -26,7 +26,7 @@ type Pool interface {
 	// Remove an IP address so it appears to be associated with the given subnet.
 	Remove(*net.IPNet, net.IP) error
 
-	// Returns the number of /30 subnets which can be Acquired by a DynamicSubnetSelector.
+	// Returns the number of subnets which can be Acquired by a DynamicSubnetSelector.
 	Capacity() int
 
 	// Run the provided callback if the given subnet is not in use
@@ -121,11 +121,17 @@ func (p *pool) Release(subnet *net.IPNet, ip net.IP) error {
 	return ErrReleasedUnallocatedSubnet
 }
 
-// Capacity returns the number of /30 subnets that can be allocated
-// from the pool's dynamic allocation range.
+// Capacity returns the number of subnets that can be allocated
+// from the pool's dynamic allocation range. The capacity of an IPv6
+// range is capped at math.MaxInt32.
 func (m *pool) Capacity() int {
-	masked, total := m.dynamicRange.Mask.Size()
-	return int(math.Pow(2, float64(total-masked)) / 4)
+	masked, _ := m.dynamicRange.Mask.Size()
+	subnetMasked, _ := SubnetMask(m.dynamicRange.IP).Size()
+	if subnetMasked-masked >= 31 {
+		return math.MaxInt32
+	}
+
+	return int(math.Pow(2, float64(subnetMasked-masked)))
 }
 
 func (p *pool) RunIfFree(subnet *net.IPNet, cb func() error) error {
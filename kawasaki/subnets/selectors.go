@@ -11,6 +11,10 @@ type StaticSubnetSelector struct {
 }
 
 func (s StaticSubnetSelector) SelectSubnet(dynamic *net.IPNet, existing []*net.IPNet) (*net.IPNet, error) {
+	if isIPv4(dynamic.IP) != isIPv4(s.IPNet.IP) {
+		return nil, fmt.Errorf("the requested subnet (%v) is not of the same IP version as the dynamic allocation range (%v)", s.IPNet.String(), dynamic.String())
+	}
+
 	if overlaps(dynamic, s.IPNet) {
 		return nil, fmt.Errorf("the requested subnet (%v) overlaps the dynamic allocation range (%v)", s.IPNet.String(), dynamic.String())
 	}
@@ -26,7 +30,8 @@ func (s StaticSubnetSelector) SelectSubnet(dynamic *net.IPNet, existing []*net.I
 
 type dynamicSubnetSelector int
 
-// DynamicSubnetSelector requests the next unallocated ("dynamic") subnet from the dynamic range.
+// DynamicSubnetSelector requests the next unallocated ("dynamic") subnet from the dynamic range,
+// which is a /30 for an IPv4 range, or a /126 for an IPv6 one.
 // Returns an error if there are no remaining subnets in the dynamic range.
 var DynamicSubnetSelector dynamicSubnetSelector = 0
 
@@ -37,7 +42,7 @@ func (dynamicSubnetSelector) SelectSubnet(dynamic *net.IPNet, existing []*net.IP
 	}
 
 	min := dynamic.IP
-	mask := net.CIDRMask(30, 32) // /30
+	mask := SubnetMask(dynamic.IP)
 	for ip := min; dynamic.Contains(ip); ip = next(ip) {
 		subnet := &net.IPNet{IP: ip, Mask: mask}
 		ip = next(next(next(ip)))
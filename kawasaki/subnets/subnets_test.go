@@ -2,6 +2,7 @@ package subnets_test
 
 import (
 	"errors"
+	"math"
 	"net"
 	"runtime"
 
@@ -702,6 +703,54 @@ var _ = Describe("Subnet Pool", func() {
 			})).To(MatchError("banana"))
 		})
 	})
+
+	Describe("IPv6", func() {
+		BeforeEach(func() {
+			defaultSubnetPool = subnetPool("fd00::/120")
+		})
+
+		It("counts the /126 subnets in the dynamic allocation range", func() {
+			Expect(subnetpool.Capacity()).To(Equal(64))
+		})
+
+		Context("when the dynamic allocation range is very large", func() {
+			BeforeEach(func() {
+				defaultSubnetPool = subnetPool("fd00::/64")
+			})
+
+			It("caps the capacity", func() {
+				Expect(subnetpool.Capacity()).To(Equal(math.MaxInt32))
+			})
+		})
+
+		It("allocates /126 subnets dynamically, skipping the network and gateway IPs", func() {
+			network, ip, err := subnetpool.Acquire(logger, subnets.DynamicSubnetSelector, subnets.DynamicIPSelector)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.String()).To(Equal("fd00::/126"))
+			Expect(ip.String()).To(Equal("fd00::2"))
+			Expect(subnets.GatewayIP(network).String()).To(Equal("fd00::1"))
+
+			network, _, err = subnetpool.Acquire(logger, subnets.DynamicSubnetSelector, subnets.DynamicIPSelector)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.String()).To(Equal("fd00::4/126"))
+		})
+
+		It("allocates static subnets outside the dynamic allocation range", func() {
+			_, static := networkParms("fd01::/126")
+
+			network, ip, err := subnetpool.Acquire(logger, subnets.StaticSubnetSelector{IPNet: static}, subnets.DynamicIPSelector)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.String()).To(Equal("fd01::/126"))
+			Expect(ip.String()).To(Equal("fd01::2"))
+		})
+
+		It("refuses static IPv4 subnets", func() {
+			_, static := networkParms("10.2.3.0/30")
+
+			_, _, err := subnetpool.Acquire(logger, subnets.StaticSubnetSelector{IPNet: static}, subnets.DynamicIPSelector)
+			Expect(err).To(MatchError("the requested subnet (10.2.3.0/30) is not of the same IP version as the dynamic allocation range (fd00::/120)"))
+		})
+	})
 })
 
 func subnetPool(networkString string) *net.IPNet {
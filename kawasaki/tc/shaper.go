@@ -0,0 +1,91 @@
+package tc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+
+	"code.cloudfoundry.org/commandrunner"
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+)
+
+// Shaper limits the bandwidth of a container by configuring traffic control on
+// the host side of its veth pair. Traffic leaving the host interface is
+// destined for the container and is shaped with a token bucket filter, while
+// traffic arriving on it was sent by the container and is policed on ingress.
+type Shaper struct {
+	tcBinPath string
+	runner    commandrunner.CommandRunner
+}
+
+func NewShaper(tcBinPath string, runner commandrunner.CommandRunner) *Shaper {
+	return &Shaper{
+		tcBinPath: tcBinPath,
+		runner:    runner,
+	}
+}
+
+// ethernetHeaderLen is added to the MTU to give the size of the largest frame
+// the interface sends, which is what tc compares against the burst.
+const ethernetHeaderLen = 14
+
+// Shape replaces any limits previously applied to the interface, so it is
+// safe to call repeatedly for the same interface. The tc commands are killed
+// once ctx is done.
+//
+// A burst smaller than a full frame on an interface with the given MTU would
+// drop every full-sized packet, so such bursts are raised to fit one.
+func (s *Shaper) Shape(ctx context.Context, log lager.Logger, intf string, mtu int, limits garden.BandwidthLimits) error {
+	log = log.Session("shape", lager.Data{"interface": intf, "mtu": mtu, "limits": limits})
+	log.Debug("started")
+	defer log.Debug("finished")
+
+	burstInBytes := limits.BurstRateInBytesPerSecond
+	if minBurst := uint64(mtu + ethernetHeaderLen); burstInBytes < minBurst {
+		log.Info("raising-burst-to-fit-a-frame", lager.Data{"burst": minBurst})
+		burstInBytes = minBurst
+	}
+
+	rate := fmt.Sprintf("%dbps", limits.RateInBytesPerSecond)
+	burst := fmt.Sprintf("%d", burstInBytes)
+
+	if err := s.run(ctx, "shape-inbound", exec.CommandContext(ctx,
+		s.tcBinPath, "qdisc", "replace", "dev", intf, "root",
+		"tbf", "rate", rate, "burst", burst, "latency", "25ms",
+	)); err != nil {
+		return err
+	}
+
+	// the ingress qdisc may not exist yet, so failing to delete it is fine
+	s.run(ctx, "delete-ingress-qdisc", exec.CommandContext(ctx, s.tcBinPath, "qdisc", "del", "dev", intf, "ingress"))
+
+	if err := s.run(ctx, "add-ingress-qdisc", exec.CommandContext(ctx,
+		s.tcBinPath, "qdisc", "add", "dev", intf, "handle", "ffff:", "ingress",
+	)); err != nil {
+		return err
+	}
+
+	return s.run(ctx, "shape-outbound", exec.CommandContext(ctx,
+		s.tcBinPath, "filter", "add", "dev", intf, "parent", "ffff:",
+		"protocol", "all", "prio", "1", "u32", "match", "u32", "0", "0",
+		"police", "rate", rate, "burst", burst, "drop", "flowid", ":1",
+	))
+}
+
+func (s *Shaper) run(ctx context.Context, action string, cmd *exec.Cmd) error {
+	var buff bytes.Buffer
+	cmd.Stdout = &buff
+	cmd.Stderr = &buff
+
+	if err := s.runner.Run(cmd); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("tc: %s: %s", action, ctx.Err())
+		}
+
+		return fmt.Errorf("tc: %s: %s", action, buff.String())
+	}
+
+	return nil
+}
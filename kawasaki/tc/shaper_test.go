@@ -0,0 +1,154 @@
+package tc_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+
+	"code.cloudfoundry.org/commandrunner/fake_command_runner"
+	. "code.cloudfoundry.org/commandrunner/fake_command_runner/matchers"
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/kawasaki/tc"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shaper", func() {
+	var (
+		fakeRunner *fake_command_runner.FakeCommandRunner
+		shaper     *tc.Shaper
+		logger     *lagertest.TestLogger
+		limits     garden.BandwidthLimits
+	)
+
+	BeforeEach(func() {
+		fakeRunner = fake_command_runner.New()
+		logger = lagertest.NewTestLogger("test")
+		shaper = tc.NewShaper("/sbin/tc", fakeRunner)
+		limits = garden.BandwidthLimits{
+			RateInBytesPerSecond:      1000,
+			BurstRateInBytesPerSecond: 2000,
+		}
+	})
+
+	It("shapes traffic in both directions on the interface", func() {
+		Expect(shaper.Shape(context.Background(), logger, "some-intf", 1500, limits)).To(Succeed())
+
+		Expect(fakeRunner).To(HaveExecutedSerially(
+			fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{
+					"qdisc", "replace", "dev", "some-intf", "root",
+					"tbf", "rate", "1000bps", "burst", "2000", "latency", "25ms",
+				},
+			},
+			fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"qdisc", "del", "dev", "some-intf", "ingress"},
+			},
+			fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"qdisc", "add", "dev", "some-intf", "handle", "ffff:", "ingress"},
+			},
+			fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{
+					"filter", "add", "dev", "some-intf", "parent", "ffff:",
+					"protocol", "all", "prio", "1", "u32", "match", "u32", "0", "0",
+					"police", "rate", "1000bps", "burst", "2000", "drop", "flowid", ":1",
+				},
+			},
+		))
+	})
+
+	Context("when the burst is smaller than a full frame", func() {
+		BeforeEach(func() {
+			limits.BurstRateInBytesPerSecond = 200
+		})
+
+		It("raises the burst to fit a frame of the interface's MTU", func() {
+			Expect(shaper.Shape(context.Background(), logger, "some-intf", 1500, limits)).To(Succeed())
+
+			Expect(fakeRunner).To(HaveExecutedSerially(
+				fake_command_runner.CommandSpec{
+					Path: "/sbin/tc",
+					Args: []string{
+						"qdisc", "replace", "dev", "some-intf", "root",
+						"tbf", "rate", "1000bps", "burst", "1514", "latency", "25ms",
+					},
+				},
+				fake_command_runner.CommandSpec{
+					Path: "/sbin/tc",
+					Args: []string{"qdisc", "del", "dev", "some-intf", "ingress"},
+				},
+				fake_command_runner.CommandSpec{
+					Path: "/sbin/tc",
+					Args: []string{"qdisc", "add", "dev", "some-intf", "handle", "ffff:", "ingress"},
+				},
+				fake_command_runner.CommandSpec{
+					Path: "/sbin/tc",
+					Args: []string{
+						"filter", "add", "dev", "some-intf", "parent", "ffff:",
+						"protocol", "all", "prio", "1", "u32", "match", "u32", "0", "0",
+						"police", "rate", "1000bps", "burst", "1514", "drop", "flowid", ":1",
+					},
+				},
+			))
+		})
+	})
+
+	Context("when there is no ingress qdisc to delete", func() {
+		BeforeEach(func() {
+			fakeRunner.WhenRunning(fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"qdisc", "del", "dev", "some-intf", "ingress"},
+			}, func(cmd *exec.Cmd) error {
+				return errors.New("exit status 2")
+			})
+		})
+
+		It("still shapes traffic in both directions", func() {
+			Expect(shaper.Shape(context.Background(), logger, "some-intf", 1500, limits)).To(Succeed())
+			Expect(fakeRunner.ExecutedCommands()).To(HaveLen(4))
+		})
+	})
+
+	Context("when tc fails", func() {
+		BeforeEach(func() {
+			fakeRunner.WhenRunning(fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+			}, func(cmd *exec.Cmd) error {
+				cmd.Stderr.Write([]byte("Cannot find device"))
+				return errors.New("exit status 1")
+			})
+		})
+
+		It("returns an error including the output", func() {
+			Expect(shaper.Shape(context.Background(), logger, "some-intf", 1500, limits)).To(MatchError("tc: shape-inbound: Cannot find device"))
+		})
+
+		It("does not carry on configuring the interface", func() {
+			Expect(shaper.Shape(context.Background(), logger, "some-intf", 1500, limits)).NotTo(Succeed())
+			Expect(fakeRunner.ExecutedCommands()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the context is done", func() {
+		BeforeEach(func() {
+			fakeRunner.WhenRunning(fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+			}, func(cmd *exec.Cmd) error {
+				return errors.New("signal: killed")
+			})
+		})
+
+		It("returns the context's error", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			Expect(shaper.Shape(ctx, logger, "some-intf", 1500, limits)).To(MatchError("tc: shape-inbound: context canceled"))
+		})
+	})
+})
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+type apiRequestKey struct {
+	operation string
+	outcome   string
+}
+
+// APIMetrics counts the requests made of the Garden API, and how long they
+// take, by operation
+type APIMetrics struct {
+	mu sync.Mutex
+
+	requests  map[apiRequestKey]uint64
+	durations map[string]*Histogram
+}
+
+func NewAPIMetrics() *APIMetrics {
+	return &APIMetrics{
+		requests:  map[apiRequestKey]uint64{},
+		durations: map[string]*Histogram{},
+	}
+}
+
+// Observe counts a request which started at start and has just finished
+func (a *APIMetrics) Observe(operation string, start time.Time, err error) {
+	duration := time.Since(start)
+
+	outcome := gardener.OutcomeSuccess
+	if err != nil {
+		outcome = gardener.OutcomeFailure
+	}
+
+	a.mu.Lock()
+	a.requests[apiRequestKey{operation: operation, outcome: outcome}]++
+	histogram, ok := a.durations[operation]
+	if !ok {
+		histogram = NewHistogram(DefaultDurationBuckets)
+		a.durations[operation] = histogram
+	}
+	a.mu.Unlock()
+
+	histogram.ObserveDuration(duration)
+}
+
+func (a *APIMetrics) CollectPrometheus(w *PrometheusWriter) {
+	a.mu.Lock()
+	requests := map[apiRequestKey]uint64{}
+	keys := []apiRequestKey{}
+	for key, count := range a.requests {
+		requests[key] = count
+		keys = append(keys, key)
+	}
+	durations := map[string]*Histogram{}
+	operations := []string{}
+	for operation, histogram := range a.durations {
+		durations[operation] = histogram
+		operations = append(operations, operation)
+	}
+	a.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].operation != keys[j].operation {
+			return keys[i].operation < keys[j].operation
+		}
+		return keys[i].outcome < keys[j].outcome
+	})
+	sort.Strings(operations)
+
+	w.Family("garden_api_requests_total", "Requests made of the Garden API.", "counter")
+	for _, key := range keys {
+		w.Sample("garden_api_requests_total", []Label{
+			{Name: "operation", Value: key.operation},
+			{Name: "outcome", Value: key.outcome},
+		}, float64(requests[key]))
+	}
+
+	w.Family("garden_api_request_duration_seconds", "Time taken to handle requests made of the Garden API.", "histogram")
+	for _, operation := range operations {
+		w.Histogram("garden_api_request_duration_seconds", []Label{
+			{Name: "operation", Value: operation},
+		}, durations[operation].Snapshot())
+	}
+}
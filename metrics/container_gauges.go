@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+)
+
+// ContainerGauges reports the resources used by each container, as they are
+// at the time of the scrape. The samples are labelled by handle and by the
+// value of each of the label properties. A label property whose label name is
+// already taken, by handle or by an earlier property, is skipped, as a sample
+// may not have two labels with the same name.
+type ContainerGauges struct {
+	logger          lager.Logger
+	backend         garden.Backend
+	labelProperties []labelProperty
+}
+
+type labelProperty struct {
+	property  string
+	labelName string
+}
+
+func NewContainerGauges(logger lager.Logger, backend garden.Backend, labelProperties []string) *ContainerGauges {
+	logger = logger.Session("container-gauges")
+
+	taken := map[string]bool{"handle": true}
+	properties := []labelProperty{}
+	for _, property := range labelProperties {
+		name := PrometheusLabelName(property)
+		if taken[name] {
+			logger.Info("skipping-colliding-label-property", lager.Data{"property": property, "label": name})
+			continue
+		}
+
+		taken[name] = true
+		properties = append(properties, labelProperty{property: property, labelName: name})
+	}
+
+	return &ContainerGauges{
+		logger:          logger,
+		backend:         backend,
+		labelProperties: properties,
+	}
+}
+
+type containerGauge struct {
+	name       string
+	help       string
+	metricType string
+	value      func(garden.ContainerInfo, garden.Metrics) float64
+	fromInfo   bool
+}
+
+var containerGaugeDefinitions = []containerGauge{
+	{
+		name:       "garden_container_cpu_usage_seconds_total",
+		help:       "CPU time used by the container.",
+		metricType: "counter",
+		value: func(_ garden.ContainerInfo, m garden.Metrics) float64 {
+			return float64(m.CPUStat.Usage) / float64(time.Second)
+		},
+	},
+	{
+		name:       "garden_container_memory_usage_bytes",
+		help:       "Memory used by the container, as counted towards its limit.",
+		metricType: "gauge",
+		value: func(_ garden.ContainerInfo, m garden.Metrics) float64 {
+			return float64(m.MemoryStat.TotalUsageTowardLimit)
+		},
+	},
+	{
+		name:       "garden_container_disk_usage_bytes",
+		help:       "Disk used by the container.",
+		metricType: "gauge",
+		value: func(_ garden.ContainerInfo, m garden.Metrics) float64 {
+			return float64(m.DiskStat.TotalBytesUsed)
+		},
+	},
+	{
+		name:       "garden_container_processes",
+		help:       "Processes running in the container.",
+		metricType: "gauge",
+		value: func(_ garden.ContainerInfo, m garden.Metrics) float64 {
+			return float64(m.PidStat.Current)
+		},
+	},
+	{
+		name:       "garden_container_mapped_ports",
+		help:       "Host ports mapped to the container.",
+		metricType: "gauge",
+		fromInfo:   true,
+		value: func(info garden.ContainerInfo, _ garden.Metrics) float64 {
+			return float64(len(info.MappedPorts))
+		},
+	},
+}
+
+func (c *ContainerGauges) CollectPrometheus(w *PrometheusWriter) {
+	log := c.logger.Session("collect")
+
+	containers, err := c.backend.Containers(nil)
+	if err != nil {
+		log.Error("listing-containers-failed", err)
+		return
+	}
+
+	handles := make([]string, 0, len(containers))
+	for _, container := range containers {
+		handles = append(handles, container.Handle())
+	}
+	sort.Strings(handles)
+
+	infos, err := c.backend.BulkInfo(handles)
+	if err != nil {
+		log.Error("bulk-info-failed", err)
+		return
+	}
+
+	metrics, err := c.backend.BulkMetrics(handles)
+	if err != nil {
+		log.Error("bulk-metrics-failed", err)
+		return
+	}
+
+	for _, gauge := range containerGaugeDefinitions {
+		w.Family(gauge.name, gauge.help, gauge.metricType)
+		for _, handle := range handles {
+			info, infoOK := infos[handle]
+			metric, metricsOK := metrics[handle]
+			if !infoOK || info.Err != nil {
+				// without info there are no properties to label the sample with
+				continue
+			}
+			if !gauge.fromInfo && (!metricsOK || metric.Err != nil) {
+				continue
+			}
+
+			w.Sample(gauge.name, c.labels(handle, info.Info.Properties), gauge.value(info.Info, metric.Metrics))
+		}
+	}
+}
+
+func (c *ContainerGauges) labels(handle string, properties garden.Properties) []Label {
+	labels := []Label{{Name: "handle", Value: handle}}
+	for _, property := range c.labelProperties {
+		labels = append(labels, Label{Name: property.labelName, Value: properties[property.property]})
+	}
+	return labels
+}
@@ -0,0 +1,122 @@
+package metrics_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/guardian/metrics"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerGauges", func() {
+	var (
+		backend *gardenfakes.FakeBackend
+		gauges  *metrics.ContainerGauges
+	)
+
+	BeforeEach(func() {
+		backend = new(gardenfakes.FakeBackend)
+
+		first := new(gardenfakes.FakeContainer)
+		first.HandleReturns("first")
+		second := new(gardenfakes.FakeContainer)
+		second.HandleReturns("second")
+		backend.ContainersReturns([]garden.Container{second, first}, nil)
+
+		backend.BulkInfoReturns(map[string]garden.ContainerInfoEntry{
+			"first": {Info: garden.ContainerInfo{
+				Properties:  garden.Properties{"tenant.id": "acme", "other": "x"},
+				MappedPorts: []garden.PortMapping{{HostPort: 1}, {HostPort: 2}},
+			}},
+			"second": {Info: garden.ContainerInfo{}},
+		}, nil)
+
+		backend.BulkMetricsReturns(map[string]garden.ContainerMetricsEntry{
+			"first": {Metrics: garden.Metrics{
+				CPUStat:    garden.ContainerCPUStat{Usage: 1500000000},
+				MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 1024},
+				DiskStat:   garden.ContainerDiskStat{TotalBytesUsed: 2048},
+				PidStat:    garden.ContainerPidStat{Current: 3},
+			}},
+			"second": {Err: garden.NewError("gone")},
+		}, nil)
+
+		gauges = metrics.NewContainerGauges(lagertest.NewTestLogger("test"), backend, []string{"tenant.id"})
+	})
+
+	scrape := func() string {
+		recorder := httptest.NewRecorder()
+		metrics.NewPrometheusHandler(gauges).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		body, err := ioutil.ReadAll(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	It("asks for the info and metrics of every container", func() {
+		scrape()
+
+		Expect(backend.BulkInfoArgsForCall(0)).To(Equal([]string{"first", "second"}))
+		Expect(backend.BulkMetricsArgsForCall(0)).To(Equal([]string{"first", "second"}))
+	})
+
+	It("labels the gauges of each container by handle and the label properties", func() {
+		Expect(scrape()).To(Equal(`# HELP garden_container_cpu_usage_seconds_total CPU time used by the container.
+# TYPE garden_container_cpu_usage_seconds_total counter
+garden_container_cpu_usage_seconds_total{handle="first",tenant_id="acme"} 1.5
+# HELP garden_container_memory_usage_bytes Memory used by the container, as counted towards its limit.
+# TYPE garden_container_memory_usage_bytes gauge
+garden_container_memory_usage_bytes{handle="first",tenant_id="acme"} 1024
+# HELP garden_container_disk_usage_bytes Disk used by the container.
+# TYPE garden_container_disk_usage_bytes gauge
+garden_container_disk_usage_bytes{handle="first",tenant_id="acme"} 2048
+# HELP garden_container_processes Processes running in the container.
+# TYPE garden_container_processes gauge
+garden_container_processes{handle="first",tenant_id="acme"} 3
+# HELP garden_container_mapped_ports Host ports mapped to the container.
+# TYPE garden_container_mapped_ports gauge
+garden_container_mapped_ports{handle="first",tenant_id="acme"} 2
+garden_container_mapped_ports{handle="second",tenant_id=""} 0
+`))
+	})
+
+	Context("when label properties have the same label name", func() {
+		var logger *lagertest.TestLogger
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("test")
+			gauges = metrics.NewContainerGauges(logger, backend, []string{"tenant.id", "handle", "tenant-id", "other"})
+		})
+
+		It("labels samples by the first property with each name, and handle always", func() {
+			Expect(scrape()).To(ContainSubstring(`garden_container_processes{handle="first",tenant_id="acme",other="x"} 3`))
+		})
+
+		It("logs the properties which are skipped", func() {
+			Expect(logger.LogMessages()).To(ConsistOf(
+				"test.container-gauges.skipping-colliding-label-property",
+				"test.container-gauges.skipping-colliding-label-property",
+			))
+		})
+	})
+
+	Context("when the containers cannot be listed", func() {
+		It("writes nothing", func() {
+			backend.ContainersReturns(nil, errors.New("boom"))
+			Expect(scrape()).To(BeEmpty())
+		})
+	})
+
+	Context("when the metrics cannot be fetched", func() {
+		It("writes nothing", func() {
+			backend.BulkMetricsReturns(nil, errors.New("boom"))
+			Expect(scrape()).To(BeEmpty())
+		})
+	})
+})
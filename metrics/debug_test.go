@@ -27,7 +27,7 @@ var _ = Describe("Debug", func() {
 		}
 
 		sink := lager.NewReconfigurableSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG), lager.DEBUG)
-		serverProc, err = metrics.StartDebugServer("127.0.0.1:5123", sink, testMetrics)
+		serverProc, err = metrics.StartDebugServer("127.0.0.1:5123", sink, testMetrics, nil)
 		Expect(err).ToNot(HaveOccurred())
 	})
 
@@ -46,3 +46,45 @@ var _ = Describe("Debug", func() {
 		Expect(expvar.Get("metric2").String()).To(Equal("12"))
 	})
 })
+
+var _ = Describe("Debug handlers", func() {
+	var (
+		serverProc ifrit.Process
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		handlers := map[string]http.Handler{
+			"/potato": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			}),
+		}
+
+		sink := lager.NewReconfigurableSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG), lager.DEBUG)
+		serverProc, err = metrics.StartDebugServer("127.0.0.1:5124", sink, nil, handlers)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		serverProc.Signal(os.Kill)
+		// the next test listens on the same port
+		Eventually(serverProc.Wait()).Should(Receive())
+	})
+
+	It("should serve the configured handlers at their paths", func() {
+		resp, err := http.Get("http://127.0.0.1:5124/potato")
+		Expect(err).ToNot(HaveOccurred())
+
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusTeapot))
+	})
+
+	It("should still serve the debug vars", func() {
+		resp, err := http.Get("http://127.0.0.1:5124/debug/vars")
+		Expect(err).ToNot(HaveOccurred())
+
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+})
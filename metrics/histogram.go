@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultDurationBuckets are the upper bounds, in seconds, of the buckets
+// durations are counted into when no others are given
+var DefaultDurationBuckets = []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60}
+
+// Histogram counts observed values into buckets of increasing upper bounds.
+// The last bucket is unbounded.
+type Histogram struct {
+	mu sync.Mutex
+
+	bounds []float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// HistogramSnapshot is the state of a histogram at some point in time. Counts
+// are cumulative: each bucket counts every value up to and including its
+// upper bound. The unbounded bucket is left out, as its count is Count.
+type HistogramSnapshot struct {
+	Buckets []Bucket `json:"buckets"`
+	Sum     float64  `json:"sum"`
+	Count   uint64   `json:"count"`
+}
+
+type Bucket struct {
+	UpperBound float64 `json:"le"`
+	Count      uint64  `json:"count"`
+}
+
+func NewHistogram(bounds []float64) *Histogram {
+	bounds = append(append([]float64{}, bounds...), math.Inf(1))
+	return &Histogram{
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)),
+	}
+}
+
+func (h *Histogram) Observe(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, bound := range h.bounds {
+		if value <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+// ObserveDuration observes a duration in seconds
+func (h *Histogram) ObserveDuration(duration time.Duration) {
+	h.Observe(duration.Seconds())
+}
+
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	snapshot := HistogramSnapshot{Sum: h.sum, Count: h.count}
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		if math.IsInf(bound, 1) {
+			break
+		}
+		snapshot.Buckets = append(snapshot.Buckets, Bucket{UpperBound: bound, Count: cumulative})
+	}
+
+	return snapshot
+}
@@ -0,0 +1,37 @@
+package metrics_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/guardian/metrics"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Histogram", func() {
+	var histogram *metrics.Histogram
+
+	BeforeEach(func() {
+		histogram = metrics.NewHistogram([]float64{1, 5})
+	})
+
+	It("starts empty", func() {
+		Expect(histogram.Snapshot()).To(Equal(metrics.HistogramSnapshot{
+			Buckets: []metrics.Bucket{{UpperBound: 1}, {UpperBound: 5}},
+		}))
+	})
+
+	It("counts each value into every bucket it fits in", func() {
+		histogram.Observe(0.5)
+		histogram.Observe(1)
+		histogram.Observe(3)
+		histogram.ObserveDuration(10 * time.Second)
+
+		Expect(histogram.Snapshot()).To(Equal(metrics.HistogramSnapshot{
+			Buckets: []metrics.Bucket{{UpperBound: 1, Count: 2}, {UpperBound: 5, Count: 3}},
+			Sum:     14.5,
+			Count:   4,
+		}))
+	})
+})
@@ -0,0 +1,282 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/properties"
+)
+
+// InstrumentedBackend tells APIMetrics about each operation on a backend, and
+// on the containers it returns. Operations which return something to be
+// streamed or waited on, such as StreamOut and Run, are timed until they
+// return it.
+type InstrumentedBackend struct {
+	garden.Backend
+
+	api *APIMetrics
+}
+
+func NewInstrumentedBackend(backend garden.Backend, api *APIMetrics) *InstrumentedBackend {
+	return &InstrumentedBackend{Backend: backend, api: api}
+}
+
+func (b *InstrumentedBackend) Ping() error {
+	start := time.Now()
+	err := b.Backend.Ping()
+	b.api.Observe("ping", start, err)
+	return err
+}
+
+func (b *InstrumentedBackend) Capacity() (garden.Capacity, error) {
+	start := time.Now()
+	capacity, err := b.Backend.Capacity()
+	b.api.Observe("capacity", start, err)
+	return capacity, err
+}
+
+func (b *InstrumentedBackend) Create(spec garden.ContainerSpec) (garden.Container, error) {
+	start := time.Now()
+	container, err := b.Backend.Create(spec)
+	b.api.Observe("create", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return b.wrap(container), nil
+}
+
+func (b *InstrumentedBackend) Destroy(handle string) error {
+	start := time.Now()
+	err := b.Backend.Destroy(handle)
+	b.api.Observe("destroy", start, err)
+	return err
+}
+
+func (b *InstrumentedBackend) Containers(props garden.Properties) ([]garden.Container, error) {
+	start := time.Now()
+	containers, err := b.Backend.Containers(props)
+	b.api.Observe("containers", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped := make([]garden.Container, len(containers))
+	for i, container := range containers {
+		wrapped[i] = b.wrap(container)
+	}
+	return wrapped, nil
+}
+
+func (b *InstrumentedBackend) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	start := time.Now()
+	infos, err := b.Backend.BulkInfo(handles)
+	b.api.Observe("bulk_info", start, err)
+	return infos, err
+}
+
+func (b *InstrumentedBackend) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	start := time.Now()
+	metrics, err := b.Backend.BulkMetrics(handles)
+	b.api.Observe("bulk_metrics", start, err)
+	return metrics, err
+}
+
+func (b *InstrumentedBackend) Lookup(handle string) (garden.Container, error) {
+	start := time.Now()
+	container, err := b.Backend.Lookup(handle)
+	b.api.Observe("lookup", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return b.wrap(container), nil
+}
+
+func (b *InstrumentedBackend) wrap(container garden.Container) garden.Container {
+	return &instrumentedContainer{Container: container, api: b.api}
+}
+
+type instrumentedContainer struct {
+	garden.Container
+
+	api *APIMetrics
+}
+
+func (c *instrumentedContainer) Stop(kill bool) error {
+	start := time.Now()
+	err := c.Container.Stop(kill)
+	c.api.Observe("stop", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) Info() (garden.ContainerInfo, error) {
+	start := time.Now()
+	info, err := c.Container.Info()
+	c.api.Observe("info", start, err)
+	return info, err
+}
+
+func (c *instrumentedContainer) StreamIn(spec garden.StreamInSpec) error {
+	start := time.Now()
+	err := c.Container.StreamIn(spec)
+	c.api.Observe("stream_in", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	start := time.Now()
+	stream, err := c.Container.StreamOut(spec)
+	c.api.Observe("stream_out", start, err)
+	return stream, err
+}
+
+func (c *instrumentedContainer) LimitBandwidth(limits garden.BandwidthLimits) error {
+	start := time.Now()
+	err := c.Container.LimitBandwidth(limits)
+	c.api.Observe("limit_bandwidth", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) LimitCPU(limits garden.CPULimits) error {
+	start := time.Now()
+	err := c.Container.LimitCPU(limits)
+	c.api.Observe("limit_cpu", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) LimitDisk(limits garden.DiskLimits) error {
+	start := time.Now()
+	err := c.Container.LimitDisk(limits)
+	c.api.Observe("limit_disk", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) LimitMemory(limits garden.MemoryLimits) error {
+	start := time.Now()
+	err := c.Container.LimitMemory(limits)
+	c.api.Observe("limit_memory", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
+	start := time.Now()
+	limits, err := c.Container.CurrentBandwidthLimits()
+	c.api.Observe("current_bandwidth_limits", start, err)
+	return limits, err
+}
+
+func (c *instrumentedContainer) CurrentCPULimits() (garden.CPULimits, error) {
+	start := time.Now()
+	limits, err := c.Container.CurrentCPULimits()
+	c.api.Observe("current_cpu_limits", start, err)
+	return limits, err
+}
+
+func (c *instrumentedContainer) CurrentDiskLimits() (garden.DiskLimits, error) {
+	start := time.Now()
+	limits, err := c.Container.CurrentDiskLimits()
+	c.api.Observe("current_disk_limits", start, err)
+	return limits, err
+}
+
+func (c *instrumentedContainer) CurrentMemoryLimits() (garden.MemoryLimits, error) {
+	start := time.Now()
+	limits, err := c.Container.CurrentMemoryLimits()
+	c.api.Observe("current_memory_limits", start, err)
+	return limits, err
+}
+
+func (c *instrumentedContainer) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	start := time.Now()
+	mappedHostPort, mappedContainerPort, err := c.Container.NetIn(hostPort, containerPort)
+	c.api.Observe("net_in", start, err)
+	return mappedHostPort, mappedContainerPort, err
+}
+
+func (c *instrumentedContainer) NetOut(netOutRule garden.NetOutRule) error {
+	start := time.Now()
+	err := c.Container.NetOut(netOutRule)
+	c.api.Observe("net_out", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) BulkNetOut(netOutRules []garden.NetOutRule) error {
+	start := time.Now()
+	err := c.Container.BulkNetOut(netOutRules)
+	c.api.Observe("bulk_net_out", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) Run(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+	start := time.Now()
+	process, err := c.Container.Run(spec, io)
+	c.api.Observe("run", start, err)
+	return process, err
+}
+
+func (c *instrumentedContainer) Attach(processID string, io garden.ProcessIO) (garden.Process, error) {
+	start := time.Now()
+	process, err := c.Container.Attach(processID, io)
+	c.api.Observe("attach", start, err)
+	return process, err
+}
+
+func (c *instrumentedContainer) Metrics() (garden.Metrics, error) {
+	start := time.Now()
+	metrics, err := c.Container.Metrics()
+	c.api.Observe("metrics", start, err)
+	return metrics, err
+}
+
+func (c *instrumentedContainer) SetGraceTime(graceTime time.Duration) error {
+	start := time.Now()
+	err := c.Container.SetGraceTime(graceTime)
+	c.api.Observe("set_grace_time", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) Properties() (garden.Properties, error) {
+	start := time.Now()
+	properties, err := c.Container.Properties()
+	c.api.Observe("properties", start, err)
+	return properties, err
+}
+
+func (c *instrumentedContainer) Property(name string) (string, error) {
+	start := time.Now()
+	value, err := c.Container.Property(name)
+	c.api.Observe("property", start, err)
+	return value, err
+}
+
+func (c *instrumentedContainer) SetProperty(name string, value string) error {
+	start := time.Now()
+	err := c.Container.SetProperty(name, value)
+	c.api.Observe("set_property", start, err)
+	return err
+}
+
+func (c *instrumentedContainer) RemoveProperty(name string) error {
+	start := time.Now()
+	err := c.Container.RemoveProperty(name)
+	c.api.Observe("remove_property", start, err)
+	return err
+}
+
+// ApplyProperties passes the batch on to the container, so that wrapping it
+// does not hide that it takes batches of property changes
+func (c *instrumentedContainer) ApplyProperties(batch properties.Batch) error {
+	start := time.Now()
+
+	var err error
+	if batcher, ok := c.Container.(gardener.PropertyBatcher); ok {
+		err = batcher.ApplyProperties(batch)
+	} else {
+		err = errors.New("container does not support batches of property changes")
+	}
+
+	c.api.Observe("apply_properties", start, err)
+	return err
+}
@@ -0,0 +1,138 @@
+package metrics_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/metrics"
+	"code.cloudfoundry.org/guardian/properties"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type batchingContainer struct {
+	*gardenfakes.FakeContainer
+	batches []properties.Batch
+}
+
+func (c *batchingContainer) ApplyProperties(batch properties.Batch) error {
+	c.batches = append(c.batches, batch)
+	return nil
+}
+
+var _ = Describe("InstrumentedBackend", func() {
+	var (
+		backend    *gardenfakes.FakeBackend
+		container  *gardenfakes.FakeContainer
+		api        *metrics.APIMetrics
+		instrument *metrics.InstrumentedBackend
+	)
+
+	BeforeEach(func() {
+		backend = new(gardenfakes.FakeBackend)
+		container = new(gardenfakes.FakeContainer)
+		container.HandleReturns("some-handle")
+		backend.LookupReturns(container, nil)
+		backend.CreateReturns(container, nil)
+		backend.ContainersReturns([]garden.Container{container}, nil)
+
+		api = metrics.NewAPIMetrics()
+		instrument = metrics.NewInstrumentedBackend(backend, api)
+	})
+
+	scrape := func() string {
+		recorder := httptest.NewRecorder()
+		metrics.NewPrometheusHandler(api).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		body, err := ioutil.ReadAll(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	It("counts the operations on the backend by outcome", func() {
+		Expect(instrument.Ping()).To(Succeed())
+		Expect(instrument.Ping()).To(Succeed())
+		backend.DestroyReturns(errors.New("boom"))
+		Expect(instrument.Destroy("some-handle")).To(MatchError("boom"))
+
+		Expect(backend.PingCallCount()).To(Equal(2))
+		Expect(backend.DestroyArgsForCall(0)).To(Equal("some-handle"))
+
+		Expect(scrape()).To(ContainSubstring(`garden_api_requests_total{operation="destroy",outcome="failure"} 1`))
+		Expect(scrape()).To(ContainSubstring(`garden_api_requests_total{operation="ping",outcome="success"} 2`))
+		Expect(scrape()).To(ContainSubstring(`garden_api_request_duration_seconds_count{operation="ping"} 2`))
+	})
+
+	It("counts the operations on the containers it creates, looks up and lists", func() {
+		created, err := instrument.Create(garden.ContainerSpec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.Handle()).To(Equal("some-handle"))
+		_, err = created.Info()
+		Expect(err).NotTo(HaveOccurred())
+
+		looked, err := instrument.Lookup("some-handle")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(looked.SetProperty("a", "b")).To(Succeed())
+
+		listed, err := instrument.Containers(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listed).To(HaveLen(1))
+		container.RunReturns(nil, errors.New("no"))
+		_, err = listed[0].Run(garden.ProcessSpec{}, garden.ProcessIO{})
+		Expect(err).To(MatchError("no"))
+
+		Expect(container.InfoCallCount()).To(Equal(1))
+		name, value := container.SetPropertyArgsForCall(0)
+		Expect([]string{name, value}).To(Equal([]string{"a", "b"}))
+
+		Expect(scrape()).To(ContainSubstring(`garden_api_requests_total{operation="create",outcome="success"} 1`))
+		Expect(scrape()).To(ContainSubstring(`garden_api_requests_total{operation="info",outcome="success"} 1`))
+		Expect(scrape()).To(ContainSubstring(`garden_api_requests_total{operation="set_property",outcome="success"} 1`))
+		Expect(scrape()).To(ContainSubstring(`garden_api_requests_total{operation="run",outcome="failure"} 1`))
+	})
+
+	It("does not count asking for the handle", func() {
+		created, err := instrument.Create(garden.ContainerSpec{})
+		Expect(err).NotTo(HaveOccurred())
+		created.Handle()
+
+		Expect(scrape()).NotTo(ContainSubstring(`operation="handle"`))
+	})
+
+	Context("when the lookup fails", func() {
+		It("returns no container", func() {
+			backend.LookupReturns(nil, errors.New("not found"))
+
+			looked, err := instrument.Lookup("some-handle")
+			Expect(err).To(MatchError("not found"))
+			Expect(looked).To(BeNil())
+		})
+	})
+
+	Describe("ApplyProperties", func() {
+		batch := properties.Batch{Set: map[string]string{"a": "b"}}
+
+		It("passes batches on to containers which take them", func() {
+			batching := &batchingContainer{FakeContainer: container}
+			backend.LookupReturns(batching, nil)
+
+			looked, err := instrument.Lookup("some-handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(looked.(gardener.PropertyBatcher).ApplyProperties(batch)).To(Succeed())
+
+			Expect(batching.batches).To(Equal([]properties.Batch{batch}))
+			Expect(scrape()).To(ContainSubstring(`garden_api_requests_total{operation="apply_properties",outcome="success"} 1`))
+		})
+
+		It("fails for containers which do not", func() {
+			looked, err := instrument.Lookup("some-handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(looked.(gardener.PropertyBatcher).ApplyProperties(batch)).To(HaveOccurred())
+		})
+	})
+})
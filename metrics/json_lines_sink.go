@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pivotal-golang/clock"
+)
+
+// JSONLinesSink writes each metric to a writer as a line of JSON
+type JSONLinesSink struct {
+	clock clock.Clock
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+type jsonLine struct {
+	Timestamp string  `json:"timestamp"`
+	Name      string  `json:"name"`
+	Value     float64 `json:"value"`
+	Unit      string  `json:"unit"`
+
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+func NewJSONLinesSink(w io.Writer, clock clock.Clock) *JSONLinesSink {
+	return &JSONLinesSink{w: w, clock: clock}
+}
+
+// OpenJSONLinesFile appends metrics to the file at path, creating it if it
+// does not exist
+func OpenJSONLinesFile(path string, clock clock.Clock) (*JSONLinesSink, *os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return NewJSONLinesSink(file, clock), file, nil
+}
+
+func (s *JSONLinesSink) SendValue(name string, value float64, unit string, tags map[string]string) error {
+	line, err := json.Marshal(jsonLine{
+		Timestamp: s.clock.Now().UTC().Format(time.RFC3339Nano),
+		Name:      name,
+		Value:     value,
+		Unit:      unit,
+		Tags:      tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
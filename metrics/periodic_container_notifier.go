@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/pivotal-golang/clock"
+)
+
+// PeriodicContainerNotifier emits the CPU, memory and disk usage of every
+// container to a sink every interval, tagged by handle and by the value of
+// each of the tag properties.
+//
+// CPU usage is reported as a percentage of one CPU over the interval, so it is
+// first emitted for a container once it has been sampled twice.
+type PeriodicContainerNotifier struct {
+	Interval time.Duration
+	Logger   lager.Logger
+	Clock    clock.Clock
+
+	backend       garden.Backend
+	sink          Sink
+	tagProperties []string
+
+	previous map[string]cpuSample
+	stopped  chan struct{}
+}
+
+type cpuSample struct {
+	usage uint64
+	at    time.Time
+}
+
+func NewPeriodicContainerNotifier(
+	logger lager.Logger,
+	backend garden.Backend,
+	sink Sink,
+	tagProperties []string,
+	interval time.Duration,
+	clock clock.Clock,
+) *PeriodicContainerNotifier {
+	return &PeriodicContainerNotifier{
+		Interval: interval,
+		Logger:   logger,
+		Clock:    clock,
+
+		backend:       backend,
+		sink:          sink,
+		tagProperties: tagProperties,
+
+		previous: map[string]cpuSample{},
+		stopped:  make(chan struct{}),
+	}
+}
+
+func (notifier *PeriodicContainerNotifier) Start() {
+	logger := notifier.Logger.Session("container-metrics-notifier", lager.Data{"interval": notifier.Interval.String()})
+	logger.Info("starting")
+	ticker := notifier.Clock.NewTicker(notifier.Interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		logger.Info("started", lager.Data{"time": notifier.Clock.Now()})
+		defer logger.Info("finished")
+
+		for {
+			select {
+			case <-ticker.C():
+				notifier.emit(logger)
+			case <-notifier.stopped:
+				return
+			}
+		}
+	}()
+}
+
+func (notifier *PeriodicContainerNotifier) Stop() {
+	close(notifier.stopped)
+}
+
+func (notifier *PeriodicContainerNotifier) emit(logger lager.Logger) {
+	containers, err := notifier.backend.Containers(nil)
+	if err != nil {
+		logger.Error("listing-containers-failed", err)
+		return
+	}
+
+	handles := make([]string, 0, len(containers))
+	tags := map[string]map[string]string{}
+	for _, container := range containers {
+		handle := container.Handle()
+		handles = append(handles, handle)
+		tags[handle] = notifier.tags(logger, container)
+	}
+
+	entries, err := notifier.backend.BulkMetrics(handles)
+	if err != nil {
+		logger.Error("bulk-metrics-failed", err)
+		return
+	}
+
+	now := notifier.Clock.Now()
+	sampled := map[string]cpuSample{}
+	for _, handle := range handles {
+		entry, ok := entries[handle]
+		if !ok || entry.Err != nil {
+			logger.Info("skipping-container-without-metrics", lager.Data{"handle": handle, "error": entry.Err})
+			continue
+		}
+
+		sample := cpuSample{usage: entry.Metrics.CPUStat.Usage, at: now}
+		sampled[handle] = sample
+
+		// the counter goes backwards when a container is recreated with the same handle
+		if previous, ok := notifier.previous[handle]; ok && sample.usage >= previous.usage && sample.at.After(previous.at) {
+			percentage := float64(sample.usage-previous.usage) / float64(sample.at.Sub(previous.at)) * 100
+			notifier.send(logger, "ContainerCPUPercentage", percentage, "percentage", tags[handle])
+		}
+
+		notifier.send(logger, "ContainerMemoryBytes", float64(entry.Metrics.MemoryStat.TotalUsageTowardLimit), "bytes", tags[handle])
+		notifier.send(logger, "ContainerDiskBytes", float64(entry.Metrics.DiskStat.TotalBytesUsed), "bytes", tags[handle])
+	}
+
+	notifier.previous = sampled
+}
+
+func (notifier *PeriodicContainerNotifier) tags(logger lager.Logger, container garden.Container) map[string]string {
+	tags := map[string]string{"handle": container.Handle()}
+	if len(notifier.tagProperties) == 0 {
+		return tags
+	}
+
+	properties, err := container.Properties()
+	if err != nil {
+		logger.Error("getting-properties-failed", err, lager.Data{"handle": container.Handle()})
+		return tags
+	}
+
+	for _, name := range notifier.tagProperties {
+		if value, ok := properties[name]; ok {
+			tags[name] = value
+		}
+	}
+
+	return tags
+}
+
+func (notifier *PeriodicContainerNotifier) send(logger lager.Logger, name string, value float64, unit string, tags map[string]string) {
+	if err := notifier.sink.SendValue(name, value, unit, tags); err != nil {
+		logger.Error("send-failed", err, lager.Data{"metric": name, "handle": tags["handle"]})
+	}
+}
@@ -0,0 +1,164 @@
+package metrics_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/guardian/metrics"
+	"code.cloudfoundry.org/guardian/metrics/metricsfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/pivotal-golang/clock/fakeclock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PeriodicContainerNotifier", func() {
+	type sent struct {
+		Name  string
+		Value float64
+		Unit  string
+		Tags  map[string]string
+	}
+
+	var (
+		backend       *gardenfakes.FakeBackend
+		container     *gardenfakes.FakeContainer
+		sink          *metricsfakes.FakeSink
+		clock         *fakeclock.FakeClock
+		interval      time.Duration
+		tagProperties []string
+
+		notifier *metrics.PeriodicContainerNotifier
+	)
+
+	metricsWithCPUUsage := func(usage uint64) map[string]garden.ContainerMetricsEntry {
+		return map[string]garden.ContainerMetricsEntry{
+			"some-handle": {Metrics: garden.Metrics{
+				CPUStat:    garden.ContainerCPUStat{Usage: usage},
+				MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 1024},
+				DiskStat:   garden.ContainerDiskStat{TotalBytesUsed: 2048},
+			}},
+		}
+	}
+
+	allSent := func() []sent {
+		all := []sent{}
+		for i := 0; i < sink.SendValueCallCount(); i++ {
+			name, value, unit, tags := sink.SendValueArgsForCall(i)
+			all = append(all, sent{Name: name, Value: value, Unit: unit, Tags: tags})
+		}
+		return all
+	}
+
+	BeforeEach(func() {
+		backend = new(gardenfakes.FakeBackend)
+		container = new(gardenfakes.FakeContainer)
+		container.HandleReturns("some-handle")
+		container.PropertiesReturns(garden.Properties{"app": "some-app", "other": "x"}, nil)
+		backend.ContainersReturns([]garden.Container{container}, nil)
+		backend.BulkMetricsReturnsOnCall(0, metricsWithCPUUsage(1000000000), nil)
+		backend.BulkMetricsReturnsOnCall(1, metricsWithCPUUsage(3500000000), nil)
+
+		sink = new(metricsfakes.FakeSink)
+		clock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		interval = 10 * time.Second
+		tagProperties = nil
+	})
+
+	JustBeforeEach(func() {
+		notifier = metrics.NewPeriodicContainerNotifier(
+			lagertest.NewTestLogger("test"), backend, sink, tagProperties, interval, clock,
+		)
+		notifier.Start()
+	})
+
+	AfterEach(func() {
+		notifier.Stop()
+	})
+
+	It("emits the memory and disk usage of each container, tagged by handle", func() {
+		clock.Increment(interval)
+
+		Eventually(sink.SendValueCallCount).Should(Equal(2))
+		Expect(backend.BulkMetricsArgsForCall(0)).To(Equal([]string{"some-handle"}))
+		Expect(allSent()).To(Equal([]sent{
+			{Name: "ContainerMemoryBytes", Value: 1024, Unit: "bytes", Tags: map[string]string{"handle": "some-handle"}},
+			{Name: "ContainerDiskBytes", Value: 2048, Unit: "bytes", Tags: map[string]string{"handle": "some-handle"}},
+		}))
+	})
+
+	It("derives the CPU percentage from the usage since the last sample", func() {
+		clock.Increment(interval)
+		Eventually(sink.SendValueCallCount).Should(Equal(2))
+
+		clock.Increment(interval)
+		Eventually(sink.SendValueCallCount).Should(Equal(5))
+
+		Expect(allSent()[2]).To(Equal(sent{
+			Name: "ContainerCPUPercentage", Value: 25, Unit: "percentage", Tags: map[string]string{"handle": "some-handle"},
+		}))
+	})
+
+	Context("when the CPU usage goes backwards", func() {
+		BeforeEach(func() {
+			backend.BulkMetricsReturnsOnCall(1, metricsWithCPUUsage(10), nil)
+		})
+
+		It("does not emit a percentage until it has sampled the new counter twice", func() {
+			clock.Increment(interval)
+			Eventually(sink.SendValueCallCount).Should(Equal(2))
+
+			clock.Increment(interval)
+			Eventually(sink.SendValueCallCount).Should(Equal(4))
+			Consistently(sink.SendValueCallCount).Should(Equal(4))
+		})
+	})
+
+	Context("with tag properties", func() {
+		BeforeEach(func() {
+			tagProperties = []string{"app", "missing"}
+		})
+
+		It("tags the metrics with the value of each property the container has", func() {
+			clock.Increment(interval)
+
+			Eventually(sink.SendValueCallCount).Should(Equal(2))
+			Expect(allSent()[0].Tags).To(Equal(map[string]string{"handle": "some-handle", "app": "some-app"}))
+		})
+	})
+
+	Context("when the metrics of a container cannot be found", func() {
+		BeforeEach(func() {
+			backend.BulkMetricsReturnsOnCall(0, map[string]garden.ContainerMetricsEntry{
+				"some-handle": {Err: garden.NewError("gone")},
+			}, nil)
+		})
+
+		It("skips it", func() {
+			clock.Increment(interval)
+
+			Eventually(backend.BulkMetricsCallCount).Should(Equal(1))
+			Consistently(sink.SendValueCallCount).Should(Equal(0))
+		})
+	})
+
+	Context("when listing the containers fails", func() {
+		BeforeEach(func() {
+			backend.ContainersReturns(nil, errors.New("boom"))
+		})
+
+		It("emits nothing, and tries again next time", func() {
+			clock.Increment(interval)
+			Eventually(backend.ContainersCallCount).Should(Equal(1))
+
+			clock.Increment(interval)
+			Eventually(backend.ContainersCallCount).Should(Equal(2))
+
+			Expect(backend.BulkMetricsCallCount()).To(Equal(0))
+			Expect(sink.SendValueCallCount()).To(Equal(0))
+		})
+	})
+})
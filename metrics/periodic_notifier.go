@@ -4,44 +4,40 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
 	"github.com/pivotal-golang/clock"
 )
 
-func sendMetric(key string, value int) {
-	dropsonde_metrics.SendValue(key, float64(value), "Metric")
-}
-
-func sendDuration(duration time.Duration) {
-	dropsonde_metrics.SendValue("MetricsReporting", float64(duration), "nanos")
-}
-
-type PeriodicMetronNotifier struct {
+// PeriodicNotifier emits the metrics to a sink every interval, followed by how
+// long emitting them took
+type PeriodicNotifier struct {
 	Interval time.Duration
 	Logger   lager.Logger
 	Clock    clock.Clock
 
 	metrics Metrics
+	sink    Sink
 	stopped chan struct{}
 }
 
-func NewPeriodicMetronNotifier(
+func NewPeriodicNotifier(
 	logger lager.Logger,
 	metrics Metrics,
+	sink Sink,
 	interval time.Duration,
 	clock clock.Clock,
-) *PeriodicMetronNotifier {
-	return &PeriodicMetronNotifier{
+) *PeriodicNotifier {
+	return &PeriodicNotifier{
 		Interval: interval,
 		Logger:   logger,
 		Clock:    clock,
 		metrics:  metrics,
+		sink:     sink,
 
 		stopped: make(chan struct{}),
 	}
 }
 
-func (notifier PeriodicMetronNotifier) Start() {
+func (notifier PeriodicNotifier) Start() {
 	logger := notifier.Logger.Session("metrics-notifier", lager.Data{"interval": notifier.Interval.String()})
 	logger.Info("starting")
 	ticker := notifier.Clock.NewTicker(notifier.Interval)
@@ -58,11 +54,11 @@ func (notifier PeriodicMetronNotifier) Start() {
 				startedAt := notifier.Clock.Now()
 
 				for key, metric := range notifier.metrics {
-					sendMetric(key, metric())
+					notifier.send(logger, key, float64(metric()), "Metric")
 				}
 
 				finishedAt := notifier.Clock.Now()
-				sendDuration(finishedAt.Sub(startedAt))
+				notifier.send(logger, "MetricsReporting", float64(finishedAt.Sub(startedAt)), "nanos")
 			case <-notifier.stopped:
 				return
 			}
@@ -70,6 +66,12 @@ func (notifier PeriodicMetronNotifier) Start() {
 	}()
 }
 
-func (notifier PeriodicMetronNotifier) Stop() {
+func (notifier PeriodicNotifier) Stop() {
 	close(notifier.stopped)
 }
+
+func (notifier PeriodicNotifier) send(logger lager.Logger, name string, value float64, unit string) {
+	if err := notifier.sink.SendValue(name, value, unit, nil); err != nil {
+		logger.Error("send-failed", err, lager.Data{"metric": name})
+	}
+}
@@ -0,0 +1,125 @@
+package metrics_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/guardian/metrics"
+	"code.cloudfoundry.org/guardian/metrics/metricsfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+	"github.com/pivotal-golang/clock/fakeclock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("PeriodicNotifier", func() {
+	var (
+		sender *fake.FakeMetricSender
+
+		testMetrics    metrics.Metrics
+		sink           metrics.Sink
+		reportInterval time.Duration
+		clock          *fakeclock.FakeClock
+		logger         *lagertest.TestLogger
+
+		pmn *metrics.PeriodicNotifier
+	)
+
+	BeforeEach(func() {
+		reportInterval = 100 * time.Millisecond
+
+		testMetrics = map[string]func() int{
+			"fooMetric": func() int { return 1 },
+			"barMetric": func() int { return 2 },
+		}
+
+		clock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		logger = lagertest.NewTestLogger("test")
+
+		sender = fake.NewFakeMetricSender()
+		dropsonde_metrics.Initialize(sender, nil)
+		sink = metrics.DropsondeSink{}
+	})
+
+	JustBeforeEach(func() {
+		pmn = metrics.NewPeriodicNotifier(
+			logger,
+			testMetrics,
+			sink,
+			reportInterval,
+			clock,
+		)
+		pmn.Start()
+	})
+
+	AfterEach(func() {
+		pmn.Stop()
+	})
+
+	Context("when the report interval elapses", func() {
+		It("emits metrics", func() {
+			clock.Increment(reportInterval)
+
+			Eventually(func() fake.Metric {
+				return sender.GetValue("fooMetric")
+			}).Should(Equal(fake.Metric{
+				Value: 1,
+				Unit:  "Metric",
+			}))
+
+			Eventually(func() fake.Metric {
+				return sender.GetValue("barMetric")
+			}).Should(Equal(fake.Metric{
+				Value: 2,
+				Unit:  "Metric",
+			}))
+		})
+	})
+
+	Context("with several sinks", func() {
+		var first, second *metricsfakes.FakeSink
+
+		BeforeEach(func() {
+			first = new(metricsfakes.FakeSink)
+			second = new(metricsfakes.FakeSink)
+			sink = metrics.Sinks{first, second}
+		})
+
+		It("emits the metrics and how long they took to every sink", func() {
+			clock.Increment(reportInterval)
+
+			for _, s := range []*metricsfakes.FakeSink{first, second} {
+				Eventually(s.SendValueCallCount).Should(Equal(3))
+
+				sent := map[string]string{}
+				for i := 0; i < 3; i++ {
+					name, _, unit, tags := s.SendValueArgsForCall(i)
+					Expect(tags).To(BeNil())
+					sent[name] = unit
+				}
+				Expect(sent).To(Equal(map[string]string{
+					"fooMetric":        "Metric",
+					"barMetric":        "Metric",
+					"MetricsReporting": "nanos",
+				}))
+			}
+		})
+
+		Context("when a sink fails", func() {
+			BeforeEach(func() {
+				first.SendValueReturns(errors.New("boom"))
+			})
+
+			It("still emits to the others, and logs the failure", func() {
+				clock.Increment(reportInterval)
+
+				Eventually(second.SendValueCallCount).Should(Equal(3))
+				Eventually(logger).Should(gbytes.Say("send-failed"))
+			})
+		})
+	})
+})
@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PrometheusCollector writes metrics to be scraped by Prometheus
+type PrometheusCollector interface {
+	CollectPrometheus(w *PrometheusWriter)
+}
+
+// PrometheusHandler serves the metrics of each of its collectors in the
+// Prometheus text format
+type PrometheusHandler struct {
+	collectors []PrometheusCollector
+}
+
+func NewPrometheusHandler(collectors ...PrometheusCollector) *PrometheusHandler {
+	return &PrometheusHandler{collectors: collectors}
+}
+
+func (h *PrometheusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writer := &PrometheusWriter{}
+	for _, collector := range h.collectors {
+		collector.CollectPrometheus(writer)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(writer.buf.Bytes())
+}
+
+// Label is the name and value of a label of a sample
+type Label struct {
+	Name  string
+	Value string
+}
+
+// PrometheusWriter writes metric families in the Prometheus text format. Each
+// family is introduced by Family, followed by its samples.
+type PrometheusWriter struct {
+	buf bytes.Buffer
+}
+
+func (p *PrometheusWriter) Family(name, help, metricType string) {
+	fmt.Fprintf(&p.buf, "# HELP %s %s\n", name, escapeHelp(help))
+	fmt.Fprintf(&p.buf, "# TYPE %s %s\n", name, metricType)
+}
+
+func (p *PrometheusWriter) Sample(name string, labels []Label, value float64) {
+	p.buf.WriteString(name)
+	if len(labels) > 0 {
+		p.buf.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				p.buf.WriteByte(',')
+			}
+			fmt.Fprintf(&p.buf, "%s=\"%s\"", label.Name, escapeLabelValue(label.Value))
+		}
+		p.buf.WriteByte('}')
+	}
+	fmt.Fprintf(&p.buf, " %s\n", formatFloat(value))
+}
+
+// Histogram writes the samples of a histogram family
+func (p *PrometheusWriter) Histogram(name string, labels []Label, snapshot HistogramSnapshot) {
+	for _, bucket := range snapshot.Buckets {
+		p.Sample(name+"_bucket", withLabel(labels, "le", formatFloat(bucket.UpperBound)), float64(bucket.Count))
+	}
+	p.Sample(name+"_bucket", withLabel(labels, "le", "+Inf"), float64(snapshot.Count))
+	p.Sample(name+"_sum", labels, snapshot.Sum)
+	p.Sample(name+"_count", labels, float64(snapshot.Count))
+}
+
+// CollectPrometheus writes each metric as a gauge, named after its key in
+// snake case: "depotDirs" becomes "garden_depot_dirs"
+func (m Metrics) CollectPrometheus(w *PrometheusWriter) {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := "garden_" + snakeCase(key)
+		w.Family(name, key, "gauge")
+		w.Sample(name, nil, float64(m[key]()))
+	}
+}
+
+var invalidLabelNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// PrometheusLabelName turns any string, such as the name of a property, into
+// a valid label name
+func PrometheusLabelName(name string) string {
+	name = invalidLabelNameChars.ReplaceAllString(name, "_")
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+func snakeCase(name string) string {
+	var snake []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && !unicode.IsUpper(runes[i-1]) {
+			snake = append(snake, '_')
+		}
+		snake = append(snake, unicode.ToLower(r))
+	}
+	return string(snake)
+}
+
+func withLabel(labels []Label, name, value string) []Label {
+	return append(append([]Label{}, labels...), Label{Name: name, Value: value})
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
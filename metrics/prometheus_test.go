@@ -0,0 +1,103 @@
+package metrics_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/metrics"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type collectorFunc func(w *metrics.PrometheusWriter)
+
+func (f collectorFunc) CollectPrometheus(w *metrics.PrometheusWriter) { f(w) }
+
+var _ = Describe("Prometheus", func() {
+	scrape := func(collectors ...metrics.PrometheusCollector) string {
+		recorder := httptest.NewRecorder()
+		metrics.NewPrometheusHandler(collectors...).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("text/plain; version=0.0.4; charset=utf-8"))
+
+		body, err := ioutil.ReadAll(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	It("writes the families of every collector", func() {
+		Expect(scrape(
+			collectorFunc(func(w *metrics.PrometheusWriter) {
+				w.Family("first", "The first.", "gauge")
+				w.Sample("first", nil, 1)
+			}),
+			collectorFunc(func(w *metrics.PrometheusWriter) {
+				w.Family("second", "The second.", "counter")
+				w.Sample("second", []metrics.Label{{Name: "a", Value: "b"}, {Name: "c", Value: "d"}}, 0.5)
+			}),
+		)).To(Equal(`# HELP first The first.
+# TYPE first gauge
+first 1
+# HELP second The second.
+# TYPE second counter
+second{a="b",c="d"} 0.5
+`))
+	})
+
+	It("escapes help and label values", func() {
+		Expect(scrape(collectorFunc(func(w *metrics.PrometheusWriter) {
+			w.Family("escaped", "back\\slash\nnewline", "gauge")
+			w.Sample("escaped", []metrics.Label{{Name: "l", Value: "a \"quoted\"\\\n"}}, 1)
+		}))).To(Equal(`# HELP escaped back\\slash\nnewline
+# TYPE escaped gauge
+escaped{l="a \"quoted\"\\\n"} 1
+`))
+	})
+
+	It("writes histograms with cumulative buckets", func() {
+		histogram := metrics.NewHistogram([]float64{0.5, 1})
+		histogram.Observe(0.25)
+		histogram.Observe(2)
+
+		Expect(scrape(collectorFunc(func(w *metrics.PrometheusWriter) {
+			w.Histogram("took", []metrics.Label{{Name: "op", Value: "x"}}, histogram.Snapshot())
+		}))).To(Equal(`took_bucket{op="x",le="0.5"} 1
+took_bucket{op="x",le="1"} 1
+took_bucket{op="x",le="+Inf"} 2
+took_sum{op="x"} 2.25
+took_count{op="x"} 2
+`))
+	})
+
+	It("writes host metrics as gauges named in snake case", func() {
+		Expect(scrape(metrics.Metrics{
+			"numCPUS":   func() int { return 4 },
+			"depotDirs": func() int { return 2 },
+		})).To(Equal(`# HELP garden_depot_dirs depotDirs
+# TYPE garden_depot_dirs gauge
+garden_depot_dirs 2
+# HELP garden_num_cpus numCPUS
+# TYPE garden_num_cpus gauge
+garden_num_cpus 4
+`))
+	})
+
+	It("writes the stage histograms", func() {
+		histograms := metrics.NewStageHistograms([]float64{1})
+		histograms.RecordSpan(gardener.Span{Operation: "create", Stage: "volume", Outcome: "success", Duration: 2 * time.Second})
+
+		Expect(scrape(histograms)).To(ContainSubstring(`garden_container_stage_duration_seconds_bucket{operation="create",stage="volume",outcome="success",le="+Inf"} 1`))
+		Expect(scrape(histograms)).To(ContainSubstring(`garden_container_stage_duration_seconds_sum{operation="create",stage="volume",outcome="success"} 2`))
+	})
+
+	Describe("PrometheusLabelName", func() {
+		It("replaces characters which may not be in a label name", func() {
+			Expect(metrics.PrometheusLabelName("tenant.id")).To(Equal("tenant_id"))
+			Expect(metrics.PrometheusLabelName("app-guid")).To(Equal("app_guid"))
+			Expect(metrics.PrometheusLabelName("9lives")).To(Equal("_9lives"))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+)
+
+//go:generate counterfeiter . Sink
+
+// Sink is somewhere metrics are emitted to. Tags, such as the handle of the
+// container a metric is about, may be nil.
+type Sink interface {
+	SendValue(name string, value float64, unit string, tags map[string]string) error
+}
+
+// Sinks emit each metric to every one of a number of sinks
+type Sinks []Sink
+
+// SendValue sends the value to every sink, even when some of them fail, and
+// returns the first failure
+func (sinks Sinks) SendValue(name string, value float64, unit string, tags map[string]string) error {
+	var firstErr error
+	for _, sink := range sinks {
+		if err := sink.SendValue(name, value, unit, tags); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// DropsondeSink emits metrics to metron, once dropsonde has been initialized.
+// Dropsonde values have no tags, and a metric per container would give metron
+// an unbounded number of names, so tagged metrics are skipped.
+type DropsondeSink struct{}
+
+func (DropsondeSink) SendValue(name string, value float64, unit string, tags map[string]string) error {
+	if len(tags) > 0 {
+		return nil
+	}
+
+	return dropsonde_metrics.SendValue(name, value, unit)
+}
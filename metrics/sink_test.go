@@ -0,0 +1,190 @@
+package metrics_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/guardian/metrics"
+	"code.cloudfoundry.org/guardian/metrics/metricsfakes"
+	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+	"github.com/pivotal-golang/clock/fakeclock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sinks", func() {
+	It("sends to every sink, returning the first failure", func() {
+		first := new(metricsfakes.FakeSink)
+		second := new(metricsfakes.FakeSink)
+		third := new(metricsfakes.FakeSink)
+		first.SendValueReturns(errors.New("first"))
+		second.SendValueReturns(errors.New("second"))
+
+		err := metrics.Sinks{first, second, third}.SendValue("m", 1, "Metric", map[string]string{"handle": "h"})
+		Expect(err).To(MatchError("first"))
+
+		name, value, unit, tags := third.SendValueArgsForCall(0)
+		Expect(name).To(Equal("m"))
+		Expect(value).To(Equal(1.0))
+		Expect(unit).To(Equal("Metric"))
+		Expect(tags).To(Equal(map[string]string{"handle": "h"}))
+	})
+})
+
+var _ = Describe("DropsondeSink", func() {
+	var sender *fake.FakeMetricSender
+
+	BeforeEach(func() {
+		sender = fake.NewFakeMetricSender()
+		dropsonde_metrics.Initialize(sender, nil)
+	})
+
+	It("sends the value to metron", func() {
+		Expect(metrics.DropsondeSink{}.SendValue("DepotDirs", 3, "Metric", nil)).To(Succeed())
+		Expect(sender.GetValue("DepotDirs")).To(Equal(fake.Metric{Value: 3, Unit: "Metric"}))
+	})
+
+	It("skips tagged metrics, such as those about each container", func() {
+		Expect(metrics.DropsondeSink{}.SendValue("ContainerMemory", 3, "bytes", map[string]string{
+			"handle": "some-handle",
+			"app":    "some-app",
+		})).To(Succeed())
+		Expect(sender.GetValue("ContainerMemory")).To(Equal(fake.Metric{}))
+		Expect(sender.GetValue("ContainerMemory.some-app.some-handle")).To(Equal(fake.Metric{}))
+	})
+})
+
+var _ = Describe("StatsdSink", func() {
+	var (
+		server *net.UDPConn
+		sink   *metrics.StatsdSink
+	)
+
+	BeforeEach(func() {
+		var err error
+		server, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+		Expect(err).NotTo(HaveOccurred())
+
+		sink, err = metrics.NewStatsdSink(server.LocalAddr().String(), "garden")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(sink.Close()).To(Succeed())
+		Expect(server.Close()).To(Succeed())
+	})
+
+	receive := func() string {
+		buf := make([]byte, 1024)
+		Expect(server.SetReadDeadline(time.Now().Add(time.Second))).To(Succeed())
+		n, err := server.Read(buf)
+		Expect(err).NotTo(HaveOccurred())
+		return string(buf[:n])
+	}
+
+	It("sends values as gauges", func() {
+		Expect(sink.SendValue("DepotDirs", 3, "Metric", nil)).To(Succeed())
+		Expect(receive()).To(Equal("garden.DepotDirs:3|g"))
+	})
+
+	It("sends durations as timers in milliseconds", func() {
+		Expect(sink.SendValue("MetricsReporting", float64(1500*time.Microsecond), "nanos", nil)).To(Succeed())
+		Expect(receive()).To(Equal("garden.MetricsReporting:1.5|ms"))
+	})
+
+	It("sends tags in the DogStatsD format", func() {
+		Expect(sink.SendValue("ContainerMemory", 3, "bytes", map[string]string{"handle": "h", "app": "a"})).To(Succeed())
+		Expect(receive()).To(Equal("garden.ContainerMemory:3|g|#app:a,handle:h"))
+	})
+
+	It("replaces the characters which would break up the packet in tag keys and values", func() {
+		Expect(sink.SendValue("ContainerMemory", 3, "bytes", map[string]string{"app:name": "a,b|c#d", "handle": "h\nx:1"})).To(Succeed())
+		Expect(receive()).To(Equal("garden.ContainerMemory:3|g|#app_name:a_b_c_d,handle:h_x_1"))
+	})
+
+	Context("without a prefix", func() {
+		It("sends the bare name", func() {
+			unprefixed, err := metrics.NewStatsdSink(server.LocalAddr().String(), "")
+			Expect(err).NotTo(HaveOccurred())
+			defer unprefixed.Close()
+
+			Expect(unprefixed.SendValue("DepotDirs", 3, "Metric", nil)).To(Succeed())
+			Expect(receive()).To(Equal("DepotDirs:3|g"))
+		})
+	})
+
+	Context("when the address is invalid", func() {
+		It("returns an error", func() {
+			_, err := metrics.NewStatsdSink("not an address", "")
+			Expect(err).To(MatchError(ContainSubstring("statsd sink")))
+		})
+	})
+})
+
+var _ = Describe("JSONLinesSink", func() {
+	var clock *fakeclock.FakeClock
+
+	BeforeEach(func() {
+		clock = fakeclock.NewFakeClock(time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC))
+	})
+
+	It("writes each value as a line of JSON", func() {
+		buffer := new(bytes.Buffer)
+		sink := metrics.NewJSONLinesSink(buffer, clock)
+
+		Expect(sink.SendValue("DepotDirs", 3, "Metric", nil)).To(Succeed())
+		clock.Increment(time.Second)
+		Expect(sink.SendValue("MetricsReporting", 12, "nanos", map[string]string{"handle": "h"})).To(Succeed())
+
+		lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+		Expect(lines).To(HaveLen(2))
+
+		var line map[string]interface{}
+		Expect(json.Unmarshal([]byte(lines[1]), &line)).To(Succeed())
+		Expect(line).To(Equal(map[string]interface{}{
+			"timestamp": "2017-01-02T03:04:06Z",
+			"name":      "MetricsReporting",
+			"value":     12.0,
+			"unit":      "nanos",
+			"tags":      map[string]interface{}{"handle": "h"},
+		}))
+	})
+
+	Describe("OpenJSONLinesFile", func() {
+		var tmpDir string
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "json-lines")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(tmpDir)).To(Succeed())
+		})
+
+		It("appends to the file", func() {
+			path := filepath.Join(tmpDir, "metrics.jsonl")
+			Expect(ioutil.WriteFile(path, []byte("{}\n"), 0644)).To(Succeed())
+
+			sink, file, err := metrics.OpenJSONLinesFile(path, clock)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sink.SendValue("DepotDirs", 3, "Metric", nil)).To(Succeed())
+			Expect(file.Close()).To(Succeed())
+
+			contents, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(HavePrefix("{}\n{"))
+			Expect(string(contents)).To(ContainSubstring(`"name":"DepotDirs"`))
+		})
+	})
+})
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+// StageKey identifies the histogram a span is counted into
+type StageKey struct {
+	Operation string `json:"operation"`
+	Stage     string `json:"stage"`
+	Outcome   string `json:"outcome"`
+}
+
+type StageHistogramSnapshot struct {
+	StageKey
+	HistogramSnapshot
+}
+
+// StageHistograms is a gardener.Tracer which keeps a histogram of the
+// durations of each stage of the creates and destroys, by how they turned out
+type StageHistograms struct {
+	mu sync.Mutex
+
+	bounds     []float64
+	histograms map[StageKey]*Histogram
+}
+
+// NewStageHistograms counts durations into buckets of the given upper bounds,
+// in seconds, or DefaultDurationBuckets when there are none
+func NewStageHistograms(bounds []float64) *StageHistograms {
+	if len(bounds) == 0 {
+		bounds = DefaultDurationBuckets
+	}
+
+	return &StageHistograms{
+		bounds:     bounds,
+		histograms: map[StageKey]*Histogram{},
+	}
+}
+
+func (s *StageHistograms) RecordSpan(span gardener.Span) {
+	key := StageKey{Operation: span.Operation, Stage: span.Stage, Outcome: span.Outcome}
+
+	s.mu.Lock()
+	histogram, ok := s.histograms[key]
+	if !ok {
+		histogram = NewHistogram(s.bounds)
+		s.histograms[key] = histogram
+	}
+	s.mu.Unlock()
+
+	histogram.ObserveDuration(span.Duration)
+}
+
+// Snapshot returns the state of every histogram, ordered by operation, stage
+// and outcome
+func (s *StageHistograms) Snapshot() []StageHistogramSnapshot {
+	s.mu.Lock()
+	histograms := make(map[StageKey]*Histogram, len(s.histograms))
+	for key, histogram := range s.histograms {
+		histograms[key] = histogram
+	}
+	s.mu.Unlock()
+
+	snapshots := []StageHistogramSnapshot{}
+	for key, histogram := range histograms {
+		snapshots = append(snapshots, StageHistogramSnapshot{StageKey: key, HistogramSnapshot: histogram.Snapshot()})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		a, b := snapshots[i].StageKey, snapshots[j].StageKey
+		if a.Operation != b.Operation {
+			return a.Operation < b.Operation
+		}
+		if a.Stage != b.Stage {
+			return a.Stage < b.Stage
+		}
+		return a.Outcome < b.Outcome
+	})
+
+	return snapshots
+}
+
+// ServeHTTP writes the histograms as JSON
+func (s *StageHistograms) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.Snapshot())
+}
+
+func (s *StageHistograms) CollectPrometheus(w *PrometheusWriter) {
+	const name = "garden_container_stage_duration_seconds"
+
+	w.Family(name, "Time taken by each stage of creating and destroying containers.", "histogram")
+	for _, snapshot := range s.Snapshot() {
+		w.Histogram(name, []Label{
+			{Name: "operation", Value: snapshot.Operation},
+			{Name: "stage", Value: snapshot.Stage},
+			{Name: "outcome", Value: snapshot.Outcome},
+		}, snapshot.HistogramSnapshot)
+	}
+}
@@ -0,0 +1,68 @@
+package metrics_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/metrics"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StageHistograms", func() {
+	var histograms *metrics.StageHistograms
+
+	BeforeEach(func() {
+		histograms = metrics.NewStageHistograms([]float64{1})
+	})
+
+	It("keeps a histogram for each stage and outcome", func() {
+		histograms.RecordSpan(gardener.Span{Operation: "create", Stage: "volume", Outcome: "success", Duration: time.Second})
+		histograms.RecordSpan(gardener.Span{Operation: "create", Stage: "volume", Outcome: "success", Duration: 2 * time.Second})
+		histograms.RecordSpan(gardener.Span{Operation: "create", Stage: "volume", Outcome: "timeout", Duration: 3 * time.Second})
+		histograms.RecordSpan(gardener.Span{Operation: "create", Stage: "network", Outcome: "success", Duration: time.Second / 2})
+
+		Expect(histograms.Snapshot()).To(Equal([]metrics.StageHistogramSnapshot{
+			{
+				StageKey:          metrics.StageKey{Operation: "create", Stage: "network", Outcome: "success"},
+				HistogramSnapshot: metrics.HistogramSnapshot{Buckets: []metrics.Bucket{{UpperBound: 1, Count: 1}}, Sum: 0.5, Count: 1},
+			},
+			{
+				StageKey:          metrics.StageKey{Operation: "create", Stage: "volume", Outcome: "success"},
+				HistogramSnapshot: metrics.HistogramSnapshot{Buckets: []metrics.Bucket{{UpperBound: 1, Count: 1}}, Sum: 3, Count: 2},
+			},
+			{
+				StageKey:          metrics.StageKey{Operation: "create", Stage: "volume", Outcome: "timeout"},
+				HistogramSnapshot: metrics.HistogramSnapshot{Buckets: []metrics.Bucket{{UpperBound: 1, Count: 0}}, Sum: 3, Count: 1},
+			},
+		}))
+	})
+
+	It("uses the default buckets when none are given", func() {
+		histograms = metrics.NewStageHistograms(nil)
+		histograms.RecordSpan(gardener.Span{Operation: "destroy", Stage: "container"})
+
+		Expect(histograms.Snapshot()[0].Buckets).To(HaveLen(len(metrics.DefaultDurationBuckets)))
+	})
+
+	It("serves the histograms as JSON", func() {
+		histograms.RecordSpan(gardener.Span{Operation: "create", Stage: "volume", Outcome: "success", Duration: time.Second})
+
+		recorder := httptest.NewRecorder()
+		histograms.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/stages", nil))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		var served []map[string]interface{}
+		Expect(json.NewDecoder(recorder.Body).Decode(&served)).To(Succeed())
+		Expect(served).To(HaveLen(1))
+		Expect(served[0]).To(HaveKeyWithValue("operation", "create"))
+		Expect(served[0]).To(HaveKeyWithValue("stage", "volume"))
+		Expect(served[0]).To(HaveKeyWithValue("outcome", "success"))
+		Expect(served[0]).To(HaveKeyWithValue("count", BeNumerically("==", 1)))
+		Expect(served[0]).To(HaveKeyWithValue("sum", BeNumerically("==", 1)))
+	})
+})
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatsdSink emits metrics to a statsd server over UDP. Values are sent as
+// gauges, apart from durations in nanoseconds, which are sent as timers. Tags
+// are sent in the DogStatsD format, with the characters it separates them by
+// replaced by underscores.
+type StatsdSink struct {
+	conn   net.Conn
+	prefix string
+}
+
+// NewStatsdSink sends metrics to the statsd server at address, with their
+// names prefixed by prefix and a dot, unless prefix is empty
+func NewStatsdSink(address, prefix string) (*StatsdSink, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, fmt.Errorf("statsd sink: %s", err)
+	}
+
+	if prefix != "" {
+		prefix += "."
+	}
+
+	return &StatsdSink{conn: conn, prefix: prefix}, nil
+}
+
+func (s *StatsdSink) SendValue(name string, value float64, unit string, tags map[string]string) error {
+	metricType := "g"
+	if unit == "nanos" {
+		metricType = "ms"
+		value = value / float64(time.Millisecond)
+	}
+
+	packet := fmt.Sprintf("%s%s:%s|%s", s.prefix, name, strconv.FormatFloat(value, 'f', -1, 64), metricType)
+	if len(tags) > 0 {
+		pairs := []string{}
+		for _, key := range sortedKeys(tags) {
+			pairs = append(pairs, statsdTag(key)+":"+statsdTag(tags[key]))
+		}
+		packet += "|#" + strings.Join(pairs, ",")
+	}
+
+	_, err := io.WriteString(s.conn, packet)
+	return err
+}
+
+var invalidStatsdTagChars = regexp.MustCompile(`[:,|#\n]`)
+
+// statsdTag replaces the characters which separate the parts of a packet, so
+// that a tag key or value, such as the value of a property, cannot break it up
+func statsdTag(tag string) string {
+	return invalidStatsdTagChars.ReplaceAllString(tag, "_")
+}
+
+func (s *StatsdSink) Close() error {
+	return s.conn.Close()
+}
+
+func sortedKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/lager"
+)
+
+// TraceEvent is a complete event in the Trace Event Format read by
+// chrome://tracing, Perfetto and similar tools
+type TraceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur"`
+	PID       int               `json:"pid"`
+	TID       uint32            `json:"tid"`
+	Args      map[string]string `json:"args"`
+}
+
+// TraceWriter is a gardener.Tracer which writes each span as a trace event.
+// The events are written as a JSON array which is never closed, as the
+// format allows, so that the trace can be read at any time.
+type TraceWriter struct {
+	logger lager.Logger
+
+	mu      sync.Mutex
+	w       io.Writer
+	started bool
+}
+
+func NewTraceWriter(logger lager.Logger, w io.Writer) *TraceWriter {
+	return &TraceWriter{
+		logger: logger.Session("trace-writer"),
+		w:      w,
+	}
+}
+
+// OpenTraceFile starts a new trace in the file at path, replacing any trace
+// already there
+func OpenTraceFile(logger lager.Logger, path string) (*TraceWriter, *os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return NewTraceWriter(logger, file), file, nil
+}
+
+func (t *TraceWriter) RecordSpan(span gardener.Span) {
+	event, err := json.Marshal(NewTraceEvent(span))
+	if err != nil {
+		t.logger.Error("marshal-failed", err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.started {
+		if _, err := io.WriteString(t.w, "[\n"); err != nil {
+			t.logger.Error("write-failed", err)
+			return
+		}
+		t.started = true
+	}
+
+	if _, err := t.w.Write(append(event, ",\n"...)); err != nil {
+		t.logger.Error("write-failed", err)
+	}
+}
+
+// NewTraceEvent describes a span as a trace event. The spans of each
+// container are put on a thread of their own, so that they line up.
+func NewTraceEvent(span gardener.Span) TraceEvent {
+	args := map[string]string{
+		"handle":  span.Handle,
+		"outcome": span.Outcome,
+	}
+	if span.Error != "" {
+		args["error"] = span.Error
+	}
+
+	return TraceEvent{
+		Name:      span.Stage,
+		Category:  span.Operation,
+		Phase:     "X",
+		Timestamp: span.Start.UnixNano() / int64(time.Microsecond),
+		Duration:  int64(span.Duration / time.Microsecond),
+		PID:       os.Getpid(),
+		TID:       threadID(span.Handle),
+		Args:      args,
+	}
+}
+
+func threadID(handle string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(handle))
+	return hash.Sum32()
+}
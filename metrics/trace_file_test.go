@@ -0,0 +1,113 @@
+package metrics_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/metrics"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TraceWriter", func() {
+	var (
+		buffer *bytes.Buffer
+		writer *metrics.TraceWriter
+		start  time.Time
+	)
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+		writer = metrics.NewTraceWriter(lagertest.NewTestLogger("test"), buffer)
+		start = time.Unix(1000, 5000)
+	})
+
+	// closing the array is left to whatever reads the trace
+	readEvents := func(trace string) []metrics.TraceEvent {
+		var events []metrics.TraceEvent
+		closed := strings.TrimSuffix(trace, ",\n") + "\n]"
+		Expect(json.Unmarshal([]byte(closed), &events)).To(Succeed())
+		return events
+	}
+
+	It("writes nothing until there is a span", func() {
+		Expect(buffer.String()).To(BeEmpty())
+	})
+
+	It("writes each span as a complete event of a JSON array", func() {
+		writer.RecordSpan(gardener.Span{
+			Operation: "create", Stage: "volume", Handle: "some-handle",
+			Start: start, Duration: 1500 * time.Microsecond, Outcome: "success",
+		})
+		writer.RecordSpan(gardener.Span{
+			Operation: "create", Stage: "network", Handle: "some-handle",
+			Start: start, Duration: time.Millisecond, Outcome: "failure", Error: "boom",
+		})
+
+		Expect(buffer.String()).To(HavePrefix("[\n"))
+
+		events := readEvents(buffer.String())
+		Expect(events).To(HaveLen(2))
+
+		Expect(events[0].Name).To(Equal("volume"))
+		Expect(events[0].Category).To(Equal("create"))
+		Expect(events[0].Phase).To(Equal("X"))
+		Expect(events[0].Timestamp).To(Equal(int64(1000000005)))
+		Expect(events[0].Duration).To(Equal(int64(1500)))
+		Expect(events[0].PID).To(Equal(os.Getpid()))
+		Expect(events[0].Args).To(Equal(map[string]string{"handle": "some-handle", "outcome": "success"}))
+
+		Expect(events[1].Args).To(HaveKeyWithValue("error", "boom"))
+	})
+
+	It("puts the spans of each container on a thread of their own", func() {
+		writer.RecordSpan(gardener.Span{Handle: "a-handle"})
+		writer.RecordSpan(gardener.Span{Handle: "a-handle"})
+		writer.RecordSpan(gardener.Span{Handle: "another-handle"})
+
+		events := readEvents(buffer.String())
+		Expect(events[0].TID).To(Equal(events[1].TID))
+		Expect(events[0].TID).NotTo(Equal(events[2].TID))
+	})
+
+	Describe("OpenTraceFile", func() {
+		var tmpDir string
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "trace")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(tmpDir)).To(Succeed())
+		})
+
+		It("starts a new trace in the file", func() {
+			path := filepath.Join(tmpDir, "trace.json")
+			Expect(ioutil.WriteFile(path, []byte("old trace"), 0644)).To(Succeed())
+
+			writer, file, err := metrics.OpenTraceFile(lagertest.NewTestLogger("test"), path)
+			Expect(err).NotTo(HaveOccurred())
+			writer.RecordSpan(gardener.Span{Stage: "volume"})
+			Expect(file.Close()).To(Succeed())
+
+			contents, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(readEvents(string(contents))[0].Name).To(Equal("volume"))
+		})
+
+		It("fails when the file cannot be opened", func() {
+			_, _, err := metrics.OpenTraceFile(lagertest.NewTestLogger("test"), filepath.Join(tmpDir, "missing", "trace.json"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
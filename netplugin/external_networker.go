@@ -2,7 +2,9 @@ package netplugin
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net"
@@ -83,7 +85,15 @@ type UpOutputs struct {
 	DNSServers []string `json:"dns_servers,omitempty"`
 }
 
-func (p *externalBinaryNetworker) Network(log lager.Logger, containerSpec garden.ContainerSpec, pid int) error {
+// Network runs the plugin's up action, which also applies the spec's NetIn and
+// NetOut rules, and kills it once ctx is done. The plugin has no way to apply
+// bandwidth limits, so any in the spec are logged and ignored, as they were
+// before limits were supported at all.
+func (p *externalBinaryNetworker) Network(ctx context.Context, log lager.Logger, containerSpec garden.ContainerSpec, pid int) error {
+	if containerSpec.Limits.Bandwidth != (garden.BandwidthLimits{}) {
+		log.Info("ignoring-bandwidth-limits", lager.Data{"handle": containerSpec.Handle, "limits": containerSpec.Limits.Bandwidth})
+	}
+
 	p.configStore.Set(containerSpec.Handle, gardener.ExternalIPKey, p.externalIP.String())
 
 	inputs := UpInputs{
@@ -94,7 +104,7 @@ func (p *externalBinaryNetworker) Network(log lager.Logger, containerSpec garden
 	}
 
 	outputs := UpOutputs{}
-	err := p.exec(log, "up", containerSpec.Handle, inputs, &outputs)
+	err := p.exec(ctx, log, "up", containerSpec.Handle, inputs, &outputs)
 	if err != nil {
 		return err
 	}
@@ -134,8 +144,10 @@ func (p *externalBinaryNetworker) Network(log lager.Logger, containerSpec garden
 	return nil
 }
 
+// Destroy runs the plugin's down action. It never takes a create's context, as
+// it is also how a failed create is cleaned up.
 func (p *externalBinaryNetworker) Destroy(log lager.Logger, handle string) error {
-	return p.exec(log, "down", handle, nil, nil)
+	return p.exec(context.Background(), log, "down", handle, nil, nil)
 }
 
 func (p *externalBinaryNetworker) Restore(log lager.Logger, handle string) error {
@@ -172,7 +184,7 @@ func (p *externalBinaryNetworker) NetIn(log lager.Logger, handle string, hostPor
 	}
 	outputs := NetInOutputs{}
 
-	err := p.exec(log, "net-in", handle, inputs, &outputs)
+	err := p.exec(context.Background(), log, "net-in", handle, inputs, &outputs)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -204,7 +216,7 @@ func (p *externalBinaryNetworker) NetOut(log lager.Logger, handle string, rule g
 		NetOutRule:  rule,
 	}
 
-	err := p.exec(log, "net-out", handle, inputs, nil)
+	err := p.exec(context.Background(), log, "net-out", handle, inputs, nil)
 	if err != nil {
 		return err
 	}
@@ -228,10 +240,16 @@ func (p *externalBinaryNetworker) BulkNetOut(log lager.Logger, handle string, ru
 		NetOutRules: rules,
 	}
 
-	return p.exec(log, "bulk-net-out", handle, inputs, nil)
+	return p.exec(context.Background(), log, "bulk-net-out", handle, inputs, nil)
+}
+
+var errBandwidthUnsupported = errors.New("external networker: bandwidth limits are not supported")
+
+func (p *externalBinaryNetworker) LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error {
+	return errBandwidthUnsupported
 }
 
-func (p *externalBinaryNetworker) exec(log lager.Logger, action, handle string,
+func (p *externalBinaryNetworker) exec(ctx context.Context, log lager.Logger, action, handle string,
 	inputData interface{}, outputData interface{}) error {
 
 	stdinBytes, err := json.Marshal(inputData)
@@ -240,7 +258,7 @@ func (p *externalBinaryNetworker) exec(log lager.Logger, action, handle string,
 	}
 
 	args := append(p.extraArg, "--action", action, "--handle", handle)
-	cmd := exec.Command(p.path, args...)
+	cmd := exec.CommandContext(ctx, p.path, args...)
 	stdout := &bytes.Buffer{}
 	cmd.Stdout = stdout
 	stderr := &bytes.Buffer{}
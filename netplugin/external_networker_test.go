@@ -1,6 +1,7 @@
 package netplugin_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -84,7 +85,7 @@ var _ = Describe("ExternalNetworker", func() {
 
 	Describe("Network", func() {
 		It("passes the pid of the container to the external plugin's stdin", func() {
-			err := plugin.Network(logger, containerSpec, 42)
+			err := plugin.Network(context.Background(), logger, containerSpec, 42)
 			Expect(err).NotTo(HaveOccurred())
 
 			cmd := fakeCommandRunner.ExecutedCommands()[0]
@@ -95,7 +96,7 @@ var _ = Describe("ExternalNetworker", func() {
 		})
 
 		It("executes the external plugin with the correct args and input", func() {
-			err := plugin.Network(logger, containerSpec, 42)
+			err := plugin.Network(context.Background(), logger, containerSpec, 42)
 			Expect(err).NotTo(HaveOccurred())
 
 			cmd := fakeCommandRunner.ExecutedCommands()[0]
@@ -138,7 +139,7 @@ var _ = Describe("ExternalNetworker", func() {
 			})
 
 			It("passes them in the stdin to the network plugin", func() {
-				Expect(plugin.Network(logger, containerSpec, 42)).To(Succeed())
+				Expect(plugin.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
 
 				cmd := fakeCommandRunner.ExecutedCommands()[0]
 				pluginInput, err := ioutil.ReadAll(cmd.Stdin)
@@ -180,7 +181,7 @@ var _ = Describe("ExternalNetworker", func() {
 			})
 
 			It("passes the input through stdin to the network plugin", func() {
-				Expect(plugin.Network(logger, containerSpec, 42)).To(Succeed())
+				Expect(plugin.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
 
 				cmd := fakeCommandRunner.ExecutedCommands()[0]
 				pluginInput, err := ioutil.ReadAll(cmd.Stdin)
@@ -207,7 +208,7 @@ var _ = Describe("ExternalNetworker", func() {
 		})
 
 		It("collects and logs the stderr from the plugin", func() {
-			err := plugin.Network(logger, containerSpec, 42)
+			err := plugin.Network(context.Background(), logger, containerSpec, 42)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(logger).To(gbytes.Say("result.*some-stderr-bytes"))
@@ -222,7 +223,7 @@ var _ = Describe("ExternalNetworker", func() {
 					pid int
 				)
 
-				err := plugin.Network(logger, containerSpec, 42)
+				err := plugin.Network(context.Background(), logger, containerSpec, 42)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(resolvConfigurer.ConfigureCallCount()).To(Equal(1))
@@ -326,17 +327,36 @@ var _ = Describe("ExternalNetworker", func() {
 			})
 		})
 
+		Context("when bandwidth limits are provided", func() {
+			BeforeEach(func() {
+				containerSpec.Limits.Bandwidth = garden.BandwidthLimits{
+					RateInBytesPerSecond:      1000,
+					BurstRateInBytesPerSecond: 200,
+				}
+			})
+
+			It("still runs the plugin", func() {
+				Expect(plugin.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+				Expect(fakeCommandRunner.ExecutedCommands()).To(HaveLen(1))
+			})
+
+			It("logs that the limits are ignored", func() {
+				Expect(plugin.Network(context.Background(), logger, containerSpec, 42)).To(Succeed())
+				Expect(logger).To(gbytes.Say("ignoring-bandwidth-limits"))
+			})
+		})
+
 		Context("when the external plugin errors", func() {
 			BeforeEach(func() {
 				pluginErr = errors.New("external-plugin-error")
 			})
 
 			It("returns the error", func() {
-				Expect(plugin.Network(logger, containerSpec, 42)).To(MatchError("external networker up: external-plugin-error"))
+				Expect(plugin.Network(context.Background(), logger, containerSpec, 42)).To(MatchError("external networker up: external-plugin-error"))
 			})
 
 			It("collects and logs the stderr from the plugin", func() {
-				plugin.Network(logger, containerSpec, 42)
+				plugin.Network(context.Background(), logger, containerSpec, 42)
 				Expect(logger).To(gbytes.Say("result.*error.*some-stderr-bytes"))
 			})
 		})
@@ -345,7 +365,7 @@ var _ = Describe("ExternalNetworker", func() {
 			It("persists the returned properties to the container's properties", func() {
 				pluginOutput = `{"properties":{"foo":"bar","ping":"pong","garden.network.container-ip":"10.255.1.2"}}`
 
-				err := plugin.Network(logger, containerSpec, 42)
+				err := plugin.Network(context.Background(), logger, containerSpec, 42)
 				Expect(err).NotTo(HaveOccurred())
 
 				persistedPropertyValue, _ := configStore.Get("some-handle", "foo")
@@ -357,7 +377,7 @@ var _ = Describe("ExternalNetworker", func() {
 			It("returns a useful error message", func() {
 				pluginOutput = "invalid-json"
 
-				err := plugin.Network(logger, containerSpec, 42)
+				err := plugin.Network(context.Background(), logger, containerSpec, 42)
 				Expect(err).To(MatchError(ContainSubstring("unmarshaling result from external networker")))
 			})
 		})
@@ -366,7 +386,7 @@ var _ = Describe("ExternalNetworker", func() {
 			It("succeeds", func() {
 				pluginOutput = ""
 
-				err := plugin.Network(logger, containerSpec, 42)
+				err := plugin.Network(context.Background(), logger, containerSpec, 42)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -0,0 +1,108 @@
+package properties
+
+import "fmt"
+
+// Batch is a set of changes to the properties of a handle which are made all
+// at once, or not at all. Removes are made before sets, so a property which
+// is both removed and set ends up set.
+type Batch struct {
+	// Preconditions must all hold for any of the changes to be made
+	Preconditions []Precondition `json:"preconditions,omitempty"`
+
+	Set    map[string]string `json:"set,omitempty"`
+	Remove []string          `json:"remove,omitempty"`
+}
+
+// Precondition is a value a property must have for a batch to be applied
+type Precondition struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+
+	// Absent means the property must not be set at all, rather than have Value
+	Absent bool `json:"absent,omitempty"`
+}
+
+func (p Precondition) holds(props map[string]string) bool {
+	value, exists := props[p.Name]
+	if p.Absent {
+		return !exists
+	}
+
+	return exists && value == p.Value
+}
+
+// PreconditionFailedError is returned when a batch is not applied because one
+// of its preconditions does not hold
+type PreconditionFailedError struct {
+	Handle       string
+	Precondition Precondition
+
+	// Actual is the value of the property, when it Exists
+	Actual string
+	Exists bool
+}
+
+func (e PreconditionFailedError) Error() string {
+	expected := fmt.Sprintf("'%s'", e.Precondition.Value)
+	if e.Precondition.Absent {
+		expected = "not set"
+	}
+
+	actual := "not set"
+	if e.Exists {
+		actual = fmt.Sprintf("'%s'", e.Actual)
+	}
+
+	return fmt.Sprintf("precondition failed for %s:%s: expected %s, was %s", e.Handle, e.Precondition.Name, expected, actual)
+}
+
+// Apply makes the changes in the batch to the properties of handle, provided
+// every precondition holds. Otherwise nothing is changed, and a
+// PreconditionFailedError is returned for the first which does not.
+func (m *Manager) Apply(handle string, batch Batch) error {
+	m.propMutex.Lock()
+	waitForSync, err := m.applyIfPreconditionsHold(handle, batch)
+	m.propMutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	waitForSync()
+	return nil
+}
+
+// applyIfPreconditionsHold does the work of Apply, returning what record does.
+// It must be called with the mutex held.
+func (m *Manager) applyIfPreconditionsHold(handle string, batch Batch) (func(), error) {
+	for _, precondition := range batch.Preconditions {
+		if !precondition.holds(m.prop[handle]) {
+			actual, exists := m.prop[handle][precondition.Name]
+			return nil, PreconditionFailedError{
+				Handle:       handle,
+				Precondition: precondition,
+				Actual:       actual,
+				Exists:       exists,
+			}
+		}
+	}
+
+	if len(batch.Set) == 0 && len(batch.Remove) == 0 {
+		return func() {}, nil
+	}
+
+	m.applyBatch(handle, batch.Set, batch.Remove)
+	// one record, so that replaying a log torn part way through never makes
+	// only some of the changes
+	return m.record(journalRecord{Op: opBatch, Handle: handle, Set: batch.Set, Remove: batch.Remove}), nil
+}
+
+func (m *Manager) applyBatch(handle string, set map[string]string, remove []string) {
+	for _, name := range remove {
+		m.remove(handle, name)
+	}
+
+	for name, value := range set {
+		m.set(handle, name, value)
+	}
+}
@@ -0,0 +1,137 @@
+package properties_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Apply", func() {
+	var mgr *properties.Manager
+
+	BeforeEach(func() {
+		mgr = properties.NewManager()
+		mgr.Set("handle", "owner", "controller-a")
+		mgr.Set("handle", "stale", "yes")
+	})
+
+	all := func() garden.Properties {
+		props, err := mgr.All("handle")
+		Expect(err).NotTo(HaveOccurred())
+		return props
+	}
+
+	It("sets and removes the properties", func() {
+		Expect(mgr.Apply("handle", properties.Batch{
+			Set:    map[string]string{"owner": "controller-b", "epoch": "2"},
+			Remove: []string{"stale", "never-set"},
+		})).To(Succeed())
+
+		Expect(all()).To(Equal(garden.Properties{"owner": "controller-b", "epoch": "2"}))
+		Expect(mgr.Matching(garden.Properties{"owner": "controller-b"})).To(ConsistOf("handle"))
+		Expect(mgr.Matching(garden.Properties{"stale": "yes"})).To(BeEmpty())
+	})
+
+	It("sets a property which is also removed", func() {
+		Expect(mgr.Apply("handle", properties.Batch{
+			Set:    map[string]string{"stale": "no"},
+			Remove: []string{"stale"},
+		})).To(Succeed())
+
+		Expect(all()).To(HaveKeyWithValue("stale", "no"))
+	})
+
+	Context("when the preconditions hold", func() {
+		It("makes the changes", func() {
+			Expect(mgr.Apply("handle", properties.Batch{
+				Preconditions: []properties.Precondition{
+					{Name: "owner", Value: "controller-a"},
+					{Name: "lease", Absent: true},
+				},
+				Set: map[string]string{"owner": "controller-b", "lease": "1"},
+			})).To(Succeed())
+
+			Expect(all()).To(Equal(garden.Properties{"owner": "controller-b", "lease": "1", "stale": "yes"}))
+		})
+	})
+
+	Context("when a precondition does not hold", func() {
+		It("makes none of the changes", func() {
+			err := mgr.Apply("handle", properties.Batch{
+				Preconditions: []properties.Precondition{
+					{Name: "stale", Value: "yes"},
+					{Name: "owner", Value: "controller-c"},
+				},
+				Set:    map[string]string{"owner": "controller-b"},
+				Remove: []string{"stale"},
+			})
+			Expect(err).To(MatchError(properties.PreconditionFailedError{
+				Handle:       "handle",
+				Precondition: properties.Precondition{Name: "owner", Value: "controller-c"},
+				Actual:       "controller-a",
+				Exists:       true,
+			}))
+			Expect(err).To(MatchError("precondition failed for handle:owner: expected 'controller-c', was 'controller-a'"))
+
+			Expect(all()).To(Equal(garden.Properties{"owner": "controller-a", "stale": "yes"}))
+		})
+
+		It("describes a property which was expected not to be set", func() {
+			err := mgr.Apply("handle", properties.Batch{
+				Preconditions: []properties.Precondition{{Name: "owner", Absent: true}},
+			})
+			Expect(err).To(MatchError("precondition failed for handle:owner: expected not set, was 'controller-a'"))
+		})
+
+		It("describes a property which was expected to be set", func() {
+			err := mgr.Apply("handle", properties.Batch{
+				Preconditions: []properties.Precondition{{Name: "lease", Value: ""}},
+			})
+			Expect(err).To(MatchError("precondition failed for handle:lease: expected '', was not set"))
+		})
+	})
+
+	Context("when the properties are journaled", func() {
+		var (
+			tmpDir    string
+			propsPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "batch")
+			Expect(err).NotTo(HaveOccurred())
+			propsPath = filepath.Join(tmpDir, "props.json")
+
+			mgr, err = properties.LoadJournaled(lagertest.NewTestLogger("test"), propsPath, 0)
+			Expect(err).NotTo(HaveOccurred())
+			mgr.Set("handle", "stale", "yes")
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(tmpDir)).To(Succeed())
+		})
+
+		It("journals the batch as a single change", func() {
+			Expect(mgr.Apply("handle", properties.Batch{
+				Set:    map[string]string{"a": "1", "b": "2"},
+				Remove: []string{"stale"},
+			})).To(Succeed())
+
+			contents, err := ioutil.ReadFile(propsPath + ".log")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(HaveSuffix(`{"op":"batch","handle":"handle","set":{"a":"1","b":"2"},"remove":["stale"]}` + "\n"))
+
+			reloaded, err := properties.Load(propsPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reloaded.All("handle")).To(Equal(garden.Properties{"a": "1", "b": "2"}))
+		})
+	})
+})
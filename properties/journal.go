@@ -0,0 +1,239 @@
+package properties
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	opSet     = "set"
+	opRemove  = "remove"
+	opDestroy = "destroy"
+	opBatch   = "batch"
+)
+
+// journalRecord is a change to the properties, as it is written to the log
+type journalRecord struct {
+	Op     string `json:"op"`
+	Handle string `json:"handle"`
+	Name   string `json:"name,omitempty"`
+	Value  string `json:"value,omitempty"`
+
+	// Set and Remove are the changes made by a batch
+	Set    map[string]string `json:"set,omitempty"`
+	Remove []string          `json:"remove,omitempty"`
+}
+
+// journal makes changes to the properties durable as they happen. Each change
+// is appended to a log beside the snapshot, and once there have been enough of
+// them the properties are written to a new snapshot and the log is emptied.
+//
+// Replaying the whole log over a snapshot which already has some of its
+// changes gives the same properties as replaying it over the snapshot before
+// them, as each property ends up as the last change to it left it. So a crash
+// at any point, even between writing a snapshot and emptying the log, loses
+// nothing which was journaled.
+//
+// Changes are written to the log under the manager's mutex, but synced to disk
+// after it is released, so that one sync can cover every change written while
+// another was in progress, and readers are not held up by the disk.
+type journal struct {
+	logger        lager.Logger
+	path          string
+	log           *os.File
+	snapshotAfter int
+	records       int
+
+	// written counts the changes ever written to the log, and synced how many
+	// of them are known to be on disk
+	written   uint64
+	syncMutex sync.Mutex
+	synced    uint64
+}
+
+func logPath(path string) string {
+	return path + ".log"
+}
+
+func openJournal(logger lager.Logger, path string, snapshotAfter int) (*journal, error) {
+	log, err := os.OpenFile(logPath(path), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &journal{
+		logger:        logger.Session("properties-journal", lager.Data{"path": path}),
+		path:          path,
+		log:           log,
+		snapshotAfter: snapshotAfter,
+	}, nil
+}
+
+// append writes the record to the log, returning the number to pass to sync to
+// wait for it to reach the disk. It must be called with the manager's mutex
+// held, so that the log is in the order the changes were made.
+func (j *journal) append(record journalRecord) (uint64, error) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := j.log.Write(append(line, '\n')); err != nil {
+		return 0, err
+	}
+
+	j.records++
+	return atomic.AddUint64(&j.written, 1), nil
+}
+
+// sync returns once the change numbered upTo, and every one before it, has
+// been synced to disk. It must be called without the manager's mutex held.
+func (j *journal) sync(upTo uint64) error {
+	j.syncMutex.Lock()
+	defer j.syncMutex.Unlock()
+
+	// a sync for a later change has covered this one
+	if j.synced >= upTo {
+		return nil
+	}
+
+	written := atomic.LoadUint64(&j.written)
+	if err := j.log.Sync(); err != nil {
+		return err
+	}
+
+	j.synced = written
+	return nil
+}
+
+// due reports whether enough changes have been logged to take a snapshot
+func (j *journal) due() bool {
+	return j.snapshotAfter > 0 && j.records >= j.snapshotAfter
+}
+
+// snapshot replaces the snapshot with the given properties and empties the
+// log. It must be called with the manager's mutex held, so that no change is
+// logged between the two.
+func (j *journal) snapshot(props map[string]map[string]string) error {
+	data, err := json.Marshal(props)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomically(j.path, data); err != nil {
+		return err
+	}
+
+	if err := j.log.Truncate(0); err != nil {
+		return err
+	}
+
+	if err := j.log.Sync(); err != nil {
+		return err
+	}
+
+	j.records = 0
+
+	// every change written so far is in the snapshot
+	j.syncMutex.Lock()
+	j.synced = atomic.LoadUint64(&j.written)
+	j.syncMutex.Unlock()
+
+	return nil
+}
+
+func (j *journal) close() error {
+	j.syncMutex.Lock()
+	defer j.syncMutex.Unlock()
+
+	return j.log.Close()
+}
+
+// replayLog applies each change in the log at path to the manager. A last
+// record without a newline was torn by a crash while it was written, and was
+// never acknowledged, so it is skipped.
+func replayLog(path string, mgr *Manager) error {
+	log, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+
+	reader := bufio.NewReader(log)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var record journalRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return fmt.Errorf("properties log %s: line %d: %s", path, lineNumber, err)
+		}
+
+		if err := mgr.apply(record); err != nil {
+			return fmt.Errorf("properties log %s: line %d: %s", path, lineNumber, err)
+		}
+	}
+}
+
+// writeFileAtomically writes data to a temporary file beside path, syncs it,
+// and renames it over path, so that path has either all of the old contents
+// or all of the new
+func writeFileAtomically(path string, data []byte) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// the rename is only durable once the directory is synced
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
@@ -0,0 +1,190 @@
+package properties_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Journal", func() {
+	var (
+		tmpDir    string
+		propsPath string
+		logger    *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "journal")
+		Expect(err).NotTo(HaveOccurred())
+
+		propsPath = filepath.Join(tmpDir, "props.json")
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	// as the next start would, after a crash which gave no chance to save
+	reload := func() *properties.Manager {
+		mgr, err := properties.Load(propsPath)
+		Expect(err).NotTo(HaveOccurred())
+		return mgr
+	}
+
+	readLog := func() string {
+		contents, err := ioutil.ReadFile(propsPath + ".log")
+		Expect(err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	It("keeps every change made, without being saved", func() {
+		mgr, err := properties.LoadJournaled(logger, propsPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		mgr.Set("a", "kawasaki.ip", "10.0.0.1")
+		mgr.Set("a", "garden.state", "creating")
+		mgr.Set("a", "garden.state", "created")
+		mgr.Set("a", "doomed", "yes")
+		Expect(mgr.Remove("a", "doomed")).To(Succeed())
+		mgr.Set("b", "garden.state", "created")
+		Expect(mgr.DestroyKeySpace("b")).To(Succeed())
+
+		reloaded := reload()
+		Expect(reloaded.All("a")).To(Equal(garden.Properties{
+			"kawasaki.ip":  "10.0.0.1",
+			"garden.state": "created",
+		}))
+		Expect(reloaded.Handles()).To(ConsistOf("a"))
+		Expect(reloaded.Matching(garden.Properties{"garden.state": "created"})).To(ConsistOf("a"))
+	})
+
+	It("keeps every change made concurrently", func() {
+		mgr, err := properties.LoadJournaled(logger, propsPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				mgr.Set("a", fmt.Sprintf("name-%d", i), "value")
+			}(i)
+		}
+		wg.Wait()
+
+		Expect(reload().All("a")).To(HaveLen(20))
+	})
+
+	It("takes a snapshot and empties the log once enough changes have been made", func() {
+		mgr, err := properties.LoadJournaled(logger, propsPath, 3)
+		Expect(err).NotTo(HaveOccurred())
+
+		mgr.Set("a", "one", "1")
+		mgr.Set("a", "two", "2")
+		Expect(readLog()).NotTo(BeEmpty())
+
+		mgr.Set("a", "three", "3")
+		Expect(readLog()).To(BeEmpty())
+
+		mgr.Set("a", "four", "4")
+		Expect(readLog()).To(ContainSubstring(`"four"`))
+
+		Expect(reload().All("a")).To(HaveLen(4))
+	})
+
+	It("replays the log over a snapshot which already has some of its changes", func() {
+		mgr, err := properties.LoadJournaled(logger, propsPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		mgr.Set("a", "name", "first")
+		Expect(mgr.DestroyKeySpace("a")).To(Succeed())
+		mgr.Set("a", "name", "second")
+		mgr.Set("b", "name", "third")
+		Expect(mgr.Remove("b", "name")).To(Succeed())
+
+		// as if a snapshot was written but the crash came before the log was emptied
+		Expect(properties.Save(propsPath, mgr)).To(Succeed())
+
+		reloaded := reload()
+		Expect(reloaded.All("a")).To(Equal(garden.Properties{"name": "second"}))
+		Expect(reloaded.All("b")).To(BeEmpty())
+	})
+
+	It("starts from a new snapshot of what it loaded", func() {
+		mgr, err := properties.LoadJournaled(logger, propsPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+		mgr.Set("a", "name", "value")
+
+		mgr, err = properties.LoadJournaled(logger, propsPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+		value, ok := mgr.Get("a", "name")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+		Expect(readLog()).To(BeEmpty())
+	})
+
+	Describe("Close", func() {
+		It("takes a snapshot and stops journaling", func() {
+			mgr, err := properties.LoadJournaled(logger, propsPath, 0)
+			Expect(err).NotTo(HaveOccurred())
+			mgr.Set("a", "name", "value")
+
+			Expect(mgr.Close()).To(Succeed())
+			Expect(readLog()).To(BeEmpty())
+
+			mgr.Set("a", "other", "value")
+			Expect(readLog()).To(BeEmpty())
+			Expect(reload().All("a")).To(Equal(garden.Properties{"name": "value"}))
+		})
+	})
+
+	Context("when the last change was torn by a crash", func() {
+		It("skips it", func() {
+			Expect(ioutil.WriteFile(propsPath+".log", []byte(
+				`{"op":"set","handle":"a","name":"kept","value":"1"}`+"\n"+`{"op":"set","handle":"a","na`,
+			), 0600)).To(Succeed())
+
+			Expect(reload().All("a")).To(Equal(garden.Properties{"kept": "1"}))
+		})
+	})
+
+	Context("when a change in the log cannot be read", func() {
+		It("returns an error", func() {
+			Expect(ioutil.WriteFile(propsPath+".log", []byte(
+				`{"op":"set","handle":"a","name":"kept","value":"1"}`+"\n"+`banana`+"\n",
+			), 0600)).To(Succeed())
+
+			_, err := properties.Load(propsPath)
+			Expect(err).To(MatchError(ContainSubstring("line 2")))
+		})
+	})
+
+	Context("when a change in the log is not known", func() {
+		It("returns an error", func() {
+			Expect(ioutil.WriteFile(propsPath+".log", []byte(`{"op":"explode","handle":"a"}`+"\n"), 0600)).To(Succeed())
+
+			_, err := properties.LoadJournaled(logger, propsPath, 0)
+			Expect(err).To(MatchError(ContainSubstring("unknown operation 'explode'")))
+		})
+	})
+
+	Context("when the log cannot be opened", func() {
+		It("returns an error", func() {
+			_, err := properties.LoadJournaled(logger, filepath.Join(tmpDir, "missing", "props.json"), 0)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -6,26 +6,58 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
 )
 
 type Manager struct {
 	propMutex sync.RWMutex
 	prop      map[string]map[string]string
+
+	// index holds the handles with each property, keyed by name and then value
+	index map[string]map[string]map[string]struct{}
+
+	// journal, when there is one, is told about each change as it is made
+	journal *journal
+
+	watchers map[*watcher]struct{}
 }
 
 func NewManager() *Manager {
 	return &Manager{
-		prop: make(map[string]map[string]string),
+		prop:  make(map[string]map[string]string),
+		index: make(map[string]map[string]map[string]struct{}),
 	}
 }
 
 func (m *Manager) DestroyKeySpace(handle string) error {
 	m.propMutex.Lock()
-	defer m.propMutex.Unlock()
+	m.destroyKeySpace(handle)
+	waitForSync := m.record(journalRecord{Op: opDestroy, Handle: handle})
+	m.propMutex.Unlock()
+
+	waitForSync()
+	return nil
+}
 
+func (m *Manager) destroyKeySpace(handle string) {
+	for name, value := range m.prop[handle] {
+		m.unindex(handle, name, value)
+		m.notify(Change{Kind: ChangeRemove, Handle: handle, Name: name})
+	}
 	delete(m.prop, handle)
+}
 
-	return nil
+// Handles returns the handles of all the key spaces which have properties set
+func (m *Manager) Handles() []string {
+	m.propMutex.RLock()
+	defer m.propMutex.RUnlock()
+
+	handles := []string{}
+	for handle := range m.prop {
+		handles = append(handles, handle)
+	}
+
+	return handles
 }
 
 func (m *Manager) MarshalJSON() ([]byte, error) {
@@ -33,25 +65,68 @@ func (m *Manager) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Manager) UnmarshalJSON(data []byte) error {
-	return json.Unmarshal(data, &(m.prop))
+	if err := json.Unmarshal(data, &(m.prop)); err != nil {
+		return err
+	}
+
+	if m.prop == nil {
+		m.prop = make(map[string]map[string]string)
+	}
+
+	m.index = make(map[string]map[string]map[string]struct{})
+	for handle, props := range m.prop {
+		for name, value := range props {
+			m.addToIndex(handle, name, value)
+		}
+	}
+
+	return nil
 }
 
 func (m *Manager) Set(handle string, name string, value string) {
 	m.propMutex.Lock()
-	defer m.propMutex.Unlock()
+	m.set(handle, name, value)
+	waitForSync := m.record(journalRecord{Op: opSet, Handle: handle, Name: name, Value: value})
+	m.propMutex.Unlock()
+
+	waitForSync()
+}
+
+func (m *Manager) set(handle string, name string, value string) {
+	if m.prop == nil {
+		m.prop = make(map[string]map[string]string)
+	}
 
 	if _, ok := m.prop[handle]; !ok {
 		m.prop[handle] = make(map[string]string)
 	}
 
+	if old, ok := m.prop[handle][name]; ok {
+		m.unindex(handle, name, old)
+	}
+
 	m.prop[handle][name] = value
+	m.addToIndex(handle, name, value)
+	m.notify(Change{Kind: ChangeSet, Handle: handle, Name: name, Value: value})
 }
 
+// All returns a copy of the properties of the handle, so that callers can
+// range over them while they are changed
 func (m *Manager) All(handle string) (garden.Properties, error) {
 	m.propMutex.RLock()
 	defer m.propMutex.RUnlock()
 
-	return m.prop[handle], nil
+	props, ok := m.prop[handle]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := make(garden.Properties, len(props))
+	for name, value := range props {
+		copied[name] = value
+	}
+
+	return copied, nil
 }
 
 func (m *Manager) Get(handle string, name string) (string, bool) {
@@ -69,15 +144,106 @@ func (m *Manager) Get(handle string, name string) (string, bool) {
 
 func (m *Manager) Remove(handle string, name string) error {
 	m.propMutex.Lock()
-	defer m.propMutex.Unlock()
-
 	if _, exists := m.prop[handle][name]; !exists {
+		m.propMutex.Unlock()
 		return NoSuchPropertyError{
 			Message: fmt.Sprintf("cannot Remove %s:%s", handle, name),
 		}
 	}
 
+	m.remove(handle, name)
+	waitForSync := m.record(journalRecord{Op: opRemove, Handle: handle, Name: name})
+	m.propMutex.Unlock()
+
+	waitForSync()
+	return nil
+}
+
+func (m *Manager) remove(handle string, name string) {
+	if _, exists := m.prop[handle][name]; !exists {
+		return
+	}
+
+	m.unindex(handle, name, m.prop[handle][name])
 	delete(m.prop[handle], name)
+	m.notify(Change{Kind: ChangeRemove, Handle: handle, Name: name})
+}
+
+// Snapshot writes all of the properties to the snapshot of the journal, and
+// empties its log. It does nothing when the properties are not journaled.
+func (m *Manager) Snapshot() error {
+	m.propMutex.Lock()
+	defer m.propMutex.Unlock()
+
+	if m.journal == nil {
+		return nil
+	}
+
+	return m.journal.snapshot(m.prop)
+}
+
+// Close stops journaling changes, once they have been written to a snapshot
+func (m *Manager) Close() error {
+	m.propMutex.Lock()
+	defer m.propMutex.Unlock()
+
+	if m.journal == nil {
+		return nil
+	}
+
+	err := m.journal.snapshot(m.prop)
+	if closeErr := m.journal.close(); err == nil {
+		err = closeErr
+	}
+	m.journal = nil
+
+	return err
+}
+
+// record tells the journal, if there is one, about a change which has just
+// been made, and takes a snapshot when one is due. The change has already
+// been made in memory, so a failure to journal it is logged rather than
+// returned. It must be called with the mutex held, and the function it returns
+// called once the mutex is released, to wait for the change to reach the disk.
+func (m *Manager) record(record journalRecord) func() {
+	journal := m.journal
+	if journal == nil {
+		return func() {}
+	}
+
+	written, err := journal.append(record)
+	if err != nil {
+		journal.logger.Error("append-failed", err, lager.Data{"handle": record.Handle, "op": record.Op})
+		return func() {}
+	}
+
+	if journal.due() {
+		if err := journal.snapshot(m.prop); err != nil {
+			journal.logger.Error("snapshot-failed", err)
+		}
+	}
+
+	return func() {
+		if err := journal.sync(written); err != nil {
+			journal.logger.Error("sync-failed", err, lager.Data{"handle": record.Handle, "op": record.Op})
+		}
+	}
+}
+
+// apply makes a change read back from the log, without journaling it again
+func (m *Manager) apply(record journalRecord) error {
+	switch record.Op {
+	case opSet:
+		m.set(record.Handle, record.Name, record.Value)
+	case opRemove:
+		m.remove(record.Handle, record.Name)
+	case opDestroy:
+		m.destroyKeySpace(record.Handle)
+	case opBatch:
+		m.applyBatch(record.Handle, record.Set, record.Remove)
+	default:
+		return fmt.Errorf("unknown operation '%s'", record.Op)
+	}
 
 	return nil
 }
@@ -86,6 +252,51 @@ func (m *Manager) MatchesAll(handle string, props garden.Properties) bool {
 	m.propMutex.RLock()
 	defer m.propMutex.RUnlock()
 
+	return m.matchesAll(handle, props)
+}
+
+// Matching returns the handles of the key spaces whose properties match all of
+// the given properties, as MatchesAll would, without visiting every key space
+func (m *Manager) Matching(props garden.Properties) []string {
+	m.propMutex.RLock()
+	defer m.propMutex.RUnlock()
+
+	// a property with an empty value also matches key spaces without it, which
+	// the index cannot answer, so only the other properties narrow the search
+	var (
+		candidates map[string]struct{}
+		narrowed   bool
+	)
+	for name, value := range props {
+		if value == "" {
+			continue
+		}
+
+		handles := m.index[name][value]
+		if !narrowed || len(handles) < len(candidates) {
+			candidates = handles
+			narrowed = true
+		}
+	}
+
+	if !narrowed {
+		candidates = make(map[string]struct{}, len(m.prop))
+		for handle := range m.prop {
+			candidates[handle] = struct{}{}
+		}
+	}
+
+	handles := []string{}
+	for handle := range candidates {
+		if m.matchesAll(handle, props) {
+			handles = append(handles, handle)
+		}
+	}
+
+	return handles
+}
+
+func (m *Manager) matchesAll(handle string, props garden.Properties) bool {
 	for key, val := range props {
 		if m.prop[handle][key] != val {
 			return false
@@ -95,6 +306,34 @@ func (m *Manager) MatchesAll(handle string, props garden.Properties) bool {
 	return true
 }
 
+func (m *Manager) addToIndex(handle, name, value string) {
+	if m.index == nil {
+		m.index = make(map[string]map[string]map[string]struct{})
+	}
+
+	if _, ok := m.index[name]; !ok {
+		m.index[name] = make(map[string]map[string]struct{})
+	}
+
+	if _, ok := m.index[name][value]; !ok {
+		m.index[name][value] = make(map[string]struct{})
+	}
+
+	m.index[name][value][handle] = struct{}{}
+}
+
+func (m *Manager) unindex(handle, name, value string) {
+	delete(m.index[name][value], handle)
+
+	if len(m.index[name][value]) == 0 {
+		delete(m.index[name], value)
+	}
+
+	if len(m.index[name]) == 0 {
+		delete(m.index, name)
+	}
+}
+
 type NoSuchPropertyError struct {
 	Message string
 }
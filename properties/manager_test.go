@@ -42,6 +42,17 @@ var _ = Describe("Properties", func() {
 			Expect(props).To(HaveLen(1))
 			Expect(props).To(HaveKeyWithValue("name", "value"))
 		})
+
+		It("returns a copy which later changes do not affect", func() {
+			props, err := propertyManager.All("handle")
+			Expect(err).NotTo(HaveOccurred())
+
+			propertyManager.Set("handle", "name", "changed")
+			props["other"] = "value"
+
+			Expect(props).To(Equal(garden.Properties{"name": "value", "other": "value"}))
+			Expect(propertyManager.Get("handle", "other")).To(BeEmpty())
+		})
 	})
 
 	Describe("Get", func() {
@@ -150,6 +161,82 @@ var _ = Describe("Properties", func() {
 		})
 	})
 
+	Describe("Matching", func() {
+		BeforeEach(func() {
+			propertyManager.Set("flintstones", "wilma", "fred")
+			propertyManager.Set("flintstones", "betty", "barney")
+			propertyManager.Set("rubbles", "betty", "barney")
+		})
+
+		It("returns the handles of the key spaces with all of the properties", func() {
+			Expect(propertyManager.Matching(garden.Properties{"betty": "barney"})).To(ConsistOf("flintstones", "rubbles"))
+			Expect(propertyManager.Matching(garden.Properties{"betty": "barney", "wilma": "fred"})).To(ConsistOf("flintstones"))
+		})
+
+		It("returns nothing when no key space has a property", func() {
+			Expect(propertyManager.Matching(garden.Properties{"betty": "pebbles"})).To(BeEmpty())
+			Expect(propertyManager.Matching(garden.Properties{"dino": "barney"})).To(BeEmpty())
+		})
+
+		Context("when the properties list is empty", func() {
+			It("returns every handle", func() {
+				Expect(propertyManager.Matching(garden.Properties{})).To(ConsistOf("handle", "flintstones", "rubbles"))
+			})
+		})
+
+		Context("when a property has an empty value", func() {
+			It("matches key spaces without the property, as MatchesAll does", func() {
+				Expect(propertyManager.Matching(garden.Properties{"wilma": ""})).To(ConsistOf("handle", "rubbles"))
+				Expect(propertyManager.Matching(garden.Properties{"wilma": "", "betty": "barney"})).To(ConsistOf("rubbles"))
+			})
+		})
+
+		Context("when a property is updated", func() {
+			It("only matches the new value", func() {
+				propertyManager.Set("rubbles", "betty", "bambam")
+
+				Expect(propertyManager.Matching(garden.Properties{"betty": "barney"})).To(ConsistOf("flintstones"))
+				Expect(propertyManager.Matching(garden.Properties{"betty": "bambam"})).To(ConsistOf("rubbles"))
+			})
+		})
+
+		Context("when a property is removed", func() {
+			It("no longer matches it", func() {
+				Expect(propertyManager.Remove("rubbles", "betty")).To(Succeed())
+
+				Expect(propertyManager.Matching(garden.Properties{"betty": "barney"})).To(ConsistOf("flintstones"))
+			})
+		})
+
+		Context("when a key space is destroyed", func() {
+			It("no longer matches it", func() {
+				Expect(propertyManager.DestroyKeySpace("flintstones")).To(Succeed())
+
+				Expect(propertyManager.Matching(garden.Properties{"betty": "barney"})).To(ConsistOf("rubbles"))
+				Expect(propertyManager.Matching(garden.Properties{"wilma": "fred"})).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Handles", func() {
+		It("returns the handles of every key space", func() {
+			propertyManager.Set("flintstones", "wilma", "fred")
+			propertyManager.Set("rubbles", "betty", "barney")
+
+			Expect(propertyManager.Handles()).To(ConsistOf("handle", "flintstones", "rubbles"))
+		})
+
+		Context("when a key space is destroyed", func() {
+			It("no longer returns its handle", func() {
+				propertyManager.Set("flintstones", "wilma", "fred")
+				propertyManager.Set("rubbles", "betty", "barney")
+				Expect(propertyManager.DestroyKeySpace("rubbles")).To(Succeed())
+
+				Expect(propertyManager.Handles()).To(ConsistOf("handle", "flintstones"))
+			})
+		})
+	})
+
 	Describe("MarshalJSON", func() {
 		It("can be saved and restored from JSON", func() {
 			mgr := properties.NewManager()
@@ -172,6 +259,22 @@ var _ = Describe("Properties", func() {
 			Expect(ok).To(BeTrue())
 			Expect(val).To(Equal("baz"))
 		})
+
+		It("indexes the restored properties", func() {
+			mgr := properties.NewManager()
+			mgr.Set("foo", "bar", "baz")
+
+			data, err := json.Marshal(mgr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var roundtripped properties.Manager
+			Expect(json.Unmarshal(data, &roundtripped)).To(Succeed())
+
+			Expect(roundtripped.Matching(garden.Properties{"bar": "baz"})).To(ConsistOf("foo"))
+
+			roundtripped.Set("foo", "bar", "qux")
+			Expect(roundtripped.Matching(garden.Properties{"bar": "baz"})).To(BeEmpty())
+		})
 	})
 
 })
@@ -3,29 +3,71 @@ package properties
 import (
 	"encoding/json"
 	"os"
+
+	"code.cloudfoundry.org/lager"
 )
 
+// Load reads the properties from the snapshot at path, and replays the log of
+// changes beside it, if the properties were journaled
 func Load(path string) (*Manager, error) {
+	mgr := NewManager()
+
 	f, err := os.Open(path)
-	defer f.Close()
+	if err != nil && !os.IsNotExist(err) {
+		// starting empty would lose every property in the snapshot, as
+		// LoadJournaled goes on to replace it
+		return nil, err
+	}
+	if err == nil {
+		defer f.Close()
+
+		if err := json.NewDecoder(f).Decode(mgr); err != nil {
+			return nil, err
+		}
+	}
+
+	if path == "" {
+		return mgr, nil
+	}
+
+	if err := replayLog(logPath(path), mgr); err != nil {
+		return nil, err
+	}
+
+	return mgr, nil
+}
+
+// LoadJournaled loads the properties as Load does, and from then on journals
+// each change to them, taking a new snapshot after every snapshotAfter
+// changes, or only when asked to when it is zero
+func LoadJournaled(logger lager.Logger, path string, snapshotAfter int) (*Manager, error) {
+	mgr, err := Load(path)
 	if err != nil {
-		return NewManager(), nil
+		return nil, err
+	}
+
+	journal, err := openJournal(logger, path, snapshotAfter)
+	if err != nil {
+		return nil, err
 	}
+	mgr.journal = journal
 
-	var mgr Manager
-	if err := json.NewDecoder(f).Decode(&mgr); err != nil {
+	// start from a snapshot of everything replayed, with an empty log
+	if err := mgr.Snapshot(); err != nil {
+		mgr.Close()
 		return nil, err
 	}
 
-	return &mgr, nil
+	return mgr, nil
 }
 
+// Save writes a snapshot of the properties to path, replacing any snapshot
+// there all at once
 func Save(path string, mgr *Manager) error {
-	f, err := os.Create(path)
-	defer f.Close()
+	data, err := json.Marshal(mgr)
 	if err != nil {
 		return err
 	}
 
-	return json.NewEncoder(f).Encode(mgr)
+	return writeFileAtomically(path, data)
 }
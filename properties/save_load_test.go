@@ -45,6 +45,13 @@ var _ = Describe("SaveLoad", func() {
 		Expect(val).To(Equal("baz"))
 	})
 
+	It("returns an error when the file cannot be opened", func() {
+		Expect(ioutil.WriteFile(path.Join(propPath, "not-a-dir"), []byte("{}"), 0644)).To(Succeed())
+
+		_, err := properties.Load(path.Join(propPath, "not-a-dir", "props.json"))
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("returns an error when decoding fails", func() {
 		Expect(ioutil.WriteFile(path.Join(propPath, "props.json"), []byte("{teest: banana"), 0655)).To(Succeed())
 
@@ -52,6 +59,19 @@ var _ = Describe("SaveLoad", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("replaces the file all at once, leaving nothing else behind", func() {
+		Expect(ioutil.WriteFile(path.Join(propPath, "props.json"), []byte("old"), 0644)).To(Succeed())
+
+		mgr := properties.NewManager()
+		mgr.Set("foo", "bar", "baz")
+		Expect(properties.Save(path.Join(propPath, "props.json"), mgr)).To(Succeed())
+
+		files, err := ioutil.ReadDir(propPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		Expect(files[0].Name()).To(Equal("props.json"))
+	})
+
 	It("returns an error when cannot write to the file", func() {
 		mgr := properties.NewManager()
 		Expect(properties.Save("/path/to/non/existing.json", mgr)).To(HaveOccurred())
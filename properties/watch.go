@@ -0,0 +1,106 @@
+package properties
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	ChangeSet    = "set"
+	ChangeRemove = "remove"
+
+	// ChangeResync is the last change a watcher which fell too far behind is
+	// told about, before its channel is closed. It has no handle or name. The
+	// watcher has missed changes since, so should read the properties again.
+	ChangeResync = "resync"
+)
+
+const watchBufferSize = 64
+
+// Change is a property which was set or removed. Destroying a key space
+// removes each of its properties.
+type Change struct {
+	Kind   string `json:"kind"`
+	Handle string `json:"handle"`
+	Name   string `json:"name"`
+	Value  string `json:"value,omitempty"`
+}
+
+// WatchFilter picks the changes a watcher is told about. Empty fields match
+// every change.
+type WatchFilter struct {
+	Handle string
+	Prefix string
+}
+
+func (f WatchFilter) matches(change Change) bool {
+	if f.Handle != "" && change.Handle != f.Handle {
+		return false
+	}
+
+	return strings.HasPrefix(change.Name, f.Prefix)
+}
+
+type watcher struct {
+	filter  WatchFilter
+	changes chan Change
+}
+
+// Watch returns a channel of the changes which match the filter, in the order
+// they were made. Rather than holding up the manager, a watcher which falls
+// watchBufferSize changes behind is sent a ChangeResync and stopped. The
+// returned function must be called to stop watching, after which the channel
+// is closed.
+func (m *Manager) Watch(filter WatchFilter) (<-chan Change, func()) {
+	w := &watcher{
+		filter: filter,
+		// the extra slot is kept for the ChangeResync
+		changes: make(chan Change, watchBufferSize+1),
+	}
+
+	m.propMutex.Lock()
+	if m.watchers == nil {
+		m.watchers = make(map[*watcher]struct{})
+	}
+	m.watchers[w] = struct{}{}
+	m.propMutex.Unlock()
+
+	var once sync.Once
+	return w.changes, func() {
+		once.Do(func() {
+			m.propMutex.Lock()
+			defer m.propMutex.Unlock()
+
+			// the watcher may already have been stopped for falling behind
+			if _, ok := m.watchers[w]; ok {
+				m.stopWatcher(w)
+			}
+		})
+	}
+}
+
+// notify tells the watchers about a change. It must be called with the mutex
+// held, so that the watchers see the changes in the order they were made.
+func (m *Manager) notify(change Change) {
+	for w := range m.watchers {
+		if !w.filter.matches(change) {
+			continue
+		}
+
+		// only notify sends to the channel, and always with the mutex held, so
+		// there is room for the ChangeResync
+		if len(w.changes) >= watchBufferSize {
+			w.changes <- Change{Kind: ChangeResync}
+			m.stopWatcher(w)
+			continue
+		}
+
+		w.changes <- change
+	}
+}
+
+// stopWatcher must be called with the mutex held
+func (m *Manager) stopWatcher(w *watcher) {
+	delete(m.watchers, w)
+	close(w.changes)
+}
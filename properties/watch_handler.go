@@ -0,0 +1,68 @@
+package properties
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// WatchHandler streams changes to properties to HTTP clients as
+// newline-delimited JSON until they disconnect. A client which falls too far
+// behind is sent a change of kind "resync", and the stream ends. Clients choose
+// the changes they receive with the query parameters:
+//
+//	handle=<handle>  only changes to the properties of the given container
+//	prefix=<prefix>  only changes to properties whose names have the prefix
+type WatchHandler struct {
+	manager *Manager
+	logger  lager.Logger
+}
+
+func NewWatchHandler(manager *Manager, logger lager.Logger) *WatchHandler {
+	return &WatchHandler{
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+func (h *WatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := h.logger.Session("watch-properties", lager.Data{"query": r.URL.RawQuery})
+	log.Info("started")
+	defer log.Info("finished")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	changes, stop := h.manager.Watch(WatchFilter{
+		Handle: query.Get("handle"),
+		Prefix: query.Get("prefix"),
+	})
+	defer stop()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				return
+			}
+
+			if err := encoder.Encode(change); err != nil {
+				log.Error("encode-failed", err)
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
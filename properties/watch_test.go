@@ -0,0 +1,193 @@
+package properties_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watch", func() {
+	var mgr *properties.Manager
+
+	BeforeEach(func() {
+		mgr = properties.NewManager()
+	})
+
+	receive := func(changes <-chan properties.Change) properties.Change {
+		var change properties.Change
+		Eventually(changes).Should(Receive(&change))
+		return change
+	}
+
+	It("tells the watcher about each property set and removed", func() {
+		changes, stop := mgr.Watch(properties.WatchFilter{})
+		defer stop()
+
+		mgr.Set("handle", "name", "value")
+		Expect(mgr.Remove("handle", "name")).To(Succeed())
+
+		Expect(receive(changes)).To(Equal(properties.Change{Kind: properties.ChangeSet, Handle: "handle", Name: "name", Value: "value"}))
+		Expect(receive(changes)).To(Equal(properties.Change{Kind: properties.ChangeRemove, Handle: "handle", Name: "name"}))
+	})
+
+	It("tells the watcher about the properties of a destroyed key space being removed", func() {
+		mgr.Set("handle", "a", "1")
+		mgr.Set("handle", "b", "2")
+
+		changes, stop := mgr.Watch(properties.WatchFilter{})
+		defer stop()
+		Expect(mgr.DestroyKeySpace("handle")).To(Succeed())
+
+		Expect([]string{receive(changes).Name, receive(changes).Name}).To(ConsistOf("a", "b"))
+	})
+
+	It("tells the watcher about each change in a batch", func() {
+		mgr.Set("handle", "stale", "yes")
+
+		changes, stop := mgr.Watch(properties.WatchFilter{})
+		defer stop()
+		Expect(mgr.Apply("handle", properties.Batch{
+			Set:    map[string]string{"fresh": "yes"},
+			Remove: []string{"stale"},
+		})).To(Succeed())
+
+		Expect(receive(changes)).To(Equal(properties.Change{Kind: properties.ChangeRemove, Handle: "handle", Name: "stale"}))
+		Expect(receive(changes)).To(Equal(properties.Change{Kind: properties.ChangeSet, Handle: "handle", Name: "fresh", Value: "yes"}))
+	})
+
+	It("only tells the watcher about changes which match its filter", func() {
+		changes, stop := mgr.Watch(properties.WatchFilter{Handle: "wanted", Prefix: "app."})
+		defer stop()
+
+		mgr.Set("unwanted", "app.name", "x")
+		mgr.Set("wanted", "other", "x")
+		mgr.Set("wanted", "app.name", "y")
+
+		Expect(receive(changes).Value).To(Equal("y"))
+		Consistently(changes).ShouldNot(Receive())
+	})
+
+	Context("when stopped", func() {
+		It("closes the channel and stops telling the watcher about changes", func() {
+			changes, stop := mgr.Watch(properties.WatchFilter{})
+			stop()
+			stop()
+
+			mgr.Set("handle", "name", "value")
+			Eventually(changes).Should(BeClosed())
+		})
+	})
+
+	Context("when a watcher is not keeping up", func() {
+		It("does not hold up the manager", func() {
+			_, stop := mgr.Watch(properties.WatchFilter{})
+			defer stop()
+
+			done := make(chan struct{})
+			go func() {
+				for i := 0; i < 1000; i++ {
+					mgr.Set("handle", "name", "value")
+				}
+				close(done)
+			}()
+
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("tells the watcher to resync once its buffer is full, and stops it", func() {
+			changes, stop := mgr.Watch(properties.WatchFilter{})
+			defer stop()
+
+			for i := 0; i < 100; i++ {
+				mgr.Set("handle", "name", strconv.Itoa(i))
+			}
+
+			for i := 0; i < 64; i++ {
+				Expect(receive(changes).Value).To(Equal(strconv.Itoa(i)))
+			}
+			Expect(receive(changes)).To(Equal(properties.Change{Kind: properties.ChangeResync}))
+			Expect(changes).To(BeClosed())
+		})
+
+		It("does not tell the watcher about changes made after it was stopped", func() {
+			changes, stop := mgr.Watch(properties.WatchFilter{})
+			defer stop()
+
+			for i := 0; i < 65; i++ {
+				mgr.Set("handle", "name", "value")
+			}
+
+			var received []properties.Change
+			for change := range changes {
+				received = append(received, change)
+			}
+			Expect(received).To(HaveLen(65))
+
+			mgr.Set("handle", "name", "value")
+			Expect(changes).To(BeClosed())
+		})
+	})
+})
+
+var _ = Describe("WatchHandler", func() {
+	var (
+		mgr    *properties.Manager
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mgr = properties.NewManager()
+		server = httptest.NewServer(properties.NewWatchHandler(mgr, lagertest.NewTestLogger("test")))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	watch := func(query string) (*http.Response, <-chan properties.Change) {
+		resp, err := http.Get(server.URL + "/properties/watch?" + query)
+		Expect(err).NotTo(HaveOccurred())
+
+		changes := make(chan properties.Change, 10)
+		go func() {
+			defer GinkgoRecover()
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				var change properties.Change
+				Expect(json.Unmarshal(scanner.Bytes(), &change)).To(Succeed())
+				changes <- change
+			}
+		}()
+
+		return resp, changes
+	}
+
+	It("streams the changes which match the query as JSON", func() {
+		resp, changes := watch("handle=wanted&prefix=app.")
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("application/x-ndjson"))
+
+		// the handler watches before responding, so nothing changed now is missed
+		mgr.Set("unwanted", "app.name", "x")
+		mgr.Set("wanted", "other", "x")
+		mgr.Set("wanted", "app.name", "y")
+		Expect(mgr.Remove("wanted", "app.name")).To(Succeed())
+
+		var change properties.Change
+		Eventually(changes).Should(Receive(&change))
+		Expect(change).To(Equal(properties.Change{Kind: properties.ChangeSet, Handle: "wanted", Name: "app.name", Value: "y"}))
+		Eventually(changes).Should(Receive(&change))
+		Expect(change).To(Equal(properties.Change{Kind: properties.ChangeRemove, Handle: "wanted", Name: "app.name"}))
+		Consistently(changes).ShouldNot(Receive())
+	})
+})
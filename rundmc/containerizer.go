@@ -1,6 +1,7 @@
 package rundmc
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -8,6 +9,7 @@ import (
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc/depot"
 	"code.cloudfoundry.org/guardian/rundmc/goci"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
 	"code.cloudfoundry.org/lager"
@@ -18,6 +20,7 @@ import (
 //go:generate counterfeiter . NstarRunner
 //go:generate counterfeiter . EventStore
 //go:generate counterfeiter . BundleLoader
+//go:generate counterfeiter . BundleSaver
 //go:generate counterfeiter . Stopper
 //go:generate counterfeiter . StateStore
 //go:generate counterfeiter . RootfsFileCreator
@@ -33,15 +36,23 @@ type BundleLoader interface {
 	Load(path string) (goci.Bndl, error)
 }
 
+type BundleSaver interface {
+	Save(bundle goci.Bndl, path string) error
+}
+
 type OCIRuntime interface {
-	Create(log lager.Logger, bundlePath, id string, io garden.ProcessIO) error
+	Create(ctx context.Context, log lager.Logger, bundlePath, id string, io garden.ProcessIO) error
 	Exec(log lager.Logger, bundlePath, id string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
 	Attach(log lager.Logger, bundlePath, id, processId string, io garden.ProcessIO) (garden.Process, error)
 	Kill(log lager.Logger, bundlePath string) error
-	Delete(log lager.Logger, bundlePath string) error
+	Delete(log lager.Logger, force bool, bundlePath string) error
 	State(log lager.Logger, id string) (runrunc.State, error)
 	Stats(log lager.Logger, id string) (gardener.ActualContainerMetrics, error)
 	WatchEvents(log lager.Logger, id string, eventsNotifier runrunc.EventsNotifier) error
+	Update(log lager.Logger, id string, resources specs.LinuxResources) error
+	List(log lager.Logger) ([]string, error)
+	Pause(log lager.Logger, id string) error
+	Resume(log lager.Logger, id string) error
 }
 
 type NstarRunner interface {
@@ -77,9 +88,11 @@ type Containerizer struct {
 	events            EventStore
 	states            StateStore
 	rootfsFileCreator RootfsFileCreator
+	saver             BundleSaver
+	limits            BundlerRule
 }
 
-func New(depot Depot, runtime OCIRuntime, loader BundleLoader, nstarRunner NstarRunner, stopper Stopper, events EventStore, states StateStore, rootfsFileCreator RootfsFileCreator) *Containerizer {
+func New(depot Depot, runtime OCIRuntime, loader BundleLoader, nstarRunner NstarRunner, stopper Stopper, events EventStore, states StateStore, rootfsFileCreator RootfsFileCreator, saver BundleSaver, limits BundlerRule) *Containerizer {
 	return &Containerizer{
 		depot:             depot,
 		runtime:           runtime,
@@ -89,11 +102,13 @@ func New(depot Depot, runtime OCIRuntime, loader BundleLoader, nstarRunner Nstar
 		events:            events,
 		states:            states,
 		rootfsFileCreator: rootfsFileCreator,
+		saver:             saver,
+		limits:            limits,
 	}
 }
 
 // Create creates a bundle in the depot and starts its init process
-func (c *Containerizer) Create(log lager.Logger, spec gardener.DesiredContainerSpec) error {
+func (c *Containerizer) Create(ctx context.Context, log lager.Logger, spec gardener.DesiredContainerSpec) error {
 	log = log.Session("containerizer-create", lager.Data{"handle": spec.Handle})
 
 	log.Info("start")
@@ -115,7 +130,7 @@ func (c *Containerizer) Create(log lager.Logger, spec gardener.DesiredContainerS
 		return err
 	}
 
-	if err = c.runtime.Create(log, path, spec.Handle, garden.ProcessIO{}); err != nil {
+	if err = c.runtime.Create(ctx, log, path, spec.Handle, garden.ProcessIO{}); err != nil {
 		log.Error("runtime-create-failed", err)
 		return err
 	}
@@ -142,6 +157,10 @@ func (c *Containerizer) Run(log lager.Logger, handle string, spec garden.Process
 		return nil, err
 	}
 
+	if state, err := c.runtime.State(log, handle); err == nil && state.Status == runrunc.PausedStatus {
+		return nil, gardener.ContainerPausedError{Handle: handle}
+	}
+
 	return c.runtime.Exec(log, path, handle, spec, io)
 }
 
@@ -173,6 +192,10 @@ func (c *Containerizer) StreamIn(log lager.Logger, handle string, spec garden.St
 		return fmt.Errorf("stream-in: pid not found for container")
 	}
 
+	if state.Status == runrunc.PausedStatus {
+		return gardener.ContainerPausedError{Handle: handle}
+	}
+
 	if err := c.nstar.StreamIn(log, state.Pid, spec.Path, spec.User, spec.TarStream); err != nil {
 		log.Error("nstar-failed", err)
 		return fmt.Errorf("stream-in: nstar: %s", err)
@@ -216,6 +239,10 @@ func (c *Containerizer) Stop(log lager.Logger, handle string, kill bool) error {
 		return fmt.Errorf("stop: pid not found for container: %s", err)
 	}
 
+	if state.Status == runrunc.PausedStatus {
+		return gardener.ContainerPausedError{Handle: handle}
+	}
+
 	if err = c.stopper.StopAll(log, handle, []int{state.Pid}, kill); err != nil {
 		log.Error("stop-all-failed", err, lager.Data{"pid": state.Pid})
 		return fmt.Errorf("stop: %s", err)
@@ -243,12 +270,92 @@ func (c *Containerizer) Destroy(log lager.Logger, handle string) error {
 	})
 
 	if state.Status == runrunc.CreatedStatus || state.Status == runrunc.StoppedStatus {
-		if err := c.runtime.Delete(log, handle); err != nil {
+		if err := c.runtime.Delete(log, false, handle); err != nil {
 			log.Error("delete-failed", err)
 			return err
 		}
 	}
 
+	// the processes in a paused container cannot exit, so it has to be killed
+	if state.Status == runrunc.PausedStatus {
+		if err := c.runtime.Delete(log, true, handle); err != nil {
+			log.Error("force-delete-failed", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Pause freezes all of the processes in the container
+func (c *Containerizer) Pause(log lager.Logger, handle string) error {
+	log = log.Session("pause", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	return c.runtime.Pause(log, handle)
+}
+
+// Resume thaws all of the processes in a paused container
+func (c *Containerizer) Resume(log lager.Logger, handle string) error {
+	log = log.Session("resume", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	return c.runtime.Resume(log, handle)
+}
+
+// UpdateLimits changes the memory and CPU limits of a running container and
+// records the new limits in its bundle. Zero-valued limits are left unchanged.
+func (c *Containerizer) UpdateLimits(log lager.Logger, handle string, limits garden.Limits) error {
+	log = log.Session("update-limits", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	bundlePath, err := c.depot.Lookup(log, handle)
+	if err != nil {
+		log.Error("lookup-failed", err)
+		return err
+	}
+
+	bundle, err := c.loader.Load(bundlePath)
+	if err != nil {
+		log.Error("load-bundle-failed", err)
+		return err
+	}
+
+	desiredLimits := bundleLimits(bundle)
+	if limits.Memory.LimitInBytes != 0 {
+		desiredLimits.Memory = limits.Memory
+	}
+	if limits.CPU.LimitInShares != 0 {
+		desiredLimits.CPU = limits.CPU
+	}
+
+	bundle, err = c.limits.Apply(bundle, gardener.DesiredContainerSpec{Handle: handle, Limits: desiredLimits}, bundlePath)
+	if err != nil {
+		log.Error("apply-limits-failed", err)
+		return err
+	}
+
+	resources := bundle.Resources()
+	if err := c.runtime.Update(log, handle, specs.LinuxResources{
+		Memory: resources.Memory,
+		CPU:    resources.CPU,
+		Pids:   resources.Pids,
+	}); err != nil {
+		log.Error("runtime-update-failed", err)
+		return err
+	}
+
+	if err := c.saver.Save(bundle, bundlePath); err != nil {
+		log.Error("save-bundle-failed", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -257,8 +364,14 @@ func (c *Containerizer) RemoveBundle(log lager.Logger, handle string) error {
 	return c.depot.Destroy(log, handle)
 }
 
+// Info returns a garden.ContainerNotFoundError when the container has no depot
+// directory or the runtime does not know about it, so that callers can tell a
+// container which is gone from one which could not be looked at
 func (c *Containerizer) Info(log lager.Logger, handle string) (gardener.ActualContainerSpec, error) {
 	bundlePath, err := c.depot.Lookup(log, handle)
+	if err == depot.ErrDoesNotExist {
+		return gardener.ActualContainerSpec{}, garden.ContainerNotFoundError{Handle: handle}
+	}
 	if err != nil {
 		return gardener.ActualContainerSpec{}, err
 	}
@@ -270,6 +383,10 @@ func (c *Containerizer) Info(log lager.Logger, handle string) (gardener.ActualCo
 
 	state, err := c.runtime.State(log, handle)
 	if err != nil {
+		if c.unknownToRuntime(log, handle) {
+			return gardener.ActualContainerSpec{}, garden.ContainerNotFoundError{Handle: handle}
+		}
+
 		return gardener.ActualContainerSpec{}, err
 	}
 
@@ -287,6 +404,7 @@ func (c *Containerizer) Info(log lager.Logger, handle string) (gardener.ActualCo
 		RootFSPath: bundle.RootFS(),
 		Events:     c.events.Events(handle),
 		Stopped:    c.states.IsStopped(handle),
+		Paused:     state.Status == runrunc.PausedStatus,
 		Limits: garden.Limits{
 			CPU: garden.CPULimits{
 				LimitInShares: *bundle.Resources().CPU.Shares,
@@ -307,3 +425,73 @@ func (c *Containerizer) Metrics(log lager.Logger, handle string) (gardener.Actua
 func (c *Containerizer) Handles() ([]string, error) {
 	return c.depot.Handles()
 }
+
+// Orphans returns the ids of the containers known to the runtime which do not
+// belong to any of the given handles, e.g. because their depot directory was
+// lost in a crash. Every container in the runtime's root is taken to be ours,
+// so it must only be used with a root which no one else uses.
+func (c *Containerizer) Orphans(log lager.Logger, handles []string) ([]string, error) {
+	ids, err := c.runtime.List(log)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, handle := range handles {
+		known[handle] = true
+	}
+
+	orphans := []string{}
+	for _, id := range ids {
+		if !known[id] {
+			orphans = append(orphans, id)
+		}
+	}
+
+	return orphans, nil
+}
+
+// unknownToRuntime is true only when the runtime can list its containers and
+// the handle is not among them
+func (c *Containerizer) unknownToRuntime(log lager.Logger, handle string) bool {
+	ids, err := c.runtime.List(log)
+	if err != nil {
+		return false
+	}
+
+	for _, id := range ids {
+		if id == handle {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RemoveOrphan kills and deletes a container which has no depot directory
+func (c *Containerizer) RemoveOrphan(log lager.Logger, id string) error {
+	return c.runtime.Delete(log, true, id)
+}
+
+func bundleLimits(bundle goci.Bndl) garden.Limits {
+	var limits garden.Limits
+
+	resources := bundle.Resources()
+	if resources == nil {
+		return limits
+	}
+
+	if resources.Memory != nil && resources.Memory.Limit != nil {
+		limits.Memory.LimitInBytes = uint64(*resources.Memory.Limit)
+	}
+
+	if resources.CPU != nil && resources.CPU.Shares != nil {
+		limits.CPU.LimitInShares = *resources.CPU.Shares
+	}
+
+	if resources.Pids != nil {
+		limits.Pid.Max = uint64(resources.Pids.Limit)
+	}
+
+	return limits
+}
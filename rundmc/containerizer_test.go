@@ -1,6 +1,7 @@
 package rundmc_test
 
 import (
+	"context"
 	"errors"
 	"os"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/gardener"
 	"code.cloudfoundry.org/guardian/rundmc"
+	"code.cloudfoundry.org/guardian/rundmc/depot"
 	"code.cloudfoundry.org/guardian/rundmc/goci"
 	fakes "code.cloudfoundry.org/guardian/rundmc/rundmcfakes"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
@@ -29,6 +31,8 @@ var _ = Describe("Rundmc", func() {
 		fakeEventStore        *fakes.FakeEventStore
 		fakeStateStore        *fakes.FakeStateStore
 		fakeRootfsFileCreator *fakes.FakeRootfsFileCreator
+		fakeBundleSaver       *fakes.FakeBundleSaver
+		fakeLimitsRule        *fakes.FakeBundlerRule
 
 		logger        lager.Logger
 		containerizer *rundmc.Containerizer
@@ -43,13 +47,15 @@ var _ = Describe("Rundmc", func() {
 		fakeEventStore = new(fakes.FakeEventStore)
 		fakeStateStore = new(fakes.FakeStateStore)
 		fakeRootfsFileCreator = new(fakes.FakeRootfsFileCreator)
+		fakeBundleSaver = new(fakes.FakeBundleSaver)
+		fakeLimitsRule = new(fakes.FakeBundlerRule)
 		logger = lagertest.NewTestLogger("test")
 
 		fakeDepot.LookupStub = func(_ lager.Logger, handle string) (string, error) {
 			return "/path/to/" + handle, nil
 		}
 
-		containerizer = rundmc.New(fakeDepot, fakeOCIRuntime, fakeBundleLoader, fakeNstarRunner, fakeStopper, fakeEventStore, fakeStateStore, fakeRootfsFileCreator)
+		containerizer = rundmc.New(fakeDepot, fakeOCIRuntime, fakeBundleLoader, fakeNstarRunner, fakeStopper, fakeEventStore, fakeStateStore, fakeRootfsFileCreator, fakeBundleSaver, fakeLimitsRule)
 	})
 
 	Describe("Create", func() {
@@ -57,7 +63,7 @@ var _ = Describe("Rundmc", func() {
 			spec := gardener.DesiredContainerSpec{
 				Handle: "exuberant!",
 			}
-			containerizer.Create(logger, spec)
+			containerizer.Create(context.Background(), logger, spec)
 
 			Expect(fakeDepot.CreateCallCount()).To(Equal(1))
 
@@ -69,26 +75,36 @@ var _ = Describe("Rundmc", func() {
 		Context("when creating the depot directory fails", func() {
 			It("returns an error", func() {
 				fakeDepot.CreateReturns(errors.New("blam"))
-				Expect(containerizer.Create(logger, gardener.DesiredContainerSpec{
+				Expect(containerizer.Create(context.Background(), logger, gardener.DesiredContainerSpec{
 					Handle: "exuberant!",
 				})).NotTo(Succeed())
 			})
 		})
 
 		It("should create a container in the given directory", func() {
-			Expect(containerizer.Create(logger, gardener.DesiredContainerSpec{
+			Expect(containerizer.Create(context.Background(), logger, gardener.DesiredContainerSpec{
 				Handle: "exuberant!",
 			})).To(Succeed())
 
 			Expect(fakeOCIRuntime.CreateCallCount()).To(Equal(1))
 
-			_, path, id, _ := fakeOCIRuntime.CreateArgsForCall(0)
+			_, _, path, id, _ := fakeOCIRuntime.CreateArgsForCall(0)
 			Expect(path).To(Equal("/path/to/exuberant!"))
 			Expect(id).To(Equal("exuberant!"))
 		})
 
+		It("passes the context on to the runtime", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			Expect(containerizer.Create(ctx, logger, gardener.DesiredContainerSpec{Handle: "some-handle"})).To(Succeed())
+
+			runtimeCtx, _, _, _, _ := fakeOCIRuntime.CreateArgsForCall(0)
+			Expect(runtimeCtx).To(Equal(ctx))
+		})
+
 		It("should prepare the root file system by creating mount points", func() {
-			Expect(containerizer.Create(logger, gardener.DesiredContainerSpec{
+			Expect(containerizer.Create(context.Background(), logger, gardener.DesiredContainerSpec{
 				Handle:     "exuberant!",
 				RootFSPath: "some-rootfs",
 			})).To(Succeed())
@@ -105,7 +121,7 @@ var _ = Describe("Rundmc", func() {
 			})
 
 			It("returns the error", func() {
-				Expect(containerizer.Create(logger, gardener.DesiredContainerSpec{})).To(MatchError("file-create-fail"))
+				Expect(containerizer.Create(context.Background(), logger, gardener.DesiredContainerSpec{})).To(MatchError("file-create-fail"))
 			})
 		})
 
@@ -115,7 +131,7 @@ var _ = Describe("Rundmc", func() {
 			})
 
 			It("should return an error", func() {
-				Expect(containerizer.Create(logger, gardener.DesiredContainerSpec{})).NotTo(Succeed())
+				Expect(containerizer.Create(context.Background(), logger, gardener.DesiredContainerSpec{})).NotTo(Succeed())
 			})
 		})
 
@@ -128,7 +144,7 @@ var _ = Describe("Rundmc", func() {
 			created := make(chan struct{})
 			go func() {
 				defer GinkgoRecover()
-				Expect(containerizer.Create(logger, gardener.DesiredContainerSpec{Handle: "some-container"})).To(Succeed())
+				Expect(containerizer.Create(context.Background(), logger, gardener.DesiredContainerSpec{Handle: "some-container"})).To(Succeed())
 				close(created)
 			}()
 
@@ -170,6 +186,22 @@ var _ = Describe("Rundmc", func() {
 				Expect(fakeOCIRuntime.ExecCallCount()).To(Equal(0))
 			})
 		})
+
+		Context("when the container is paused", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.StateReturns(runrunc.State{Status: runrunc.PausedStatus}, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := containerizer.Run(logger, "some-handle", garden.ProcessSpec{}, garden.ProcessIO{})
+				Expect(err).To(MatchError(gardener.ContainerPausedError{Handle: "some-handle"}))
+			})
+
+			It("does not attempt to exec the process", func() {
+				containerizer.Run(logger, "some-handle", garden.ProcessSpec{}, garden.ProcessIO{})
+				Expect(fakeOCIRuntime.ExecCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("Attach", func() {
@@ -227,6 +259,17 @@ var _ = Describe("Rundmc", func() {
 			fakeNstarRunner.StreamInReturns(errors.New("failed"))
 			Expect(containerizer.StreamIn(logger, "some-handle", garden.StreamInSpec{})).To(MatchError("stream-in: nstar: failed"))
 		})
+
+		Context("when the container is paused", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.StateReturns(runrunc.State{Pid: 12, Status: runrunc.PausedStatus}, nil)
+			})
+
+			It("returns an error without streaming in", func() {
+				Expect(containerizer.StreamIn(logger, "some-handle", garden.StreamInSpec{})).To(MatchError(gardener.ContainerPausedError{Handle: "some-handle"}))
+				Expect(fakeNstarRunner.StreamInCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("StreamOut", func() {
@@ -328,6 +371,18 @@ var _ = Describe("Rundmc", func() {
 				Expect(fakeStateStore.StoreStoppedCallCount()).To(Equal(0))
 			})
 		})
+
+		Context("when the container is paused", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.StateReturns(runrunc.State{Pid: 1234, Status: runrunc.PausedStatus}, nil)
+			})
+
+			It("returns an error without stopping the processes", func() {
+				Expect(containerizer.Stop(logger, "some-handle", true)).To(MatchError(gardener.ContainerPausedError{Handle: "some-handle"}))
+				Expect(fakeStopper.StopAllCallCount()).To(Equal(0))
+				Expect(fakeStateStore.StoreStoppedCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("Destroy", func() {
@@ -349,7 +404,9 @@ var _ = Describe("Rundmc", func() {
 				It("should run delete", func() {
 					Expect(containerizer.Destroy(logger, "some-handle")).To(Succeed())
 					Expect(fakeOCIRuntime.DeleteCallCount()).To(Equal(1))
-					Expect(arg2(fakeOCIRuntime.DeleteArgsForCall(0))).To(Equal("some-handle"))
+					_, force, handle := fakeOCIRuntime.DeleteArgsForCall(0)
+					Expect(force).To(BeFalse())
+					Expect(handle).To(Equal("some-handle"))
 				})
 
 				Context("when delete fails", func() {
@@ -384,6 +441,21 @@ var _ = Describe("Rundmc", func() {
 			})
 		})
 
+		Context("when the container is paused", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.StateReturns(runrunc.State{Status: runrunc.PausedStatus}, nil)
+			})
+
+			It("force deletes the container", func() {
+				Expect(containerizer.Destroy(logger, "some-handle")).To(Succeed())
+
+				Expect(fakeOCIRuntime.DeleteCallCount()).To(Equal(1))
+				_, force, handle := fakeOCIRuntime.DeleteArgsForCall(0)
+				Expect(force).To(BeTrue())
+				Expect(handle).To(Equal("some-handle"))
+			})
+		})
+
 		Context("when state that should not result in a delete", func() {
 			BeforeEach(func() {
 				fakeOCIRuntime.StateReturns(runrunc.State{
@@ -417,6 +489,119 @@ var _ = Describe("Rundmc", func() {
 		})
 	})
 
+	Describe("UpdateLimits", func() {
+		var (
+			limits        garden.Limits
+			loadedBundle  goci.Bndl
+			limitedBundle goci.Bndl
+		)
+
+		BeforeEach(func() {
+			var memoryLimit int64 = 1024
+			var cpuShares uint64 = 10
+			loadedBundle = goci.Bundle().
+				WithMemoryLimit(specs.LinuxMemory{Limit: &memoryLimit}).
+				WithCPUShares(specs.LinuxCPU{Shares: &cpuShares})
+
+			var newMemoryLimit int64 = 2048
+			limitedBundle = goci.Bundle().
+				WithMemoryLimit(specs.LinuxMemory{Limit: &newMemoryLimit}).
+				WithCPUShares(specs.LinuxCPU{Shares: &cpuShares})
+
+			limits = garden.Limits{Memory: garden.MemoryLimits{LimitInBytes: 2048}}
+
+			fakeDepot.LookupReturns("/path/to/some-handle", nil)
+			fakeBundleLoader.LoadReturns(loadedBundle, nil)
+			fakeLimitsRule.ApplyReturns(limitedBundle, nil)
+		})
+
+		It("applies the requested limits on top of the existing bundle limits", func() {
+			Expect(containerizer.UpdateLimits(logger, "some-handle", limits)).To(Succeed())
+
+			Expect(fakeBundleLoader.LoadArgsForCall(0)).To(Equal("/path/to/some-handle"))
+			Expect(fakeLimitsRule.ApplyCallCount()).To(Equal(1))
+			bundle, spec, bundlePath := fakeLimitsRule.ApplyArgsForCall(0)
+			Expect(bundle).To(Equal(loadedBundle))
+			Expect(bundlePath).To(Equal("/path/to/some-handle"))
+			Expect(spec.Handle).To(Equal("some-handle"))
+			Expect(spec.Limits.Memory.LimitInBytes).To(BeEquivalentTo(2048))
+			Expect(spec.Limits.CPU.LimitInShares).To(BeEquivalentTo(10))
+		})
+
+		It("updates the running container with the new resources", func() {
+			Expect(containerizer.UpdateLimits(logger, "some-handle", limits)).To(Succeed())
+
+			Expect(fakeOCIRuntime.UpdateCallCount()).To(Equal(1))
+			_, id, resources := fakeOCIRuntime.UpdateArgsForCall(0)
+			Expect(id).To(Equal("some-handle"))
+			Expect(*resources.Memory.Limit).To(BeEquivalentTo(2048))
+			Expect(*resources.CPU.Shares).To(BeEquivalentTo(10))
+		})
+
+		It("saves the updated bundle", func() {
+			Expect(containerizer.UpdateLimits(logger, "some-handle", limits)).To(Succeed())
+
+			Expect(fakeBundleSaver.SaveCallCount()).To(Equal(1))
+			bundle, bundlePath := fakeBundleSaver.SaveArgsForCall(0)
+			Expect(bundle).To(Equal(limitedBundle))
+			Expect(bundlePath).To(Equal("/path/to/some-handle"))
+		})
+
+		Context("when looking up the container fails", func() {
+			BeforeEach(func() {
+				fakeDepot.LookupReturns("", errors.New("lookup-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.UpdateLimits(logger, "some-handle", limits)).To(MatchError("lookup-failed"))
+				Expect(fakeOCIRuntime.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when loading the bundle fails", func() {
+			BeforeEach(func() {
+				fakeBundleLoader.LoadReturns(goci.Bndl{}, errors.New("load-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.UpdateLimits(logger, "some-handle", limits)).To(MatchError("load-failed"))
+				Expect(fakeOCIRuntime.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when applying the limits fails", func() {
+			BeforeEach(func() {
+				fakeLimitsRule.ApplyReturns(goci.Bndl{}, errors.New("apply-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.UpdateLimits(logger, "some-handle", limits)).To(MatchError("apply-failed"))
+				Expect(fakeOCIRuntime.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the runtime update fails", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.UpdateReturns(errors.New("update-failed"))
+			})
+
+			It("returns the error and does not save the bundle", func() {
+				Expect(containerizer.UpdateLimits(logger, "some-handle", limits)).To(MatchError("update-failed"))
+				Expect(fakeBundleSaver.SaveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when saving the bundle fails", func() {
+			BeforeEach(func() {
+				fakeBundleSaver.SaveReturns(errors.New("save-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.UpdateLimits(logger, "some-handle", limits)).To(MatchError("save-failed"))
+			})
+		})
+	})
+
 	Describe("Info", func() {
 		var namespaces []specs.LinuxNamespace = []specs.LinuxNamespace{}
 
@@ -481,6 +666,14 @@ var _ = Describe("Rundmc", func() {
 				_, err := containerizer.Info(logger, "some-handle")
 				Expect(err).To(MatchError("spiderman-error"))
 			})
+
+			Context("because the container is not in the depot", func() {
+				It("should return a ContainerNotFoundError", func() {
+					fakeDepot.LookupReturns("", depot.ErrDoesNotExist)
+					_, err := containerizer.Info(logger, "some-handle")
+					Expect(err).To(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+				})
+			})
 		})
 
 		Context("when loading the bundle fails", func() {
@@ -513,6 +706,18 @@ var _ = Describe("Rundmc", func() {
 			Expect(actualSpec.Stopped).To(Equal(true))
 		})
 
+		It("should return whether the container is paused", func() {
+			actualSpec, err := containerizer.Info(logger, "some-handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualSpec.Paused).To(BeFalse())
+
+			fakeOCIRuntime.StateReturns(runrunc.State{Pid: 42, Status: runrunc.PausedStatus}, nil)
+
+			actualSpec, err = containerizer.Info(logger, "some-handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualSpec.Paused).To(BeTrue())
+		})
+
 		It("should return the ActualContainerSpec with privileged by default", func() {
 			actualSpec, err := containerizer.Info(logger, "some-handle")
 			Expect(err).NotTo(HaveOccurred())
@@ -534,12 +739,59 @@ var _ = Describe("Rundmc", func() {
 		Context("when retrieving the State from the runtime errors", func() {
 			BeforeEach(func() {
 				fakeOCIRuntime.StateReturns(runrunc.State{}, errors.New("error-fetching-state"))
+				fakeOCIRuntime.ListReturns([]string{"some-handle"}, nil)
 			})
 
 			It("returns the error", func() {
 				_, err := containerizer.Info(logger, "some-handle")
 				Expect(err).To(MatchError("error-fetching-state"))
 			})
+
+			Context("and the runtime does not list the container", func() {
+				It("returns a ContainerNotFoundError", func() {
+					fakeOCIRuntime.ListReturns([]string{"other-handle"}, nil)
+					_, err := containerizer.Info(logger, "some-handle")
+					Expect(err).To(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+				})
+			})
+
+			Context("and the runtime cannot list its containers", func() {
+				It("returns the error", func() {
+					fakeOCIRuntime.ListReturns(nil, errors.New("error-listing"))
+					_, err := containerizer.Info(logger, "some-handle")
+					Expect(err).To(MatchError("error-fetching-state"))
+				})
+			})
+		})
+	})
+
+	Describe("Pause", func() {
+		It("asks the runtime to pause the container", func() {
+			Expect(containerizer.Pause(logger, "some-handle")).To(Succeed())
+
+			Expect(fakeOCIRuntime.PauseCallCount()).To(Equal(1))
+			_, id := fakeOCIRuntime.PauseArgsForCall(0)
+			Expect(id).To(Equal("some-handle"))
+		})
+
+		It("returns the error when pausing fails", func() {
+			fakeOCIRuntime.PauseReturns(errors.New("runc pause: boom"))
+			Expect(containerizer.Pause(logger, "some-handle")).To(MatchError("runc pause: boom"))
+		})
+	})
+
+	Describe("Resume", func() {
+		It("asks the runtime to resume the container", func() {
+			Expect(containerizer.Resume(logger, "some-handle")).To(Succeed())
+
+			Expect(fakeOCIRuntime.ResumeCallCount()).To(Equal(1))
+			_, id := fakeOCIRuntime.ResumeArgsForCall(0)
+			Expect(id).To(Equal("some-handle"))
+		})
+
+		It("returns the error when resuming fails", func() {
+			fakeOCIRuntime.ResumeReturns(errors.New("runc resume: boom"))
+			Expect(containerizer.Resume(logger, "some-handle")).To(MatchError("runc resume: boom"))
 		})
 	})
 
@@ -593,8 +845,46 @@ var _ = Describe("Rundmc", func() {
 			})
 		})
 	})
-})
 
-func arg2(_ lager.Logger, i interface{}) interface{} {
-	return i
-}
+	Describe("Orphans", func() {
+		BeforeEach(func() {
+			fakeOCIRuntime.ListReturns([]string{"banana", "orphan", "banana2"}, nil)
+		})
+
+		It("returns the runtime containers which do not belong to any of the handles", func() {
+			Expect(containerizer.Orphans(logger, []string{"banana", "banana2", "apple"})).To(ConsistOf("orphan"))
+		})
+
+		Context("when listing the runtime containers fails", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.ListReturns(nil, errors.New("runc list failed"))
+			})
+
+			It("returns the error", func() {
+				_, err := containerizer.Orphans(logger, []string{})
+				Expect(err).To(MatchError("runc list failed"))
+			})
+		})
+	})
+
+	Describe("RemoveOrphan", func() {
+		It("force deletes the container", func() {
+			Expect(containerizer.RemoveOrphan(logger, "orphan")).To(Succeed())
+
+			Expect(fakeOCIRuntime.DeleteCallCount()).To(Equal(1))
+			_, force, id := fakeOCIRuntime.DeleteArgsForCall(0)
+			Expect(force).To(BeTrue())
+			Expect(id).To(Equal("orphan"))
+		})
+
+		Context("when deleting fails", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.DeleteReturns(errors.New("delete failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.RemoveOrphan(logger, "orphan")).To(MatchError("delete failed"))
+			})
+		})
+	})
+})
@@ -17,6 +17,7 @@ import (
 
 	"code.cloudfoundry.org/commandrunner"
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
 	"code.cloudfoundry.org/lager"
 	"github.com/kr/logfmt"
@@ -35,11 +36,12 @@ type ExecRunner struct {
 	pidGetter                PidGetter
 	commandRunner            commandrunner.CommandRunner
 	cleanupProcessDirsOnWait bool
+	events                   gardener.EventPublisher
 	processes                map[string]*process
 	processesMutex           *sync.Mutex
 }
 
-func NewExecRunner(dadooPath, runcPath, runcRoot string, processIDGen runrunc.UidGenerator, pidGetter PidGetter, commandRunner commandrunner.CommandRunner, shouldCleanup bool) *ExecRunner {
+func NewExecRunner(dadooPath, runcPath, runcRoot string, processIDGen runrunc.UidGenerator, pidGetter PidGetter, commandRunner commandrunner.CommandRunner, shouldCleanup bool, events gardener.EventPublisher) *ExecRunner {
 	return &ExecRunner{
 		dadooPath:                dadooPath,
 		runcPath:                 runcPath,
@@ -48,6 +50,7 @@ func NewExecRunner(dadooPath, runcPath, runcRoot string, processIDGen runrunc.Ui
 		pidGetter:                pidGetter,
 		commandRunner:            commandRunner,
 		cleanupProcessDirsOnWait: shouldCleanup,
+		events:                   events,
 		processes:                map[string]*process{},
 		processesMutex:           new(sync.Mutex),
 	}
@@ -91,7 +94,7 @@ func (d *ExecRunner) Run(log lager.Logger, processID string, spec *runrunc.Prepa
 	defer logr.Close()
 	defer syncr.Close()
 
-	process := d.getProcess(log, processID, processPath, filepath.Join(processPath, "pidfile"))
+	process := d.getProcess(log, handle, processID, processPath, filepath.Join(processPath, "pidfile"))
 	process.mkfifos(spec.HostUID, spec.HostGID)
 	if err != nil {
 		return nil, err
@@ -131,6 +134,13 @@ func (d *ExecRunner) Run(log lager.Logger, processID string, spec *runrunc.Prepa
 	go func() {
 		// wait on spawned process to avoid zombies
 		d.commandRunner.Wait(cmd)
+
+		// dadoo has written the exit code by the time it exits, so report it
+		// even if no client ever waits on the process
+		if code, err := process.readExitCode(); err == nil {
+			process.exited(code)
+		}
+
 		if copyErr := copyDadooLogsToGuardianLogger(dadooLogFilePath, log); copyErr != nil {
 			log.Error("reading-dadoo-log-file", copyErr)
 		}
@@ -190,7 +200,9 @@ func (d *ExecRunner) Run(log lager.Logger, processID string, spec *runrunc.Prepa
 
 func (d *ExecRunner) Attach(log lager.Logger, processID string, io garden.ProcessIO, processesPath string) (garden.Process, error) {
 	processPath := filepath.Join(processesPath, processID)
-	process := d.getProcess(log, processID, processPath, filepath.Join(processPath, "pidfile"))
+	// processes live in the bundle of their container, which is named after its handle
+	handle := filepath.Base(filepath.Dir(processesPath))
+	process := d.getProcess(log, handle, processID, processPath, filepath.Join(processPath, "pidfile"))
 	if err := process.attach(io); err != nil {
 		return nil, err
 	}
@@ -211,6 +223,7 @@ func (s osSignal) OsSignal() syscall.Signal {
 
 type process struct {
 	logger                                       lager.Logger
+	handle                                       string
 	id                                           string
 	stdin, stdout, stderr, exit, winsz, exitcode string
 	ioWg                                         *sync.WaitGroup
@@ -219,11 +232,13 @@ type process struct {
 	stdoutWriter                                 *DynamicMultiWriter
 	stderrWriter                                 *DynamicMultiWriter
 	streamMutex                                  *sync.Mutex
+	events                                       gardener.EventPublisher
+	exitedOnce                                   *sync.Once
 
 	*signaller
 }
 
-func (d *ExecRunner) getProcess(log lager.Logger, id, processPath, pidFilePath string) *process {
+func (d *ExecRunner) getProcess(log lager.Logger, handle, id, processPath, pidFilePath string) *process {
 	d.processesMutex.Lock()
 	defer d.processesMutex.Unlock()
 
@@ -243,6 +258,7 @@ func (d *ExecRunner) getProcess(log lager.Logger, id, processPath, pidFilePath s
 
 	d.processes[processPath] = &process{
 		logger:   log,
+		handle:   handle,
 		id:       id,
 		stdin:    filepath.Join(processPath, "stdin"),
 		stdout:   filepath.Join(processPath, "stdout"),
@@ -260,6 +276,8 @@ func (d *ExecRunner) getProcess(log lager.Logger, id, processPath, pidFilePath s
 		stdoutWriter: NewDynamicMultiWriter(),
 		stderrWriter: NewDynamicMultiWriter(),
 		streamMutex:  new(sync.Mutex),
+		events:       d.events,
+		exitedOnce:   new(sync.Once),
 	}
 	return d.processes[processPath]
 }
@@ -369,6 +387,21 @@ func (p process) Wait() (int, error) {
 
 	p.ioWg.Wait()
 
+	code, err := p.readExitCode()
+	if err != nil {
+		return 1, err
+	}
+
+	p.exited(code)
+
+	if err := p.cleanup(); err != nil {
+		p.logger.Error("process-cleanup", err)
+	}
+
+	return code, nil
+}
+
+func (p process) readExitCode() (int, error) {
 	if _, err := os.Stat(p.exitcode); os.IsNotExist(err) {
 		return 1, fmt.Errorf("could not find the exitcode file for the process: %s", err.Error())
 	}
@@ -387,13 +420,22 @@ func (p process) Wait() (int, error) {
 		return 1, fmt.Errorf("failed to parse exit code: %s", err.Error())
 	}
 
-	if err := p.cleanup(); err != nil {
-		p.logger.Error("process-cleanup", err)
-	}
-
 	return code, nil
 }
 
+// exited publishes the exit of the process, at most once however many times
+// its exit is observed
+func (p process) exited(code int) {
+	p.exitedOnce.Do(func() {
+		p.events.Publish(gardener.Event{
+			Kind:       gardener.ProcessExitedEvent,
+			Handle:     p.handle,
+			ProcessID:  p.id,
+			ExitStatus: &code,
+		})
+	})
+}
+
 func (p process) SetTTY(spec garden.TTYSpec) error {
 	if spec.WindowSize == nil {
 		return nil
@@ -17,6 +17,8 @@ import (
 
 	"code.cloudfoundry.org/commandrunner/fake_command_runner"
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/gardener/gardenerfakes"
 	"code.cloudfoundry.org/guardian/rundmc/execrunner/dadoo"
 	"code.cloudfoundry.org/guardian/rundmc/execrunner/dadoo/dadoofakes"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
@@ -35,6 +37,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 		fakeCommandRunner                      *fake_command_runner.FakeCommandRunner
 		fakeProcessIDGenerator                 *fakes.FakeUidGenerator
 		fakePidGetter                          *dadoofakes.FakePidGetter
+		fakeEventPublisher                     *gardenerfakes.FakeEventPublisher
 		runner                                 *dadoo.ExecRunner
 		bundlePath                             string
 		processPath                            string
@@ -57,6 +60,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 		fakeCommandRunner = fake_command_runner.New()
 		fakeProcessIDGenerator = new(fakes.FakeUidGenerator)
 		fakePidGetter = new(dadoofakes.FakePidGetter)
+		fakeEventPublisher = new(gardenerfakes.FakeEventPublisher)
 
 		processID = fmt.Sprintf("pid-%d", GinkgoParallelNode())
 		fakePidGetter.PidReturns(0, nil)
@@ -67,7 +71,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 		processPath = filepath.Join(bundlePath, "the-process")
 		pidPath = filepath.Join(processPath, "0.pid")
 
-		runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, false)
+		runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, false, fakeEventPublisher)
 		log = lagertest.NewTestLogger("test")
 
 		runcReturns = 0
@@ -287,7 +291,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 
 		Context("when cleanupProcessDirsOnWait is true", func() {
 			BeforeEach(func() {
-				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, true)
+				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, true, fakeEventPublisher)
 			})
 
 			It("cleans up the processes dir after Wait returns", func() {
@@ -305,7 +309,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 
 		Context("when cleanupProcessDirsOnWait is false", func() {
 			BeforeEach(func() {
-				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, false)
+				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, false, fakeEventPublisher)
 			})
 
 			It("does not clean up the processes dir after Wait returns", func() {
@@ -634,6 +638,23 @@ var _ = Describe("Dadoo ExecRunner", func() {
 					Expect(process.Wait()).To(Equal(42))
 				})
 
+				It("publishes the exit of the process once", func() {
+					dadooWritesExitCode = []byte("42")
+
+					process, err := runner.Run(log, processID, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(process.Wait()).To(Equal(42))
+					Eventually(fakeEventPublisher.PublishCallCount).Should(Equal(1))
+					Consistently(fakeEventPublisher.PublishCallCount).Should(Equal(1))
+
+					event := fakeEventPublisher.PublishArgsForCall(0)
+					Expect(event.Kind).To(Equal(gardener.ProcessExitedEvent))
+					Expect(event.Handle).To(Equal("some-handle"))
+					Expect(event.ProcessID).To(Equal(processID))
+					Expect(*event.ExitStatus).To(Equal(42))
+				})
+
 				Context("when the exitfile is empty", func() {
 					It("returns an error", func() {
 						dadooWritesExitCode = []byte("")
@@ -730,7 +751,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 
 			Context("when cleanupProcessDirsOnWait is true", func() {
 				JustBeforeEach(func() {
-					runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, true)
+					runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, true, fakeEventPublisher)
 				})
 
 				It("cleans up the map entry and the process path", func() {
@@ -802,7 +823,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 
 		Context("when cleanupProcessDirsOnWait is true", func() {
 			BeforeEach(func() {
-				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, true)
+				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, true, fakeEventPublisher)
 			})
 
 			It("cleans up the processes dir after Wait returns", func() {
@@ -821,7 +842,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 
 		Context("when cleanupProcessDirsOnWait is false", func() {
 			BeforeEach(func() {
-				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, false)
+				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", "runc-root", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, false, fakeEventPublisher)
 			})
 
 			It("does not clean up the processes dir after Wait returns", func() {
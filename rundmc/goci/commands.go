@@ -45,14 +45,34 @@ func StatsCommand(id, logFile string) *exec.Cmd {
 }
 
 // DeleteCommand creates a command that deletes a container using the default runc binary name.
-func DeleteCommand(id, logFile string) *exec.Cmd {
-	return DefaultRuncBinary.DeleteCommand(id, logFile)
+func DeleteCommand(id string, force bool, logFile string) *exec.Cmd {
+	return DefaultRuncBinary.DeleteCommand(id, force, logFile)
+}
+
+// ListCommand creates a command that lists all containers using the default runc binary name.
+func ListCommand(logFile string) *exec.Cmd {
+	return DefaultRuncBinary.ListCommand(logFile)
 }
 
 func EventsCommand(id string) *exec.Cmd {
 	return DefaultRuncBinary.EventsCommand(id)
 }
 
+// UpdateCommand creates a command that updates the resources of a container using the default runc binary name.
+func UpdateCommand(id, logFile string) *exec.Cmd {
+	return DefaultRuncBinary.UpdateCommand(id, logFile)
+}
+
+// PauseCommand creates a command that freezes a container using the default runc binary name.
+func PauseCommand(id, logFile string) *exec.Cmd {
+	return DefaultRuncBinary.PauseCommand(id, logFile)
+}
+
+// ResumeCommand creates a command that thaws a container using the default runc binary name.
+func ResumeCommand(id, logFile string) *exec.Cmd {
+	return DefaultRuncBinary.ResumeCommand(id, logFile)
+}
+
 // StartCommand returns an *exec.Cmd that, when run, will execute a given bundle.
 func (runc RuncBinary) StartCommand(path, id string, detach bool, log string) *exec.Cmd {
 	args := []string{"--debug", "--log", log, "start"}
@@ -101,7 +121,36 @@ func (runc RuncBinary) StatsCommand(id, logFile string) *exec.Cmd {
 }
 
 // DeleteCommand returns an *exec.Cmd that, when run, will signal the running
-// container.
-func (runc RuncBinary) DeleteCommand(id, logFile string) *exec.Cmd {
-	return exec.Command(runc.Path, runc.args("--debug", "--log", logFile, "delete", id)...)
+// container. Forcing the delete kills the container first if it is running.
+func (runc RuncBinary) DeleteCommand(id string, force bool, logFile string) *exec.Cmd {
+	args := []string{"--debug", "--log", logFile, "delete"}
+	if force {
+		args = append(args, "--force")
+	}
+
+	return exec.Command(runc.Path, runc.args(append(args, id)...)...)
+}
+
+// ListCommand returns an *exec.Cmd that, when run, will list all of the
+// containers known to runc as JSON.
+func (runc RuncBinary) ListCommand(logFile string) *exec.Cmd {
+	return exec.Command(runc.Path, runc.args("--debug", "--log", logFile, "list", "--format", "json")...)
+}
+
+// UpdateCommand returns an *exec.Cmd that, when run, will update the resource
+// limits of the container from a resources JSON document read on stdin.
+func (runc RuncBinary) UpdateCommand(id, logFile string) *exec.Cmd {
+	return exec.Command(runc.Path, runc.args("--debug", "--log", logFile, "update", "-r", "-", id)...)
+}
+
+// PauseCommand returns an *exec.Cmd that, when run, will freeze all of the
+// processes in the container.
+func (runc RuncBinary) PauseCommand(id, logFile string) *exec.Cmd {
+	return exec.Command(runc.Path, runc.args("--debug", "--log", logFile, "pause", id)...)
+}
+
+// ResumeCommand returns an *exec.Cmd that, when run, will thaw all of the
+// processes in a paused container.
+func (runc RuncBinary) ResumeCommand(id, logFile string) *exec.Cmd {
+	return exec.Command(runc.Path, runc.args("--debug", "--log", logFile, "resume", id)...)
 }
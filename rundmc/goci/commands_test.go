@@ -69,9 +69,44 @@ var _ = Describe("Commands", func() {
 
 	Describe("DeleteCommand", func() {
 		It("creates an *exec.Cmd to delete the bundle", func() {
-			cmd := goci.DeleteCommand("my-bundle-id", "log.file")
+			cmd := goci.DeleteCommand("my-bundle-id", false, "log.file")
 			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "delete", "my-bundle-id"}))
 		})
+
+		Context("when the delete is forced", func() {
+			It("passes --force to runc", func() {
+				cmd := goci.DeleteCommand("my-bundle-id", true, "log.file")
+				Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "delete", "--force", "my-bundle-id"}))
+			})
+		})
+	})
+
+	Describe("ListCommand", func() {
+		It("creates an *exec.Cmd to list all containers as JSON", func() {
+			cmd := goci.ListCommand("log.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "list", "--format", "json"}))
+		})
+	})
+
+	Describe("UpdateCommand", func() {
+		It("creates an *exec.Cmd to update the resources of the bundle from stdin", func() {
+			cmd := goci.UpdateCommand("my-bundle-id", "log.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "update", "-r", "-", "my-bundle-id"}))
+		})
+	})
+
+	Describe("PauseCommand", func() {
+		It("creates an *exec.Cmd to pause the bundle", func() {
+			cmd := goci.PauseCommand("my-bundle-id", "log.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "pause", "my-bundle-id"}))
+		})
+	})
+
+	Describe("ResumeCommand", func() {
+		It("creates an *exec.Cmd to resume the bundle", func() {
+			cmd := goci.ResumeCommand("my-bundle-id", "log.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "resume", "my-bundle-id"}))
+		})
 	})
 
 	Context("when a runc root is passed", func() {
@@ -104,7 +139,19 @@ var _ = Describe("Commands", func() {
 				return goci.StatsCommand("", "")
 			}),
 			Entry("DeleteCommand", func() *exec.Cmd {
-				return goci.DeleteCommand("", "")
+				return goci.DeleteCommand("", false, "")
+			}),
+			Entry("ListCommand", func() *exec.Cmd {
+				return goci.ListCommand("")
+			}),
+			Entry("UpdateCommand", func() *exec.Cmd {
+				return goci.UpdateCommand("", "")
+			}),
+			Entry("PauseCommand", func() *exec.Cmd {
+				return goci.PauseCommand("", "")
+			}),
+			Entry("ResumeCommand", func() *exec.Cmd {
+				return goci.ResumeCommand("", "")
 			}),
 		)
 	})
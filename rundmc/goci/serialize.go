@@ -28,7 +28,7 @@ func (b BundleSaver) Save(bundle Bndl, path string) error {
 }
 
 func save(value interface{}, path string) error {
-	w, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0600)
+	w, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	defer w.Close()
 	if err != nil {
 		return fmt.Errorf("Failed to save bundle: %s", err)
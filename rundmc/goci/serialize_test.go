@@ -65,6 +65,23 @@ var _ = Describe("Bundle Serialization", func() {
 			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
 		})
 
+		Context("when the bundle is saved again", func() {
+			It("replaces the previous contents of config.json", func() {
+				bndle.Spec.Hostname = "a-rather-long-hostname-that-makes-the-first-save-longer"
+				Expect(bundleSaver.Save(bndle, tmp)).To(Succeed())
+
+				Expect(bundleSaver.Save(goci.Bndl{Spec: specs.Spec{Version: "efgh"}}, tmp)).To(Succeed())
+
+				contents, err := ioutil.ReadFile(filepath.Join(tmp, "config.json"))
+				Expect(err).NotTo(HaveOccurred())
+
+				var configJson map[string]interface{}
+				Expect(json.Unmarshal(contents, &configJson)).To(Succeed())
+				Expect(configJson).To(HaveKeyWithValue("ociVersion", Equal("efgh")))
+				Expect(configJson).NotTo(HaveKey("hostname"))
+			})
+		})
+
 		Context("when saving fails", func() {
 			It("returns an error", func() {
 				err := bundleSaver.Save(bndle, "non-existent-dir")
@@ -0,0 +1,66 @@
+package rundmc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// ProcessDirs finds the process directories left behind when running a
+// process failed before runc started it. These never get a pidfile, so waiting
+// on the process will never clean them up. Orphans are identified as
+// <handle>/<process id>.
+type ProcessDirs struct {
+	depot Depot
+}
+
+func NewProcessDirs(depot Depot) *ProcessDirs {
+	return &ProcessDirs{
+		depot: depot,
+	}
+}
+
+func (p *ProcessDirs) Orphans(log lager.Logger, handles []string) ([]string, error) {
+	orphans := []string{}
+	for _, handle := range handles {
+		bundlePath, err := p.depot.Lookup(log, handle)
+		if err != nil {
+			continue
+		}
+
+		processesPath := filepath.Join(bundlePath, "processes")
+		processDirs, err := ioutil.ReadDir(processesPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dir := range processDirs {
+			if _, err := os.Stat(filepath.Join(processesPath, dir.Name(), "pidfile")); os.IsNotExist(err) {
+				orphans = append(orphans, handle+"/"+dir.Name())
+			}
+		}
+	}
+
+	return orphans, nil
+}
+
+func (p *ProcessDirs) RemoveOrphan(log lager.Logger, id string) error {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid process dir id: %s", id)
+	}
+
+	bundlePath, err := p.depot.Lookup(log, parts[0])
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(filepath.Join(bundlePath, "processes", parts[1]))
+}
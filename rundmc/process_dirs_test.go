@@ -0,0 +1,94 @@
+package rundmc_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/guardian/rundmc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/rundmcfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcessDirs", func() {
+	var (
+		fakeDepot   *fakes.FakeDepot
+		depotDir    string
+		processDirs *rundmc.ProcessDirs
+		logger      lager.Logger
+	)
+
+	createProcessDir := func(handle, processID string, started bool) {
+		processPath := filepath.Join(depotDir, handle, "processes", processID)
+		Expect(os.MkdirAll(processPath, 0700)).To(Succeed())
+
+		if started {
+			Expect(ioutil.WriteFile(filepath.Join(processPath, "pidfile"), []byte("123"), 0600)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		depotDir, err = ioutil.TempDir("", "process-dirs")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeDepot = new(fakes.FakeDepot)
+		fakeDepot.LookupStub = func(_ lager.Logger, handle string) (string, error) {
+			if _, err := os.Stat(filepath.Join(depotDir, handle)); err != nil {
+				return "", errors.New("does not exist")
+			}
+
+			return filepath.Join(depotDir, handle), nil
+		}
+
+		processDirs = rundmc.NewProcessDirs(fakeDepot)
+		logger = lagertest.NewTestLogger("test")
+
+		createProcessDir("handle1", "started", true)
+		createProcessDir("handle1", "never-started", false)
+		createProcessDir("handle2", "also-never-started", false)
+		Expect(os.MkdirAll(filepath.Join(depotDir, "handle3"), 0755)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(depotDir)).To(Succeed())
+	})
+
+	Describe("Orphans", func() {
+		It("returns the process dirs without a pidfile", func() {
+			Expect(processDirs.Orphans(logger, []string{"handle1", "handle2", "handle3", "not-in-depot"})).To(ConsistOf(
+				"handle1/never-started",
+				"handle2/also-never-started",
+			))
+		})
+
+		It("only looks at the given containers", func() {
+			Expect(processDirs.Orphans(logger, []string{"handle2"})).To(ConsistOf("handle2/also-never-started"))
+		})
+	})
+
+	Describe("RemoveOrphan", func() {
+		It("removes the process dir", func() {
+			Expect(processDirs.RemoveOrphan(logger, "handle1/never-started")).To(Succeed())
+
+			Expect(filepath.Join(depotDir, "handle1", "processes", "never-started")).NotTo(BeAnExistingFile())
+			Expect(filepath.Join(depotDir, "handle1", "processes", "started")).To(BeADirectory())
+		})
+
+		Context("when the id is not a process dir id", func() {
+			It("returns an error", func() {
+				Expect(processDirs.RemoveOrphan(logger, "handle1")).To(MatchError("invalid process dir id: handle1"))
+			})
+		})
+
+		Context("when the container is not in the depot", func() {
+			It("returns an error", func() {
+				Expect(processDirs.RemoveOrphan(logger, "not-in-depot/process")).To(MatchError("does not exist"))
+			})
+		})
+	})
+})
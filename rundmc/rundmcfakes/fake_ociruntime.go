@@ -2,6 +2,7 @@
 package rundmcfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/garden"
@@ -9,12 +10,14 @@ import (
 	"code.cloudfoundry.org/guardian/rundmc"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
 	"code.cloudfoundry.org/lager"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 type FakeOCIRuntime struct {
-	CreateStub        func(log lager.Logger, bundlePath, id string, io garden.ProcessIO) error
+	CreateStub        func(ctx context.Context, log lager.Logger, bundlePath, id string, io garden.ProcessIO) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
+		ctx        context.Context
 		log        lager.Logger
 		bundlePath string
 		id         string
@@ -72,10 +75,11 @@ type FakeOCIRuntime struct {
 	killReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteStub        func(log lager.Logger, bundlePath string) error
+	DeleteStub        func(log lager.Logger, force bool, bundlePath string) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
 		log        lager.Logger
+		force      bool
 		bundlePath string
 	}
 	deleteReturns struct {
@@ -125,23 +129,74 @@ type FakeOCIRuntime struct {
 	watchEventsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateStub        func(log lager.Logger, id string, resources specs.LinuxResources) error
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		log       lager.Logger
+		id        string
+		resources specs.LinuxResources
+	}
+	updateReturns struct {
+		result1 error
+	}
+	updateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListStub        func(log lager.Logger) ([]string, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		log lager.Logger
+	}
+	listReturns struct {
+		result1 []string
+		result2 error
+	}
+	listReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	PauseStub        func(log lager.Logger, id string) error
+	pauseMutex       sync.RWMutex
+	pauseArgsForCall []struct {
+		log lager.Logger
+		id  string
+	}
+	pauseReturns struct {
+		result1 error
+	}
+	pauseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResumeStub        func(log lager.Logger, id string) error
+	resumeMutex       sync.RWMutex
+	resumeArgsForCall []struct {
+		log lager.Logger
+		id  string
+	}
+	resumeReturns struct {
+		result1 error
+	}
+	resumeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeOCIRuntime) Create(log lager.Logger, bundlePath string, id string, io garden.ProcessIO) error {
+func (fake *FakeOCIRuntime) Create(ctx context.Context, log lager.Logger, bundlePath string, id string, io garden.ProcessIO) error {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		ctx        context.Context
 		log        lager.Logger
 		bundlePath string
 		id         string
 		io         garden.ProcessIO
-	}{log, bundlePath, id, io})
-	fake.recordInvocation("Create", []interface{}{log, bundlePath, id, io})
+	}{ctx, log, bundlePath, id, io})
+	fake.recordInvocation("Create", []interface{}{ctx, log, bundlePath, id, io})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(log, bundlePath, id, io)
+		return fake.CreateStub(ctx, log, bundlePath, id, io)
 	}
 	if specificReturn {
 		return ret.result1
@@ -155,10 +210,10 @@ func (fake *FakeOCIRuntime) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeOCIRuntime) CreateArgsForCall(i int) (lager.Logger, string, string, garden.ProcessIO) {
+func (fake *FakeOCIRuntime) CreateArgsForCall(i int) (context.Context, lager.Logger, string, string, garden.ProcessIO) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].log, fake.createArgsForCall[i].bundlePath, fake.createArgsForCall[i].id, fake.createArgsForCall[i].io
+	return fake.createArgsForCall[i].ctx, fake.createArgsForCall[i].log, fake.createArgsForCall[i].bundlePath, fake.createArgsForCall[i].id, fake.createArgsForCall[i].io
 }
 
 func (fake *FakeOCIRuntime) CreateReturns(result1 error) {
@@ -339,17 +394,18 @@ func (fake *FakeOCIRuntime) KillReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeOCIRuntime) Delete(log lager.Logger, bundlePath string) error {
+func (fake *FakeOCIRuntime) Delete(log lager.Logger, force bool, bundlePath string) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
 		log        lager.Logger
+		force      bool
 		bundlePath string
-	}{log, bundlePath})
-	fake.recordInvocation("Delete", []interface{}{log, bundlePath})
+	}{log, force, bundlePath})
+	fake.recordInvocation("Delete", []interface{}{log, force, bundlePath})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(log, bundlePath)
+		return fake.DeleteStub(log, force, bundlePath)
 	}
 	if specificReturn {
 		return ret.result1
@@ -363,10 +419,10 @@ func (fake *FakeOCIRuntime) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakeOCIRuntime) DeleteArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeOCIRuntime) DeleteArgsForCall(i int) (lager.Logger, bool, string) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].log, fake.deleteArgsForCall[i].bundlePath
+	return fake.deleteArgsForCall[i].log, fake.deleteArgsForCall[i].force, fake.deleteArgsForCall[i].bundlePath
 }
 
 func (fake *FakeOCIRuntime) DeleteReturns(result1 error) {
@@ -542,6 +598,205 @@ func (fake *FakeOCIRuntime) WatchEventsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeOCIRuntime) Update(log lager.Logger, id string, resources specs.LinuxResources) error {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		log       lager.Logger
+		id        string
+		resources specs.LinuxResources
+	}{log, id, resources})
+	fake.recordInvocation("Update", []interface{}{log, id, resources})
+	fake.updateMutex.Unlock()
+	if fake.UpdateStub != nil {
+		return fake.UpdateStub(log, id, resources)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateReturns.result1
+}
+
+func (fake *FakeOCIRuntime) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *FakeOCIRuntime) UpdateArgsForCall(i int) (lager.Logger, string, specs.LinuxResources) {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return fake.updateArgsForCall[i].log, fake.updateArgsForCall[i].id, fake.updateArgsForCall[i].resources
+}
+
+func (fake *FakeOCIRuntime) UpdateReturns(result1 error) {
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOCIRuntime) UpdateReturnsOnCall(i int, result1 error) {
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOCIRuntime) List(log lager.Logger) ([]string, error) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		log lager.Logger
+	}{log})
+	fake.recordInvocation("List", []interface{}{log})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub(log)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.listReturns.result1, fake.listReturns.result2
+}
+
+func (fake *FakeOCIRuntime) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeOCIRuntime) ListArgsForCall(i int) lager.Logger {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return fake.listArgsForCall[i].log
+}
+
+func (fake *FakeOCIRuntime) ListReturns(result1 []string, result2 error) {
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOCIRuntime) ListReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOCIRuntime) Pause(log lager.Logger, id string) error {
+	fake.pauseMutex.Lock()
+	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+		log lager.Logger
+		id  string
+	}{log, id})
+	fake.recordInvocation("Pause", []interface{}{log, id})
+	fake.pauseMutex.Unlock()
+	if fake.PauseStub != nil {
+		return fake.PauseStub(log, id)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pauseReturns.result1
+}
+
+func (fake *FakeOCIRuntime) PauseCallCount() int {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return len(fake.pauseArgsForCall)
+}
+
+func (fake *FakeOCIRuntime) PauseArgsForCall(i int) (lager.Logger, string) {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return fake.pauseArgsForCall[i].log, fake.pauseArgsForCall[i].id
+}
+
+func (fake *FakeOCIRuntime) PauseReturns(result1 error) {
+	fake.PauseStub = nil
+	fake.pauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOCIRuntime) PauseReturnsOnCall(i int, result1 error) {
+	fake.PauseStub = nil
+	if fake.pauseReturnsOnCall == nil {
+		fake.pauseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOCIRuntime) Resume(log lager.Logger, id string) error {
+	fake.resumeMutex.Lock()
+	ret, specificReturn := fake.resumeReturnsOnCall[len(fake.resumeArgsForCall)]
+	fake.resumeArgsForCall = append(fake.resumeArgsForCall, struct {
+		log lager.Logger
+		id  string
+	}{log, id})
+	fake.recordInvocation("Resume", []interface{}{log, id})
+	fake.resumeMutex.Unlock()
+	if fake.ResumeStub != nil {
+		return fake.ResumeStub(log, id)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.resumeReturns.result1
+}
+
+func (fake *FakeOCIRuntime) ResumeCallCount() int {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return len(fake.resumeArgsForCall)
+}
+
+func (fake *FakeOCIRuntime) ResumeArgsForCall(i int) (lager.Logger, string) {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return fake.resumeArgsForCall[i].log, fake.resumeArgsForCall[i].id
+}
+
+func (fake *FakeOCIRuntime) ResumeReturns(result1 error) {
+	fake.ResumeStub = nil
+	fake.resumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOCIRuntime) ResumeReturnsOnCall(i int, result1 error) {
+	fake.ResumeStub = nil
+	if fake.resumeReturnsOnCall == nil {
+		fake.resumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeOCIRuntime) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -561,6 +816,14 @@ func (fake *FakeOCIRuntime) Invocations() map[string][][]interface{} {
 	defer fake.statsMutex.RUnlock()
 	fake.watchEventsMutex.RLock()
 	defer fake.watchEventsMutex.RUnlock()
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -1,6 +1,7 @@
 package runrunc
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -30,7 +31,7 @@ func NewCreator(runcPath, runcRoot, newuidmapPath, newgidmapPath string, command
 	}
 }
 
-func (c *Creator) Create(log lager.Logger, bundlePath, id string, _ garden.ProcessIO) (theErr error) {
+func (c *Creator) Create(ctx context.Context, log lager.Logger, bundlePath, id string, _ garden.ProcessIO) (theErr error) {
 	log = log.Session("create", lager.Data{"bundle": bundlePath})
 
 	defer log.Info("finished")
@@ -57,7 +58,7 @@ func (c *Creator) Create(log lager.Logger, bundlePath, id string, _ garden.Proce
 		globalArgs = append(globalArgs, []string{"--root", c.runcRoot}...)
 	}
 
-	cmd := exec.Command(c.runcPath, append(globalArgs, createArgs...)...)
+	cmd := exec.CommandContext(ctx, c.runcPath, append(globalArgs, createArgs...)...)
 
 	log.Info("creating", lager.Data{
 		"runc":        c.runcPath,
@@ -2,6 +2,7 @@ package runrunc_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -61,7 +62,7 @@ var _ = Describe("Create", func() {
 	})
 
 	It("creates the container with runC create", func() {
-		Expect(runner.Create(logger, bundlePath, "some-id", garden.ProcessIO{})).To(Succeed())
+		Expect(runner.Create(context.Background(), logger, bundlePath, "some-id", garden.ProcessIO{})).To(Succeed())
 
 		Expect(commandRunner.ExecutedCommands()[0].Path).To(Equal("funC"))
 		Expect(commandRunner.ExecutedCommands()[0].Args).To(ConsistOf(
@@ -84,7 +85,7 @@ var _ = Describe("Create", func() {
 		})
 
 		It("creates the container with runC create, passing --root", func() {
-			Expect(runner.Create(logger, bundlePath, "some-id", garden.ProcessIO{})).To(Succeed())
+			Expect(runner.Create(context.Background(), logger, bundlePath, "some-id", garden.ProcessIO{})).To(Succeed())
 
 			Expect(commandRunner.ExecutedCommands()[0].Path).To(Equal("funC"))
 			Expect(commandRunner.ExecutedCommands()[0].Args).To(ConsistOf(
@@ -110,7 +111,7 @@ var _ = Describe("Create", func() {
 		})
 
 		It("returns runc's exit status", func() {
-			Expect(runner.Create(logger, bundlePath, "some-id", garden.ProcessIO{})).To(MatchError("runc create: some-error: "))
+			Expect(runner.Create(context.Background(), logger, bundlePath, "some-id", garden.ProcessIO{})).To(MatchError("runc create: some-error: "))
 		})
 	})
 
@@ -123,7 +124,7 @@ var _ = Describe("Create", func() {
 		})
 
 		It("sends all the logs to the logger", func() {
-			Expect(runner.Create(logger, bundlePath, "some-id", garden.ProcessIO{})).To(Succeed())
+			Expect(runner.Create(context.Background(), logger, bundlePath, "some-id", garden.ProcessIO{})).To(Succeed())
 
 			runcLogs := make([]lager.LogFormat, 0)
 			for _, log := range logger.Logs() {
@@ -142,7 +143,7 @@ var _ = Describe("Create", func() {
 			})
 
 			It("return an error including parsed logs when runC fails to start the container", func() {
-				Expect(runner.Create(logger, bundlePath, "some-id", garden.ProcessIO{})).To(MatchError("runc create: boom: Container start failed: [10] System error: fork/exec POTATO: no such file or directory"))
+				Expect(runner.Create(context.Background(), logger, bundlePath, "some-id", garden.ProcessIO{})).To(MatchError("runc create: boom: Container start failed: [10] System error: fork/exec POTATO: no such file or directory"))
 			})
 
 			Context("when the log messages can't be parsed", func() {
@@ -152,7 +153,7 @@ var _ = Describe("Create", func() {
 				})
 
 				It("returns an error with only the exit status", func() {
-					Expect(runner.Create(logger, bundlePath, "some-id", garden.ProcessIO{})).To(MatchError("runc create: boom: "))
+					Expect(runner.Create(context.Background(), logger, bundlePath, "some-id", garden.ProcessIO{})).To(MatchError("runc create: boom: "))
 				})
 			})
 		})
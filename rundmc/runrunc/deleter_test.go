@@ -31,7 +31,11 @@ var _ = Describe("Delete", func() {
 
 		deleter = runrunc.NewDeleter(runner, runcBinary)
 
-		runcBinary.DeleteCommandStub = func(id, logFile string) *exec.Cmd {
+		runcBinary.DeleteCommandStub = func(id string, force bool, logFile string) *exec.Cmd {
+			if force {
+				return exec.Command("funC", "--log", logFile, "delete", "--force", id)
+			}
+
 			return exec.Command("funC", "--log", logFile, "delete", id)
 		}
 
@@ -41,11 +45,21 @@ var _ = Describe("Delete", func() {
 	})
 
 	It("runs 'runc delete' in the container directory using the logging runner", func() {
-		Expect(deleter.Delete(logger, "some-container")).To(Succeed())
+		Expect(deleter.Delete(logger, false, "some-container")).To(Succeed())
 		Expect(commandRunner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
 			Path: "funC",
 			Args: []string{"--log", "potato.log", "delete", "some-container"},
 		}))
 	})
 
+	Context("when the delete is forced", func() {
+		It("runs 'runc delete --force'", func() {
+			Expect(deleter.Delete(logger, true, "some-container")).To(Succeed())
+			Expect(commandRunner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+				Path: "funC",
+				Args: []string{"--log", "potato.log", "delete", "--force", "some-container"},
+			}))
+		})
+	})
+
 })
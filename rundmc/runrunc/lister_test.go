@@ -0,0 +1,90 @@
+package runrunc_test
+
+import (
+	"errors"
+	"os/exec"
+
+	"code.cloudfoundry.org/commandrunner/fake_command_runner"
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/runrunc/runruncfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("List", func() {
+	var (
+		commandRunner *fake_command_runner.FakeCommandRunner
+		runner        *fakes.FakeRuncCmdRunner
+		runcBinary    *fakes.FakeRuncBinary
+		logger        *lagertest.TestLogger
+
+		lister *runrunc.Lister
+	)
+
+	BeforeEach(func() {
+		runcBinary = new(fakes.FakeRuncBinary)
+		commandRunner = fake_command_runner.New()
+		runner = new(fakes.FakeRuncCmdRunner)
+		logger = lagertest.NewTestLogger("test")
+
+		lister = runrunc.NewLister(runner, runcBinary)
+
+		runcBinary.ListCommandStub = func(logFile string) *exec.Cmd {
+			return exec.Command("funC", "--log", logFile, "list", "--format", "json")
+		}
+
+		runner.RunAndLogStub = func(_ lager.Logger, fn runrunc.LoggingCmd) error {
+			return commandRunner.Run(fn("potato.log"))
+		}
+	})
+
+	It("returns the ids of the containers listed by runc", func() {
+		commandRunner.WhenRunning(fake_command_runner.CommandSpec{
+			Path: "funC",
+			Args: []string{"--log", "potato.log", "list", "--format", "json"},
+		}, func(cmd *exec.Cmd) error {
+			cmd.Stdout.Write([]byte(`[{"id":"some-container","status":"running"},{"id":"other-container","status":"stopped"}]`))
+			return nil
+		})
+
+		Expect(lister.List(logger)).To(Equal([]string{"some-container", "other-container"}))
+	})
+
+	Context("when there are no containers", func() {
+		It("returns an empty list", func() {
+			commandRunner.WhenRunning(fake_command_runner.CommandSpec{
+				Path: "funC",
+			}, func(cmd *exec.Cmd) error {
+				cmd.Stdout.Write([]byte("null"))
+				return nil
+			})
+
+			Expect(lister.List(logger)).To(BeEmpty())
+		})
+	})
+
+	Context("when running runc list fails", func() {
+		It("returns the error", func() {
+			runner.RunAndLogReturns(errors.New("boom"))
+
+			_, err := lister.List(logger)
+			Expect(err).To(MatchError("runc list: boom"))
+		})
+	})
+
+	Context("when the output is not valid JSON", func() {
+		It("returns an error", func() {
+			commandRunner.WhenRunning(fake_command_runner.CommandSpec{
+				Path: "funC",
+			}, func(cmd *exec.Cmd) error {
+				cmd.Stdout.Write([]byte("potato"))
+				return nil
+			})
+
+			_, err := lister.List(logger)
+			Expect(err).To(MatchError(ContainSubstring("runc list:")))
+		})
+	})
+})
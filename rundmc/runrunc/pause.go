@@ -0,0 +1,52 @@
+package runrunc
+
+import (
+	"fmt"
+	"os/exec"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type Pauser struct {
+	runner RuncCmdRunner
+	runc   RuncBinary
+}
+
+func NewPauser(runner RuncCmdRunner, runc RuncBinary) *Pauser {
+	return &Pauser{
+		runner: runner,
+		runc:   runc,
+	}
+}
+
+// Pause freezes all of the processes in the container using 'runc pause'
+func (p *Pauser) Pause(log lager.Logger, handle string) error {
+	log = log.Session("pause", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	if err := p.runner.RunAndLog(log, func(logFile string) *exec.Cmd {
+		return p.runc.PauseCommand(handle, logFile)
+	}); err != nil {
+		return fmt.Errorf("runc pause: %s", err)
+	}
+
+	return nil
+}
+
+// Resume thaws all of the processes in a paused container using 'runc resume'
+func (p *Pauser) Resume(log lager.Logger, handle string) error {
+	log = log.Session("resume", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	if err := p.runner.RunAndLog(log, func(logFile string) *exec.Cmd {
+		return p.runc.ResumeCommand(handle, logFile)
+	}); err != nil {
+		return fmt.Errorf("runc resume: %s", err)
+	}
+
+	return nil
+}
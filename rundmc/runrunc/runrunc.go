@@ -21,6 +21,9 @@ type RunRunc struct {
 	*Stater
 	*Killer
 	*Deleter
+	*Updater
+	*Lister
+	*Pauser
 }
 
 //go:generate counterfeiter . RuncBinary
@@ -30,7 +33,11 @@ type RuncBinary interface {
 	StateCommand(id, logFile string) *exec.Cmd
 	StatsCommand(id, logFile string) *exec.Cmd
 	KillCommand(id, signal, logFile string) *exec.Cmd
-	DeleteCommand(id, logFile string) *exec.Cmd
+	DeleteCommand(id string, force bool, logFile string) *exec.Cmd
+	UpdateCommand(id, logFile string) *exec.Cmd
+	ListCommand(logFile string) *exec.Cmd
+	PauseCommand(id, logFile string) *exec.Cmd
+	ResumeCommand(id, logFile string) *exec.Cmd
 }
 
 func New(runner commandrunner.CommandRunner, runcCmdRunner RuncCmdRunner, runc RuncBinary, dadooPath, runcPath, runcRoot, newuidmapPath, newgidmapPath string, execPreparer ExecPreparer, execRunner ExecRunner) *RunRunc {
@@ -43,5 +50,8 @@ func New(runner commandrunner.CommandRunner, runcCmdRunner RuncCmdRunner, runc R
 		Stater:     NewStater(runcCmdRunner, runc),
 		Killer:     NewKiller(runcCmdRunner, runc),
 		Deleter:    NewDeleter(runcCmdRunner, runc),
+		Updater:    NewUpdater(runcCmdRunner, runc),
+		Lister:     NewLister(runcCmdRunner, runc),
+		Pauser:     NewPauser(runcCmdRunner, runc),
 	}
 }
@@ -70,10 +70,11 @@ type FakeRuncBinary struct {
 	killCommandReturnsOnCall map[int]struct {
 		result1 *exec.Cmd
 	}
-	DeleteCommandStub        func(id, logFile string) *exec.Cmd
+	DeleteCommandStub        func(id string, force bool, logFile string) *exec.Cmd
 	deleteCommandMutex       sync.RWMutex
 	deleteCommandArgsForCall []struct {
 		id      string
+		force   bool
 		logFile string
 	}
 	deleteCommandReturns struct {
@@ -82,6 +83,53 @@ type FakeRuncBinary struct {
 	deleteCommandReturnsOnCall map[int]struct {
 		result1 *exec.Cmd
 	}
+	UpdateCommandStub        func(id, logFile string) *exec.Cmd
+	updateCommandMutex       sync.RWMutex
+	updateCommandArgsForCall []struct {
+		id      string
+		logFile string
+	}
+	updateCommandReturns struct {
+		result1 *exec.Cmd
+	}
+	updateCommandReturnsOnCall map[int]struct {
+		result1 *exec.Cmd
+	}
+	ListCommandStub        func(logFile string) *exec.Cmd
+	listCommandMutex       sync.RWMutex
+	listCommandArgsForCall []struct {
+		logFile string
+	}
+	listCommandReturns struct {
+		result1 *exec.Cmd
+	}
+	listCommandReturnsOnCall map[int]struct {
+		result1 *exec.Cmd
+	}
+	PauseCommandStub        func(id, logFile string) *exec.Cmd
+	pauseCommandMutex       sync.RWMutex
+	pauseCommandArgsForCall []struct {
+		id      string
+		logFile string
+	}
+	pauseCommandReturns struct {
+		result1 *exec.Cmd
+	}
+	pauseCommandReturnsOnCall map[int]struct {
+		result1 *exec.Cmd
+	}
+	ResumeCommandStub        func(id, logFile string) *exec.Cmd
+	resumeCommandMutex       sync.RWMutex
+	resumeCommandArgsForCall []struct {
+		id      string
+		logFile string
+	}
+	resumeCommandReturns struct {
+		result1 *exec.Cmd
+	}
+	resumeCommandReturnsOnCall map[int]struct {
+		result1 *exec.Cmd
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -332,17 +380,18 @@ func (fake *FakeRuncBinary) KillCommandReturnsOnCall(i int, result1 *exec.Cmd) {
 	}{result1}
 }
 
-func (fake *FakeRuncBinary) DeleteCommand(id string, logFile string) *exec.Cmd {
+func (fake *FakeRuncBinary) DeleteCommand(id string, force bool, logFile string) *exec.Cmd {
 	fake.deleteCommandMutex.Lock()
 	ret, specificReturn := fake.deleteCommandReturnsOnCall[len(fake.deleteCommandArgsForCall)]
 	fake.deleteCommandArgsForCall = append(fake.deleteCommandArgsForCall, struct {
 		id      string
+		force   bool
 		logFile string
-	}{id, logFile})
-	fake.recordInvocation("DeleteCommand", []interface{}{id, logFile})
+	}{id, force, logFile})
+	fake.recordInvocation("DeleteCommand", []interface{}{id, force, logFile})
 	fake.deleteCommandMutex.Unlock()
 	if fake.DeleteCommandStub != nil {
-		return fake.DeleteCommandStub(id, logFile)
+		return fake.DeleteCommandStub(id, force, logFile)
 	}
 	if specificReturn {
 		return ret.result1
@@ -356,10 +405,10 @@ func (fake *FakeRuncBinary) DeleteCommandCallCount() int {
 	return len(fake.deleteCommandArgsForCall)
 }
 
-func (fake *FakeRuncBinary) DeleteCommandArgsForCall(i int) (string, string) {
+func (fake *FakeRuncBinary) DeleteCommandArgsForCall(i int) (string, bool, string) {
 	fake.deleteCommandMutex.RLock()
 	defer fake.deleteCommandMutex.RUnlock()
-	return fake.deleteCommandArgsForCall[i].id, fake.deleteCommandArgsForCall[i].logFile
+	return fake.deleteCommandArgsForCall[i].id, fake.deleteCommandArgsForCall[i].force, fake.deleteCommandArgsForCall[i].logFile
 }
 
 func (fake *FakeRuncBinary) DeleteCommandReturns(result1 *exec.Cmd) {
@@ -381,6 +430,201 @@ func (fake *FakeRuncBinary) DeleteCommandReturnsOnCall(i int, result1 *exec.Cmd)
 	}{result1}
 }
 
+func (fake *FakeRuncBinary) UpdateCommand(id string, logFile string) *exec.Cmd {
+	fake.updateCommandMutex.Lock()
+	ret, specificReturn := fake.updateCommandReturnsOnCall[len(fake.updateCommandArgsForCall)]
+	fake.updateCommandArgsForCall = append(fake.updateCommandArgsForCall, struct {
+		id      string
+		logFile string
+	}{id, logFile})
+	fake.recordInvocation("UpdateCommand", []interface{}{id, logFile})
+	fake.updateCommandMutex.Unlock()
+	if fake.UpdateCommandStub != nil {
+		return fake.UpdateCommandStub(id, logFile)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) UpdateCommandCallCount() int {
+	fake.updateCommandMutex.RLock()
+	defer fake.updateCommandMutex.RUnlock()
+	return len(fake.updateCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) UpdateCommandArgsForCall(i int) (string, string) {
+	fake.updateCommandMutex.RLock()
+	defer fake.updateCommandMutex.RUnlock()
+	return fake.updateCommandArgsForCall[i].id, fake.updateCommandArgsForCall[i].logFile
+}
+
+func (fake *FakeRuncBinary) UpdateCommandReturns(result1 *exec.Cmd) {
+	fake.UpdateCommandStub = nil
+	fake.updateCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) UpdateCommandReturnsOnCall(i int, result1 *exec.Cmd) {
+	fake.UpdateCommandStub = nil
+	if fake.updateCommandReturnsOnCall == nil {
+		fake.updateCommandReturnsOnCall = make(map[int]struct {
+			result1 *exec.Cmd
+		})
+	}
+	fake.updateCommandReturnsOnCall[i] = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) ListCommand(logFile string) *exec.Cmd {
+	fake.listCommandMutex.Lock()
+	ret, specificReturn := fake.listCommandReturnsOnCall[len(fake.listCommandArgsForCall)]
+	fake.listCommandArgsForCall = append(fake.listCommandArgsForCall, struct {
+		logFile string
+	}{logFile})
+	fake.recordInvocation("ListCommand", []interface{}{logFile})
+	fake.listCommandMutex.Unlock()
+	if fake.ListCommandStub != nil {
+		return fake.ListCommandStub(logFile)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.listCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) ListCommandCallCount() int {
+	fake.listCommandMutex.RLock()
+	defer fake.listCommandMutex.RUnlock()
+	return len(fake.listCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) ListCommandArgsForCall(i int) string {
+	fake.listCommandMutex.RLock()
+	defer fake.listCommandMutex.RUnlock()
+	return fake.listCommandArgsForCall[i].logFile
+}
+
+func (fake *FakeRuncBinary) ListCommandReturns(result1 *exec.Cmd) {
+	fake.ListCommandStub = nil
+	fake.listCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) ListCommandReturnsOnCall(i int, result1 *exec.Cmd) {
+	fake.ListCommandStub = nil
+	if fake.listCommandReturnsOnCall == nil {
+		fake.listCommandReturnsOnCall = make(map[int]struct {
+			result1 *exec.Cmd
+		})
+	}
+	fake.listCommandReturnsOnCall[i] = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) PauseCommand(id string, logFile string) *exec.Cmd {
+	fake.pauseCommandMutex.Lock()
+	ret, specificReturn := fake.pauseCommandReturnsOnCall[len(fake.pauseCommandArgsForCall)]
+	fake.pauseCommandArgsForCall = append(fake.pauseCommandArgsForCall, struct {
+		id      string
+		logFile string
+	}{id, logFile})
+	fake.recordInvocation("PauseCommand", []interface{}{id, logFile})
+	fake.pauseCommandMutex.Unlock()
+	if fake.PauseCommandStub != nil {
+		return fake.PauseCommandStub(id, logFile)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pauseCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) PauseCommandCallCount() int {
+	fake.pauseCommandMutex.RLock()
+	defer fake.pauseCommandMutex.RUnlock()
+	return len(fake.pauseCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) PauseCommandArgsForCall(i int) (string, string) {
+	fake.pauseCommandMutex.RLock()
+	defer fake.pauseCommandMutex.RUnlock()
+	return fake.pauseCommandArgsForCall[i].id, fake.pauseCommandArgsForCall[i].logFile
+}
+
+func (fake *FakeRuncBinary) PauseCommandReturns(result1 *exec.Cmd) {
+	fake.PauseCommandStub = nil
+	fake.pauseCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) PauseCommandReturnsOnCall(i int, result1 *exec.Cmd) {
+	fake.PauseCommandStub = nil
+	if fake.pauseCommandReturnsOnCall == nil {
+		fake.pauseCommandReturnsOnCall = make(map[int]struct {
+			result1 *exec.Cmd
+		})
+	}
+	fake.pauseCommandReturnsOnCall[i] = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) ResumeCommand(id string, logFile string) *exec.Cmd {
+	fake.resumeCommandMutex.Lock()
+	ret, specificReturn := fake.resumeCommandReturnsOnCall[len(fake.resumeCommandArgsForCall)]
+	fake.resumeCommandArgsForCall = append(fake.resumeCommandArgsForCall, struct {
+		id      string
+		logFile string
+	}{id, logFile})
+	fake.recordInvocation("ResumeCommand", []interface{}{id, logFile})
+	fake.resumeCommandMutex.Unlock()
+	if fake.ResumeCommandStub != nil {
+		return fake.ResumeCommandStub(id, logFile)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.resumeCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) ResumeCommandCallCount() int {
+	fake.resumeCommandMutex.RLock()
+	defer fake.resumeCommandMutex.RUnlock()
+	return len(fake.resumeCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) ResumeCommandArgsForCall(i int) (string, string) {
+	fake.resumeCommandMutex.RLock()
+	defer fake.resumeCommandMutex.RUnlock()
+	return fake.resumeCommandArgsForCall[i].id, fake.resumeCommandArgsForCall[i].logFile
+}
+
+func (fake *FakeRuncBinary) ResumeCommandReturns(result1 *exec.Cmd) {
+	fake.ResumeCommandStub = nil
+	fake.resumeCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) ResumeCommandReturnsOnCall(i int, result1 *exec.Cmd) {
+	fake.ResumeCommandStub = nil
+	if fake.resumeCommandReturnsOnCall == nil {
+		fake.resumeCommandReturnsOnCall = make(map[int]struct {
+			result1 *exec.Cmd
+		})
+	}
+	fake.resumeCommandReturnsOnCall[i] = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
 func (fake *FakeRuncBinary) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -396,6 +640,14 @@ func (fake *FakeRuncBinary) Invocations() map[string][][]interface{} {
 	defer fake.killCommandMutex.RUnlock()
 	fake.deleteCommandMutex.RLock()
 	defer fake.deleteCommandMutex.RUnlock()
+	fake.updateCommandMutex.RLock()
+	defer fake.updateCommandMutex.RUnlock()
+	fake.listCommandMutex.RLock()
+	defer fake.listCommandMutex.RUnlock()
+	fake.pauseCommandMutex.RLock()
+	defer fake.pauseCommandMutex.RUnlock()
+	fake.resumeCommandMutex.RLock()
+	defer fake.resumeCommandMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -28,6 +28,10 @@ type runcStats struct {
 		MemoryStats struct {
 			Stats garden.ContainerMemoryStat `json:"raw"`
 		} `json:"memory"`
+		PidsStats struct {
+			Current uint64 `json:"current"`
+			Limit   uint64 `json:"limit"`
+		} `json:"pids"`
 	}
 }
 
@@ -65,6 +69,10 @@ func (r *Statser) Stats(log lager.Logger, id string) (gardener.ActualContainerMe
 			System: data.Data.CPUStats.CPUUsage.System,
 			User:   data.Data.CPUStats.CPUUsage.User,
 		},
+		Pid: garden.ContainerPidStat{
+			Current: data.Data.PidsStats.Current,
+			Max:     data.Data.PidsStats.Limit,
+		},
 	}
 
 	stats.Memory.TotalUsageTowardLimit = stats.Memory.TotalRss + (stats.Memory.TotalCache - stats.Memory.TotalInactiveFile)
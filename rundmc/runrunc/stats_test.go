@@ -92,6 +92,10 @@ var _ = Describe("Stats", func() {
 								"hierarchical_memsw_limit": 31,
 								"total_swap": 32
 							}
+						},
+						"pids": {
+							"current": 4,
+							"limit": 128
 						}
 					}
 				}`))
@@ -148,6 +152,16 @@ var _ = Describe("Stats", func() {
 			}))
 		})
 
+		It("parses the pid stats", func() {
+			stats, err := statser.Stats(logger, "some-handle")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stats.Pid).To(Equal(garden.ContainerPidStat{
+				Current: 4,
+				Max:     128,
+			}))
+		})
+
 		It("forwards logs from runc", func() {
 			_, err := statser.Stats(logger, "some-container")
 			Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,88 @@
+package runrunc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os/exec"
+
+	"code.cloudfoundry.org/commandrunner/fake_command_runner"
+	. "code.cloudfoundry.org/commandrunner/fake_command_runner/matchers"
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/runrunc/runruncfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+var _ = Describe("Update", func() {
+	var (
+		commandRunner *fake_command_runner.FakeCommandRunner
+		runner        *fakes.FakeRuncCmdRunner
+		runcBinary    *fakes.FakeRuncBinary
+		logger        *lagertest.TestLogger
+
+		updater *runrunc.Updater
+
+		resources       specs.LinuxResources
+		passedResources specs.LinuxResources
+	)
+
+	BeforeEach(func() {
+		runcBinary = new(fakes.FakeRuncBinary)
+		commandRunner = fake_command_runner.New()
+		runner = new(fakes.FakeRuncCmdRunner)
+		logger = lagertest.NewTestLogger("test")
+
+		updater = runrunc.NewUpdater(runner, runcBinary)
+
+		limit := int64(1024)
+		shares := uint64(512)
+		resources = specs.LinuxResources{
+			Memory: &specs.LinuxMemory{Limit: &limit, Swap: &limit},
+			CPU:    &specs.LinuxCPU{Shares: &shares},
+		}
+
+		runcBinary.UpdateCommandStub = func(id, logFile string) *exec.Cmd {
+			return exec.Command("funC", "--log", logFile, "update", "-r", "-", id)
+		}
+
+		commandRunner.WhenRunning(fake_command_runner.CommandSpec{
+			Path: "funC",
+		}, func(cmd *exec.Cmd) error {
+			stdin, err := ioutil.ReadAll(cmd.Stdin)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Unmarshal(stdin, &passedResources)).To(Succeed())
+			return nil
+		})
+
+		runner.RunAndLogStub = func(_ lager.Logger, fn runrunc.LoggingCmd) error {
+			return commandRunner.Run(fn("potato.log"))
+		}
+	})
+
+	It("runs 'runc update' using the logging runner", func() {
+		Expect(updater.Update(logger, "some-container", resources)).To(Succeed())
+		Expect(commandRunner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+			Path: "funC",
+			Args: []string{"--log", "potato.log", "update", "-r", "-", "some-container"},
+		}))
+	})
+
+	It("passes the resources as JSON on stdin", func() {
+		Expect(updater.Update(logger, "some-container", resources)).To(Succeed())
+		Expect(passedResources).To(Equal(resources))
+	})
+
+	Context("when running runc update fails", func() {
+		BeforeEach(func() {
+			runner.RunAndLogReturns(errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			Expect(updater.Update(logger, "some-container", resources)).To(MatchError("runc update: boom"))
+		})
+	})
+})
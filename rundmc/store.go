@@ -3,8 +3,13 @@ package rundmc
 import (
 	"strings"
 	"sync"
+
+	"code.cloudfoundry.org/guardian/gardener"
 )
 
+// outOfMemoryEvent is the event runc reports when a container hits its memory limit
+const outOfMemoryEvent = "Out of memory"
+
 //go:generate counterfeiter . Properties
 
 type Properties interface {
@@ -13,13 +18,15 @@ type Properties interface {
 }
 
 type events struct {
-	props Properties
-	mu    sync.Mutex
+	props     Properties
+	publisher gardener.EventPublisher
+	mu        sync.Mutex
 }
 
-func NewEventStore(props Properties) *events {
+func NewEventStore(props Properties, publisher gardener.EventPublisher) *events {
 	return &events{
-		props: props,
+		props:     props,
+		publisher: publisher,
 	}
 }
 
@@ -29,6 +36,11 @@ func (e *events) OnEvent(handle, event string) error {
 
 	events := append(e.Events(handle), event)
 	e.props.Set(handle, "rundmc.events", strings.Join(events, ","))
+
+	if event == outOfMemoryEvent {
+		e.publisher.Publish(gardener.Event{Kind: gardener.OutOfMemoryEvent, Handle: handle})
+	}
+
 	return nil
 }
 
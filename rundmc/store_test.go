@@ -3,6 +3,8 @@ package rundmc_test
 import (
 	"fmt"
 
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/gardener/gardenerfakes"
 	"code.cloudfoundry.org/guardian/rundmc"
 	fakes "code.cloudfoundry.org/guardian/rundmc/rundmcfakes"
 	. "github.com/onsi/ginkgo"
@@ -11,15 +13,17 @@ import (
 
 var _ = Describe("Event Store", func() {
 	var (
-		props *fakes.FakeProperties
+		props     *fakes.FakeProperties
+		publisher *gardenerfakes.FakeEventPublisher
 	)
 
 	BeforeEach(func() {
 		props = new(fakes.FakeProperties)
+		publisher = new(gardenerfakes.FakeEventPublisher)
 	})
 
 	It("stashes events on the property manager under the 'rundmc.events' key", func() {
-		events := rundmc.NewEventStore(props)
+		events := rundmc.NewEventStore(props, publisher)
 		events.OnEvent("foo", "bar")
 
 		Expect(props.SetCallCount()).To(Equal(1))
@@ -33,7 +37,7 @@ var _ = Describe("Event Store", func() {
 	It("stashes further events on the same property using a CSV for the value", func() {
 		props.GetReturns("bar", true)
 
-		events := rundmc.NewEventStore(props)
+		events := rundmc.NewEventStore(props, publisher)
 		events.OnEvent("foo", "baz")
 
 		Expect(props.SetCallCount()).To(Equal(1))
@@ -43,12 +47,30 @@ var _ = Describe("Event Store", func() {
 		Expect(value).To(Equal("bar,baz"))
 	})
 
+	It("publishes out of memory events", func() {
+		events := rundmc.NewEventStore(props, publisher)
+		events.OnEvent("foo", "Out of memory")
+
+		Expect(publisher.PublishCallCount()).To(Equal(1))
+		Expect(publisher.PublishArgsForCall(0)).To(Equal(gardener.Event{
+			Kind:   gardener.OutOfMemoryEvent,
+			Handle: "foo",
+		}))
+	})
+
+	It("does not publish other events", func() {
+		events := rundmc.NewEventStore(props, publisher)
+		events.OnEvent("foo", "bar")
+
+		Expect(publisher.PublishCallCount()).To(Equal(0))
+	})
+
 	It("retrieves events from the property manager", func() {
 		props.GetStub = func(handle, key string) (string, bool) {
 			return fmt.Sprintf("%s,%s", handle, key), true
 		}
 
-		events := rundmc.NewEventStore(props)
+		events := rundmc.NewEventStore(props, publisher)
 		Expect(events.Events("some-container")).To(Equal([]string{
 			"some-container", "rundmc.events",
 		}))
@@ -57,12 +79,12 @@ var _ = Describe("Event Store", func() {
 	It("returns no events when the property hasn't been set or cant be retrieved", func() {
 		props.GetReturns("bar", false)
 
-		events := rundmc.NewEventStore(props)
+		events := rundmc.NewEventStore(props, publisher)
 		Expect(events.Events("some-container")).To(HaveLen(0))
 	})
 
 	It("returns no events when the property is empty", func() {
-		events := rundmc.NewEventStore(props)
+		events := rundmc.NewEventStore(props, publisher)
 		Expect(events.Events("some-container")).To(HaveLen(0))
 	})
 })